@@ -1,14 +1,26 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"log"
 	"net/http"
+	"os"
+	"strconv"
+	"time"
 
 	"github.com/UmutTKMN/go-backend/configs"
 	"github.com/UmutTKMN/go-backend/internal/app/handler"
 	"github.com/UmutTKMN/go-backend/internal/app/services"
 	"github.com/UmutTKMN/go-backend/internal/pkg/database"
+	"github.com/UmutTKMN/go-backend/internal/pkg/messaging"
 	"github.com/UmutTKMN/go-backend/internal/pkg/middleware"
+	"github.com/UmutTKMN/go-backend/internal/pkg/realtime"
+	"github.com/UmutTKMN/go-backend/internal/pkg/scanner"
+	"github.com/UmutTKMN/go-backend/internal/pkg/scheduler"
+	"github.com/UmutTKMN/go-backend/internal/pkg/signedurl"
+	"github.com/UmutTKMN/go-backend/internal/pkg/storage"
 	"github.com/gin-gonic/gin"
 )
 
@@ -20,22 +32,52 @@ func main() {
 	database.Init(config)
 
 	gin.SetMode(gin.DebugMode)
-	router := gin.Default()
+	router := gin.New()
+	// Akış uç noktasının access_token parametresi günlüğe yazılmadan önce URL'den çıkarılır
+	router.Use(middleware.StripQueryToken(), gin.Logger(), gin.Recovery())
 
 	// Güvenilir proxy'leri ayarla
 	router.SetTrustedProxies([]string{"127.0.0.1"})
 
+	// Dosya deposunu ve imzalı bağlantı üreticisini oluştur
+	fileStorage, err := newFileStorage()
+	if err != nil {
+		log.Fatal("Dosya deposu oluşturulamadı:", err)
+	}
+	appURL := getEnv("APP_URL", "http://localhost:"+config.AppPort)
+	urlSigner, err := newURLSigner(appURL, config.JWTKey)
+	if err != nil {
+		log.Fatal("İmzalı bağlantı üreticisi oluşturulamadı:", err)
+	}
+	fileScanner, err := newFileScanner()
+	if err != nil {
+		log.Fatal("Dosya tarayıcısı oluşturulamadı:", err)
+	}
+	if err := registerNotificationChannels(); err != nil {
+		log.Fatal("Bildirim kanalları oluşturulamadı:", err)
+	}
+	realtimeHub, err := newRealtimeHub(config)
+	if err != nil {
+		log.Fatal("Anlık olay dağıtımı başlatılamadı:", err)
+	}
+	services.SetRealtimeHub(realtimeHub)
+
 	// Servisleri oluştur
 	authService := services.NewAuthService(config.JWTKey)
 	userService := services.NewUserService()
+	avatarService := services.NewAvatarService(fileStorage, appURL)
+	deletionService := services.NewAccountDeletionService(fileStorage, accountDeletionGracePeriod())
 
 	// Handler'ları oluştur
 	userHandler := handler.NewUserHandler(userService, authService)
-	profileHandler := handler.NewProfileHandler(userService)
+	profileHandler := handler.NewProfileHandler(userService, avatarService, deletionService)
 
 	// API v1 grubu
 	v1 := router.Group("/api/v1")
 	{
+		// Rol ve Personel middleware tanımla
+		roleMiddleware := middleware.NewRoleMiddleware()
+
 		v1.GET("/", func(c *gin.Context) {
 			c.JSON(http.StatusOK, gin.H{
 				"message": "Hoş geldiniz!",
@@ -54,22 +96,85 @@ func main() {
 		// Kullanıcı endpoint'leri
 		users := v1.Group("/users")
 		{
-			users.GET("", userHandler.GetAllUsers)
-			users.GET("/:id", userHandler.GetUser)
-			users.PUT("/:id", userHandler.UpdateUser)
-			users.DELETE("/:id", userHandler.DeleteUser)
+			users.GET("", middleware.OptionalAuthMiddleware(), userHandler.GetAllUsers)
+			users.GET("/export", middleware.AuthMiddleware(), roleMiddleware.RequireAdmin(), userHandler.ExportUsers)
+			users.GET("/:id", middleware.OptionalAuthMiddleware(), userHandler.GetUser)
+			users.PUT("/:id", middleware.AuthMiddleware(), roleMiddleware.RequireAdmin(), userHandler.UpdateUser)
+			users.DELETE("/:id", middleware.AuthMiddleware(), roleMiddleware.RequireAdmin(), userHandler.DeleteUser)
+			users.POST("/:id/restore", middleware.AuthMiddleware(), roleMiddleware.RequireAdmin(), userHandler.RestoreUser)
 		}
 
 		// Profil yönetimi rotaları
+		documentHandler := handler.NewDocumentHandler(fileStorage, urlSigner, fileScanner)
+		notificationHandler := handler.NewNotificationHandler()
+		preferenceHandler := handler.NewPreferenceHandler()
+		privacyHandler := handler.NewPrivacyHandler()
+		dataExportHandler := handler.NewDataExportHandler(fileStorage, urlSigner)
 		profileGroup := v1.Group("/profile")
 		profileGroup.Use(middleware.AuthMiddleware())
 		{
 			profileGroup.GET("", profileHandler.GetProfile)
 			profileGroup.PUT("", profileHandler.UpdateProfile)
+			profileGroup.DELETE("", profileHandler.DeleteAccount)
+			profileGroup.PUT("/avatar", profileHandler.UploadAvatar)
+			profileGroup.DELETE("/avatar", profileHandler.DeleteAvatar)
+
+			// Belgeler
+			profileGroup.GET("/documents", documentHandler.GetMyDocuments)
+			profileGroup.POST("/documents", documentHandler.UploadDocument)
+			profileGroup.GET("/documents/:id", documentHandler.GetMyDocument)
+			profileGroup.GET("/documents/:id/download-url", documentHandler.GetDownloadURL)
+			profileGroup.DELETE("/documents/:id", documentHandler.DeleteMyDocument)
+
+			// Bildirimler
+			profileGroup.GET("/notifications", notificationHandler.GetNotifications)
+			profileGroup.GET("/notifications/unread-count", notificationHandler.GetUnreadCounts)
+			profileGroup.POST("/notifications/read-all", notificationHandler.MarkAllRead)
+			profileGroup.GET("/notifications/:id", notificationHandler.GetNotification)
+			profileGroup.POST("/notifications/:id/read", notificationHandler.MarkRead)
+			profileGroup.DELETE("/notifications/:id", notificationHandler.DeleteNotification)
+			profileGroup.GET("/notification-preferences", notificationHandler.GetPreferences)
+			profileGroup.PUT("/notification-preferences", notificationHandler.UpdatePreferences)
+
+			// Tercihler
+			profileGroup.GET("/preferences", preferenceHandler.GetPreferences)
+			profileGroup.PUT("/preferences", preferenceHandler.UpdatePreferences)
+			profileGroup.GET("/preferences/:key", preferenceHandler.GetPreference)
+			profileGroup.PUT("/preferences/:key", preferenceHandler.UpdatePreference)
+			profileGroup.DELETE("/preferences/:key", preferenceHandler.DeletePreference)
+
+			// Gizlilik ayarları
+			profileGroup.GET("/privacy", privacyHandler.GetSettings)
+			profileGroup.PUT("/privacy", privacyHandler.UpdateSettings)
+
+			// Kişisel veri dışa aktarma
+			profileGroup.POST("/export", dataExportHandler.RequestExport)
+			profileGroup.GET("/exports", dataExportHandler.GetExports)
+			profileGroup.GET("/exports/:id/download-url", dataExportHandler.GetDownloadURL)
 		}
 
-		// Rol ve Personel middleware tanımla
-		roleMiddleware := middleware.NewRoleMiddleware()
+		// İmzalı bağlantı ile dosya indirme; yetki kontrolü imza ile yapılır
+		v1.GET("/files/documents/:id", documentHandler.DownloadSignedDocument)
+		v1.GET("/files/exports/:id", dataExportHandler.DownloadSignedExport)
+
+		// Profil fotoğrafları herkese açıktır
+		v1.GET("/files/avatars/:userId/:token/:size", profileHandler.ServeAvatar)
+
+		// Bildirimler ve anlık olaylar için SSE/WebSocket akışı
+		streamHandler := handler.NewStreamHandler(realtimeHub)
+		v1.GET("/stream", middleware.QueryTokenMiddleware(), middleware.AuthMiddleware(), streamHandler.Stream)
+
+		// Belge doğrulama rotaları
+		documentGroup := v1.Group("/documents")
+		documentGroup.Use(middleware.AuthMiddleware(), roleMiddleware.RequireStaff())
+		{
+			documentGroup.GET("/queue", documentHandler.GetReviewQueue)
+			documentGroup.GET("/:id", documentHandler.GetDocumentForReview)
+			documentGroup.GET("/:id/download-url", documentHandler.GetReviewDownloadURL)
+			documentGroup.POST("/:id/approve", documentHandler.ApproveDocument)
+			documentGroup.POST("/:id/reject", documentHandler.RejectDocument)
+			documentGroup.POST("/:id/restore", roleMiddleware.RequireAdmin(), documentHandler.RestoreDocument)
+		}
 
 		// Rol yönetimi rotaları
 		roleHandler := handler.NewRoleHandler()
@@ -88,6 +193,7 @@ func main() {
 				adminRoleGroup.POST("/remove", roleHandler.RemoveRoleFromUser)
 				adminRoleGroup.PUT("/:id", roleHandler.UpdateRole)
 				adminRoleGroup.DELETE("/:id", roleHandler.DeleteRole)
+				adminRoleGroup.POST("/:id/restore", roleHandler.RestoreRole)
 			}
 
 			// Kullanıcı rolleri
@@ -100,11 +206,15 @@ func main() {
 		staffGroup := v1.Group("/staff")
 		staffGroup.Use(middleware.AuthMiddleware())
 		{
+			// Personel kaydı gerektiren rotalar (kontrol handler'da yapılır)
+			staffGroup.GET("/me/follow-ups", staffHandler.GetMyFollowUps)
+
 			// Yönetici gerektiren rotalar
 			managerStaffGroup := staffGroup.Group("")
 			managerStaffGroup.Use(roleMiddleware.RequireManager())
 			{
 				managerStaffGroup.GET("", staffHandler.GetAllStaff)
+				managerStaffGroup.GET("/export", staffHandler.ExportStaff)
 				managerStaffGroup.GET("/:id", staffHandler.GetStaffByID)
 				managerStaffGroup.GET("/user/:id", staffHandler.GetStaffByUserID)
 				managerStaffGroup.GET("/department/:department", staffHandler.GetStaffByDepartment)
@@ -117,16 +227,323 @@ func main() {
 			adminStaffGroup.Use(roleMiddleware.RequireAdmin())
 			{
 				adminStaffGroup.POST("", staffHandler.CreateStaff)
+				adminStaffGroup.POST("/import", staffHandler.ImportStaff)
 				adminStaffGroup.PUT("/:id", staffHandler.UpdateStaff)
 				adminStaffGroup.PUT("/:id/position", staffHandler.UpdateStaffPosition)
 				adminStaffGroup.PUT("/:id/manager", staffHandler.UpdateStaffManager)
 				adminStaffGroup.DELETE("/:id", staffHandler.DeleteStaff)
+				adminStaffGroup.POST("/:id/restore", staffHandler.RestoreStaff)
 			}
 		}
+
+		// İzin yönetimi rotaları
+		leaveHandler := handler.NewLeaveHandler()
+		leaveGroup := v1.Group("/leave")
+		leaveGroup.Use(middleware.AuthMiddleware())
+		{
+			leaveGroup.GET("/types", leaveHandler.GetAllLeaveTypes)
+			leaveGroup.GET("/balances", leaveHandler.GetMyBalances)
+			leaveGroup.GET("/requests", leaveHandler.GetMyLeaveRequests)
+			leaveGroup.POST("/requests", leaveHandler.CreateLeaveRequest)
+			leaveGroup.POST("/requests/:id/cancel", leaveHandler.CancelLeaveRequest)
+
+			// Onay yetkisi servis katmanında personelin yöneticisi üzerinden kontrol edilir
+			leaveGroup.GET("/approvals", leaveHandler.GetPendingApprovals)
+			leaveGroup.POST("/requests/:id/approve", leaveHandler.ApproveLeaveRequest)
+			leaveGroup.POST("/requests/:id/reject", leaveHandler.RejectLeaveRequest)
+
+			// Yönetici gerektiren rotalar
+			managerLeaveGroup := leaveGroup.Group("")
+			managerLeaveGroup.Use(roleMiddleware.RequireManager())
+			{
+				managerLeaveGroup.GET("/calendar/:department", leaveHandler.GetDepartmentCalendar)
+				managerLeaveGroup.GET("/staff/:id/balances", leaveHandler.GetStaffBalances)
+			}
+
+			// Admin gerektiren rotalar
+			adminLeaveGroup := leaveGroup.Group("/types")
+			adminLeaveGroup.Use(roleMiddleware.RequireAdmin())
+			{
+				adminLeaveGroup.POST("", leaveHandler.CreateLeaveType)
+				adminLeaveGroup.PUT("/:id", leaveHandler.UpdateLeaveType)
+				adminLeaveGroup.DELETE("/:id", leaveHandler.DeleteLeaveType)
+			}
+		}
+
+		// Yetki devri rotaları
+		delegationHandler := handler.NewDelegationHandler()
+		delegationGroup := v1.Group("/delegations")
+		delegationGroup.Use(middleware.AuthMiddleware())
+		{
+			delegationGroup.GET("", delegationHandler.GetMyDelegations)
+			delegationGroup.POST("", delegationHandler.CreateDelegation)
+			delegationGroup.GET("/:id", delegationHandler.GetDelegationByID)
+			delegationGroup.POST("/:id/cancel", delegationHandler.CancelDelegation)
+		}
+
+		// Kullanıcı talebi (destek kaydı) rotaları
+		requestHandler := handler.NewRequestHandler(fileStorage)
+		requestGroup := v1.Group("/requests")
+		requestGroup.Use(middleware.AuthMiddleware())
+		{
+			requestGroup.POST("", requestHandler.SubmitRequest)
+			requestGroup.GET("", requestHandler.GetMyRequests)
+			requestGroup.GET("/:id", requestHandler.GetRequest)
+			requestGroup.POST("/:id/close", requestHandler.CloseRequest)
+
+			// Yorumlar; iç notlar ve yetki kontrolü handler'da yapılır
+			requestGroup.GET("/:id/comments", requestHandler.GetComments)
+			requestGroup.POST("/:id/comments", requestHandler.AddComment)
+			requestGroup.PUT("/:id/comments/:commentId", requestHandler.EditComment)
+			requestGroup.GET("/:id/comments/:commentId/history", requestHandler.GetCommentHistory)
+			requestGroup.GET("/:id/comments/:commentId/attachments/:attachmentId", requestHandler.DownloadCommentAttachment)
+
+			// Personel kaydı gerektiren rotalar (kontrol handler'da yapılır)
+			requestGroup.GET("/queues", requestHandler.GetQueueCounts)
+			requestGroup.GET("/queues/:status", requestHandler.GetQueue)
+			requestGroup.POST("/:id/claim", requestHandler.ClaimRequest)
+			requestGroup.PUT("/:id/status", requestHandler.UpdateRequestStatus)
+			requestGroup.PUT("/:id/follow-up", requestHandler.SetFollowUp)
+
+			// Yönetici gerektiren rotalar
+			managerRequestGroup := requestGroup.Group("")
+			managerRequestGroup.Use(roleMiddleware.RequireManager())
+			{
+				managerRequestGroup.POST("/:id/assign", requestHandler.AssignRequest)
+			}
+
+			// Admin gerektiren rotalar
+			adminRequestGroup := requestGroup.Group("")
+			adminRequestGroup.Use(roleMiddleware.RequireAdmin())
+			{
+				adminRequestGroup.DELETE("/:id", requestHandler.DeleteRequest)
+				adminRequestGroup.POST("/:id/restore", requestHandler.RestoreRequest)
+			}
+		}
+
+		// Talep yönlendirme kuralı rotaları
+		routingHandler := handler.NewRoutingHandler()
+		routingGroup := v1.Group("/routing/rules")
+		routingGroup.Use(middleware.AuthMiddleware())
+		{
+			routingGroup.GET("", roleMiddleware.RequireManager(), routingHandler.GetAllRules)
+
+			// Admin gerektiren rotalar
+			adminRoutingGroup := routingGroup.Group("")
+			adminRoutingGroup.Use(roleMiddleware.RequireAdmin())
+			{
+				adminRoutingGroup.POST("", routingHandler.CreateRule)
+				adminRoutingGroup.PUT("/:id", routingHandler.UpdateRule)
+				adminRoutingGroup.DELETE("/:id", routingHandler.DeleteRule)
+			}
+		}
+
+		// SLA politikası ve mesai takvimi rotaları
+		slaHandler := handler.NewSLAHandler()
+		slaGroup := v1.Group("/sla")
+		slaGroup.Use(middleware.AuthMiddleware())
+		{
+			// Yönetici gerektiren rotalar
+			managerSLAGroup := slaGroup.Group("")
+			managerSLAGroup.Use(roleMiddleware.RequireManager())
+			{
+				managerSLAGroup.GET("/policies", slaHandler.GetAllPolicies)
+				managerSLAGroup.GET("/policies/:id", slaHandler.GetPolicyByID)
+				managerSLAGroup.GET("/calendars", slaHandler.GetAllCalendars)
+			}
+
+			// Admin gerektiren rotalar
+			adminSLAGroup := slaGroup.Group("")
+			adminSLAGroup.Use(roleMiddleware.RequireAdmin())
+			{
+				adminSLAGroup.POST("/policies", slaHandler.CreatePolicy)
+				adminSLAGroup.PUT("/policies/:id", slaHandler.UpdatePolicy)
+				adminSLAGroup.DELETE("/policies/:id", slaHandler.DeletePolicy)
+				adminSLAGroup.POST("/calendars", slaHandler.CreateCalendar)
+				adminSLAGroup.PUT("/calendars/:id", slaHandler.UpdateCalendar)
+				adminSLAGroup.DELETE("/calendars/:id", slaHandler.DeleteCalendar)
+				adminSLAGroup.POST("/calendars/:id/holidays", slaHandler.AddHoliday)
+				adminSLAGroup.DELETE("/calendars/:id/holidays/:holidayId", slaHandler.RemoveHoliday)
+			}
+		}
+
+		// Yönetim rotaları
+		templateHandler := handler.NewTemplateHandler()
+		announcementHandler := handler.NewAnnouncementHandler()
+		adminGroup := v1.Group("/admin")
+		adminGroup.Use(middleware.AuthMiddleware(), roleMiddleware.RequireAdmin())
+		{
+			adminGroup.GET("/templates", templateHandler.GetAllTemplates)
+			adminGroup.GET("/templates/:name/:language", templateHandler.GetTemplate)
+			adminGroup.PUT("/templates/:name/:language", templateHandler.UpdateTemplate)
+			adminGroup.DELETE("/templates/:name/:language", templateHandler.DeleteTemplate)
+			adminGroup.POST("/templates/:name/:language/preview", templateHandler.PreviewTemplate)
+
+			adminGroup.GET("/announcements", announcementHandler.GetAnnouncements)
+			adminGroup.POST("/announcements", announcementHandler.CreateAnnouncement)
+			adminGroup.GET("/announcements/:id", announcementHandler.GetAnnouncement)
+			adminGroup.GET("/announcements/:id/stats", announcementHandler.GetAnnouncementStats)
+			adminGroup.POST("/announcements/:id/cancel", announcementHandler.CancelAnnouncement)
+		}
 	}
 
+	// Arka plan işlerini başlat
+	jobs := scheduler.New()
+	jobs.Every("leave-status-sync", time.Hour, services.NewLeaveService().SyncLeaveStatuses)
+	jobs.Every("sla-escalation", 5*time.Minute, services.NewSLAService().EscalateBreaches)
+	jobs.Every("request-follow-ups", time.Hour, services.NewRequestService().ProcessDueFollowUps)
+	jobs.Every("request-routing", 5*time.Minute, services.NewRoutingService().RouteRequests)
+	documentService := services.NewDocumentService(fileStorage, urlSigner, fileScanner)
+	jobs.Every("document-expiry", 24*time.Hour, documentService.FlagExpiringDocuments)
+	jobs.Every("document-scan", 15*time.Minute, documentService.RescanDocuments)
+	jobs.Every("notification-delivery", time.Minute, services.NewNotificationService().ProcessDeliveries)
+	jobs.Every("announcement-dispatch", time.Minute, services.NewAnnouncementService().DispatchAnnouncements)
+	dataExportService := services.NewDataExportService(fileStorage, urlSigner)
+	jobs.Every("data-export", time.Minute, dataExportService.ProcessExports)
+	jobs.Every("data-export-purge", time.Hour, dataExportService.PurgeExpiredExports)
+	jobs.Every("account-deletion", time.Hour, deletionService.ProcessDeletions)
+	retentionService := services.NewRetentionService(fileStorage, softDeleteRetention(), deletionService)
+	jobs.Every("soft-delete-purge", 24*time.Hour, retentionService.PurgeDeleted)
+	jobs.Start(context.Background())
+
 	// Sunucuyu başlat
 	if err := router.Run(":" + config.AppPort); err != nil {
 		log.Fatal("Sunucu başlatılamadı:", err)
 	}
 }
+
+// newFileStorage STORAGE_DRIVER ayarına göre yerel veya S3 uyumlu dosya deposunu oluşturur
+func newFileStorage() (storage.Storage, error) {
+	switch driver := getEnv("STORAGE_DRIVER", "local"); driver {
+	case "local":
+		return storage.NewLocalStorage(getEnv("STORAGE_LOCAL_PATH", "./storage"))
+	case "s3":
+		useSSL, _ := strconv.ParseBool(getEnv("S3_USE_SSL", "true"))
+		return storage.NewS3Storage(context.Background(), storage.S3Config{
+			Endpoint:  os.Getenv("S3_ENDPOINT"),
+			AccessKey: os.Getenv("S3_ACCESS_KEY"),
+			SecretKey: os.Getenv("S3_SECRET_KEY"),
+			Bucket:    getEnv("S3_BUCKET", "go-backend"),
+			Region:    os.Getenv("S3_REGION"),
+			UseSSL:    useSSL,
+		})
+	default:
+		return nil, fmt.Errorf("bilinmeyen depolama sürücüsü: %s", driver)
+	}
+}
+
+// newURLSigner SIGNED_URL_SECRET ile imzalı indirme bağlantısı üreticisini oluşturur.
+// Anahtar zorunludur ve JWT anahtarıyla aynı olamaz.
+func newURLSigner(appURL, jwtKey string) (*signedurl.Signer, error) {
+	secret := os.Getenv("SIGNED_URL_SECRET")
+	if secret == "" {
+		return nil, errors.New("SIGNED_URL_SECRET tanımlı değil")
+	}
+	if secret == jwtKey {
+		return nil, errors.New("SIGNED_URL_SECRET, JWT anahtarından farklı olmalıdır")
+	}
+	return signedurl.New(secret, appURL), nil
+}
+
+// newFileScanner SCANNER_DRIVER ayarına göre yüklenen dosyalar için tarayıcıyı oluşturur.
+// "none" seçildiğinde nil döner ve belgeler taranmadan kullanıma açılır.
+func newFileScanner() (scanner.Scanner, error) {
+	switch driver := getEnv("SCANNER_DRIVER", "none"); driver {
+	case "none":
+		return nil, nil
+	case "clamav":
+		return scanner.NewClamAV(getEnv("CLAMAV_ADDRESS", "tcp://127.0.0.1:3310"), scanner.DefaultClamAVTimeout)
+	default:
+		return nil, fmt.Errorf("bilinmeyen tarayıcı sürücüsü: %s", driver)
+	}
+}
+
+// newRealtimeHub REALTIME_BRIDGE ayarına göre anlık olay hub'ını oluşturur. "postgres" seçildiğinde olaylar
+// LISTEN/NOTIFY ile tüm uygulama örneklerine dağıtılır; "none" olayları yalnızca bu örnekteki istemcilere iletir.
+func newRealtimeHub(config *configs.Config) (*realtime.Hub, error) {
+	hub := realtime.NewHub()
+	switch driver := getEnv("REALTIME_BRIDGE", "postgres"); driver {
+	case "none":
+	case "postgres":
+		sqlDB, err := database.DB.DB()
+		if err != nil {
+			return nil, err
+		}
+		bridge := realtime.NewPostgresBridge(database.DSN(config), getEnv("REALTIME_CHANNEL", realtime.DefaultPostgresChannel), sqlDB, hub)
+		hub.SetBroker(bridge)
+		go bridge.Run(context.Background())
+	default:
+		return nil, fmt.Errorf("bilinmeyen anlık olay köprüsü: %s", driver)
+	}
+	return hub, nil
+}
+
+// registerNotificationChannels ayarları tanımlı olan e-posta, SMS ve push kanallarını bildirim dağıtımına ekler
+func registerNotificationChannels() error {
+	if host := os.Getenv("SMTP_HOST"); host != "" {
+		port, _ := strconv.Atoi(getEnv("SMTP_PORT", "587"))
+		mailer, err := messaging.NewSMTPMailer(messaging.SMTPConfig{
+			Host:     host,
+			Port:     port,
+			Username: os.Getenv("SMTP_USER"),
+			Password: os.Getenv("SMTP_PASSWORD"),
+			From:     os.Getenv("SMTP_FROM"),
+		})
+		if err != nil {
+			return err
+		}
+		services.RegisterNotificationChannel(services.NewEmailChannel(mailer))
+	}
+
+	if url := os.Getenv("SMS_API_URL"); url != "" {
+		sender, err := messaging.NewHTTPSMSSender(messaging.HTTPSMSConfig{
+			URL:   url,
+			Token: os.Getenv("SMS_API_TOKEN"),
+			From:  os.Getenv("SMS_FROM"),
+		})
+		if err != nil {
+			return err
+		}
+		services.RegisterNotificationChannel(services.NewSMSChannel(sender))
+	}
+
+	if url := os.Getenv("PUSH_API_URL"); url != "" {
+		sender, err := messaging.NewHTTPPushSender(messaging.HTTPPushConfig{
+			URL:   url,
+			Token: os.Getenv("PUSH_API_TOKEN"),
+		})
+		if err != nil {
+			return err
+		}
+		services.RegisterNotificationChannel(services.NewPushChannel(sender))
+	}
+	return nil
+}
+
+// getEnv ortam değişkenini okur, tanımlı değilse varsayılan değeri döndürür
+func getEnv(key, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
+
+// accountDeletionGracePeriod ACCOUNT_DELETION_GRACE_DAYS ayarından hesap silme bekleme süresini okur
+func accountDeletionGracePeriod() time.Duration {
+	days, err := strconv.Atoi(getEnv("ACCOUNT_DELETION_GRACE_DAYS", "30"))
+	if err != nil || days <= 0 {
+		log.Printf("Geçersiz ACCOUNT_DELETION_GRACE_DAYS değeri, varsayılan kullanılıyor")
+		return services.DefaultAccountDeletionGracePeriod
+	}
+	return time.Duration(days) * 24 * time.Hour
+}
+
+// softDeleteRetention SOFT_DELETE_RETENTION_DAYS ayarından silinen kayıtların saklanma süresini okur
+func softDeleteRetention() time.Duration {
+	days, err := strconv.Atoi(getEnv("SOFT_DELETE_RETENTION_DAYS", "90"))
+	if err != nil || days <= 0 {
+		log.Printf("Geçersiz SOFT_DELETE_RETENTION_DAYS değeri, varsayılan kullanılıyor")
+		return services.DefaultSoftDeleteRetention
+	}
+	return time.Duration(days) * 24 * time.Hour
+}
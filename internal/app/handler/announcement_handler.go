@@ -0,0 +1,151 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/UmutTKMN/go-backend/internal/app/model"
+	"github.com/UmutTKMN/go-backend/internal/app/services"
+	"github.com/UmutTKMN/go-backend/internal/pkg/listquery"
+	"github.com/UmutTKMN/go-backend/internal/pkg/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+// AnnouncementHandler toplu duyurular için handler
+type AnnouncementHandler struct {
+	announcementService *services.AnnouncementService
+}
+
+// NewAnnouncementHandler yeni bir AnnouncementHandler örneği oluşturur
+func NewAnnouncementHandler() *AnnouncementHandler {
+	return &AnnouncementHandler{
+		announcementService: services.NewAnnouncementService(),
+	}
+}
+
+// announcementRequest duyuru oluşturma isteği
+type announcementRequest struct {
+	Subject     string                     `json:"subject" binding:"required"`
+	Content     string                     `json:"content" binding:"required"`
+	Category    string                     `json:"category"`
+	Importance  string                     `json:"importance"`
+	Audience    model.AnnouncementAudience `json:"audience"`
+	ScheduledAt *time.Time                 `json:"scheduled_at"`
+}
+
+// CreateAnnouncement duyuruyu oluşturur ve gönderime planlar. Dağıtım arka planda yapılır.
+func (h *AnnouncementHandler) CreateAnnouncement(c *gin.Context) {
+	userID, exists := middleware.GetCurrentUserID(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Kullanıcı bulunamadı"})
+		return
+	}
+
+	var request announcementRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Geçersiz veri: " + err.Error()})
+		return
+	}
+
+	announcement := model.Announcement{
+		Subject:    request.Subject,
+		Content:    request.Content,
+		Category:   request.Category,
+		Importance: request.Importance,
+		Audience:   request.Audience,
+		CreatedBy:  userID,
+	}
+	if request.ScheduledAt != nil {
+		announcement.ScheduledAt = *request.ScheduledAt
+	}
+
+	if err := h.announcementService.CreateAnnouncement(&announcement); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{"data": announcement, "message": "Duyuru gönderime planlandı"})
+}
+
+// GetAnnouncements duyuruları listeler. Filtreler: status. Arama: q. Sıralama: sort. Sayfalama: limit, cursor.
+func (h *AnnouncementHandler) GetAnnouncements(c *gin.Context) {
+	query, err := listquery.Parse(c.Request.URL.Query(), services.AnnouncementListSpec)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	page, err := h.announcementService.GetAnnouncements(c.Query("status"), query)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Duyurular getirilirken hata oluştu: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": page.Items,
+		"meta": gin.H{
+			"limit":       page.Limit,
+			"has_more":    page.HasMore,
+			"next_cursor": page.NextCursor,
+		},
+	})
+}
+
+// GetAnnouncement duyuruyu getirir
+func (h *AnnouncementHandler) GetAnnouncement(c *gin.Context) {
+	id, ok := announcementID(c)
+	if !ok {
+		return
+	}
+
+	announcement, err := h.announcementService.GetAnnouncement(id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": announcement})
+}
+
+// GetAnnouncementStats duyurunun teslim ve okunma istatistiklerini getirir
+func (h *AnnouncementHandler) GetAnnouncementStats(c *gin.Context) {
+	id, ok := announcementID(c)
+	if !ok {
+		return
+	}
+
+	stats, err := h.announcementService.GetAnnouncementStats(id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": stats})
+}
+
+// CancelAnnouncement gönderimi tamamlanmamış duyuruyu iptal eder
+func (h *AnnouncementHandler) CancelAnnouncement(c *gin.Context) {
+	id, ok := announcementID(c)
+	if !ok {
+		return
+	}
+
+	announcement, err := h.announcementService.CancelAnnouncement(id)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": announcement, "message": "Duyuru iptal edildi"})
+}
+
+// announcementID yoldaki duyuru ID'sini okur
+func announcementID(c *gin.Context) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Geçersiz duyuru ID'si"})
+		return 0, false
+	}
+	return uint(id), true
+}
@@ -0,0 +1,150 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/UmutTKMN/go-backend/internal/app/services"
+	"github.com/UmutTKMN/go-backend/internal/pkg/middleware"
+	"github.com/UmutTKMN/go-backend/internal/pkg/signedurl"
+	"github.com/UmutTKMN/go-backend/internal/pkg/storage"
+	"github.com/gin-gonic/gin"
+)
+
+// DataExportHandler kişisel veri dışa aktarma istekleri için handler
+type DataExportHandler struct {
+	exportService *services.DataExportService
+	signer        *signedurl.Signer
+}
+
+// NewDataExportHandler yeni bir DataExportHandler örneği oluşturur
+func NewDataExportHandler(store storage.Storage, signer *signedurl.Signer) *DataExportHandler {
+	return &DataExportHandler{
+		exportService: services.NewDataExportService(store, signer),
+		signer:        signer,
+	}
+}
+
+// RequestExport oturum açmış kullanıcının kişisel verilerinin dışa aktarılmasını başlatır.
+// Arşiv arka planda hazırlanır; hazır olduğunda kullanıcıya indirme bağlantısıyla bildirim gönderilir.
+func (h *DataExportHandler) RequestExport(c *gin.Context) {
+	userID, exists := middleware.GetCurrentUserID(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Kullanıcı bulunamadı"})
+		return
+	}
+
+	export, err := h.exportService.RequestExport(userID)
+	if err != nil {
+		if errors.Is(err, services.ErrDataExportInProgress) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Dışa aktarma isteği oluşturulamadı: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{
+		"data":    export,
+		"message": "Dışa aktarma isteğiniz alındı, arşiv hazır olduğunda bildirim alacaksınız",
+	})
+}
+
+// GetExports oturum açmış kullanıcının dışa aktarma isteklerini getirir
+func (h *DataExportHandler) GetExports(c *gin.Context) {
+	userID, exists := middleware.GetCurrentUserID(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Kullanıcı bulunamadı"})
+		return
+	}
+
+	exports, err := h.exportService.GetExports(userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Dışa aktarma istekleri getirilirken hata oluştu: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": exports})
+}
+
+// GetDownloadURL hazır arşiv için arşivin silinme zamanına kadar geçerli imzalı indirme bağlantısı üretir
+func (h *DataExportHandler) GetDownloadURL(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Geçersiz dışa aktarma ID'si"})
+		return
+	}
+
+	userID, exists := middleware.GetCurrentUserID(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Kullanıcı bulunamadı"})
+		return
+	}
+
+	export, err := h.exportService.GetExport(userID, uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	link, expiresAt, err := h.exportService.DownloadURL(c.Request.Context(), export)
+	if errors.Is(err, services.ErrDataExportUnavailable) {
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "İndirme bağlantısı oluşturulamadı: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": gin.H{"url": link, "expires_at": expiresAt}})
+}
+
+// DownloadSignedExport imzalı bağlantı ile dışa aktarma arşivini indirir; kimlik doğrulaması imza ile yapılır
+func (h *DataExportHandler) DownloadSignedExport(c *gin.Context) {
+	if err := h.signer.Verify(c.Request.URL.Path, c.Request.URL.Query()); err != nil {
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Geçersiz dışa aktarma ID'si"})
+		return
+	}
+
+	export, err := h.exportService.GetExportByID(uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	reader, err := h.exportService.OpenExport(c.Request.Context(), export)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		if errors.Is(err, services.ErrDataExportUnavailable) {
+			c.JSON(http.StatusGone, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Arşiv açılamadı: " + err.Error()})
+		return
+	}
+	defer reader.Close()
+
+	c.Header("Content-Type", "application/zip")
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, export.FileName))
+	c.Header("Content-Length", strconv.FormatInt(export.Size, 10))
+	c.Header("Cache-Control", "private, no-store")
+	c.Status(http.StatusOK)
+
+	if _, err := io.Copy(c.Writer, reader); err != nil {
+		log.Printf("Dışa aktarma arşivi gönderilemedi (%d): %v", export.ID, err)
+	}
+}
@@ -0,0 +1,198 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/UmutTKMN/go-backend/internal/app/model"
+	"github.com/UmutTKMN/go-backend/internal/app/services"
+	"github.com/gin-gonic/gin"
+)
+
+// DelegationHandler onay yetkisi devri işlemleri için handler
+type DelegationHandler struct {
+	delegationService *services.DelegationService
+	staffService      *services.StaffService
+	roleService       *services.RoleService
+	privacyService    *services.PrivacyService
+}
+
+// NewDelegationHandler yeni bir DelegationHandler örneği oluşturur
+func NewDelegationHandler() *DelegationHandler {
+	return &DelegationHandler{
+		delegationService: services.NewDelegationService(),
+		staffService:      services.NewStaffService(),
+		roleService:       services.NewRoleService(),
+		privacyService:    services.NewPrivacyService(),
+	}
+}
+
+// CreateDelegation yeni bir yetki devri oluşturur.
+// Varsayılan olarak yetkiyi oturum açmış personel devreder; adminler başka bir personel adına devir oluşturabilir.
+func (h *DelegationHandler) CreateDelegation(c *gin.Context) {
+	staff, ok := currentStaff(c, h.staffService)
+	if !ok {
+		return
+	}
+
+	var request struct {
+		DelegatorStaffID uint   `json:"delegator_staff_id"`
+		DelegateStaffID  uint   `json:"delegate_staff_id" binding:"required"`
+		StartDate        string `json:"start_date" binding:"required"`
+		EndDate          string `json:"end_date" binding:"required"`
+		Scope            string `json:"scope"`
+		Reason           string `json:"reason"`
+	}
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Geçersiz veri: " + err.Error()})
+		return
+	}
+
+	delegatorID := staff.ID
+	if request.DelegatorStaffID != 0 && request.DelegatorStaffID != staff.ID {
+		isAdmin, err := h.roleService.HasRole(staff.UserID, "Admin")
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Rol kontrolü yapılırken hata oluştu"})
+			return
+		}
+		if !isAdmin {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Başka bir personel adına yetki devri yalnızca adminler tarafından oluşturulabilir"})
+			return
+		}
+		delegatorID = request.DelegatorStaffID
+	}
+
+	startDate, err := time.Parse("2006-01-02", request.StartDate)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Geçersiz başlangıç tarihi, beklenen format: YYYY-MM-DD"})
+		return
+	}
+
+	endDate, err := time.Parse("2006-01-02", request.EndDate)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Geçersiz bitiş tarihi, beklenen format: YYYY-MM-DD"})
+		return
+	}
+
+	delegation := model.Delegation{
+		DelegatorStaffID: delegatorID,
+		DelegateStaffID:  request.DelegateStaffID,
+		StartDate:        startDate,
+		EndDate:          endDate,
+		Scope:            request.Scope,
+		Reason:           request.Reason,
+		CreatedByUserID:  staff.UserID,
+	}
+
+	if err := h.delegationService.CreateDelegation(&delegation); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Yetki devri oluşturulamadı: " + err.Error()})
+		return
+	}
+
+	viewer, ok := privacyViewer(c, h.privacyService)
+	if !ok {
+		return
+	}
+	data, err := services.FilterDelegation(&delegation, viewer)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"data": data, "message": "Yetki devri başarıyla oluşturuldu"})
+}
+
+// GetMyDelegations oturum açmış personelin verdiği ve aldığı yetki devirlerini getirir
+func (h *DelegationHandler) GetMyDelegations(c *gin.Context) {
+	staff, ok := currentStaff(c, h.staffService)
+	if !ok {
+		return
+	}
+
+	given, received, err := h.delegationService.GetDelegationsByStaff(staff.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Yetki devirleri getirilirken hata oluştu: " + err.Error()})
+		return
+	}
+
+	viewer, ok := privacyViewer(c, h.privacyService)
+	if !ok {
+		return
+	}
+	givenItems, err := services.FilterDelegations(given, viewer)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	receivedItems, err := services.FilterDelegations(received, viewer)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": gin.H{"given": givenItems, "received": receivedItems}})
+}
+
+// GetDelegationByID ID'ye göre yetki devri getirir.
+// Yalnızca devreden, devralan personel ve adminler görüntüleyebilir; diğerlerine bulunamadı yanıtı döner.
+func (h *DelegationHandler) GetDelegationByID(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Geçersiz yetki devri ID'si"})
+		return
+	}
+
+	delegation, err := h.delegationService.GetDelegationByID(uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	viewer, ok := privacyViewer(c, h.privacyService)
+	if !ok {
+		return
+	}
+	isParty := (delegation.Delegator != nil && delegation.Delegator.UserID == viewer.UserID) ||
+		(delegation.Delegate != nil && delegation.Delegate.UserID == viewer.UserID)
+	if viewer.UserID == 0 || (!isParty && !viewer.IsAdmin) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "yetki devri bulunamadı"})
+		return
+	}
+
+	data, err := services.FilterDelegation(delegation, viewer)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": data})
+}
+
+// CancelDelegation bir yetki devrini iptal eder
+func (h *DelegationHandler) CancelDelegation(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Geçersiz yetki devri ID'si"})
+		return
+	}
+
+	staff, ok := currentStaff(c, h.staffService)
+	if !ok {
+		return
+	}
+
+	isAdmin, err := h.roleService.HasRole(staff.UserID, "Admin")
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Rol kontrolü yapılırken hata oluştu"})
+		return
+	}
+
+	if err := h.delegationService.CancelDelegation(uint(id), staff.ID, isAdmin); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Yetki devri iptal edildi"})
+}
@@ -0,0 +1,409 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/UmutTKMN/go-backend/internal/app/model"
+	"github.com/UmutTKMN/go-backend/internal/app/services"
+	"github.com/UmutTKMN/go-backend/internal/pkg/listquery"
+	"github.com/UmutTKMN/go-backend/internal/pkg/middleware"
+	"github.com/UmutTKMN/go-backend/internal/pkg/scanner"
+	"github.com/UmutTKMN/go-backend/internal/pkg/signedurl"
+	"github.com/UmutTKMN/go-backend/internal/pkg/storage"
+	"github.com/gin-gonic/gin"
+)
+
+// DocumentHandler kullanıcı belgeleri için handler
+type DocumentHandler struct {
+	documentService *services.DocumentService
+	staffService    *services.StaffService
+	privacyService  *services.PrivacyService
+	signer          *signedurl.Signer
+}
+
+// NewDocumentHandler yeni bir DocumentHandler örneği oluşturur
+func NewDocumentHandler(store storage.Storage, signer *signedurl.Signer, fileScanner scanner.Scanner) *DocumentHandler {
+	return &DocumentHandler{
+		documentService: services.NewDocumentService(store, signer, fileScanner),
+		staffService:    services.NewStaffService(),
+		privacyService:  services.NewPrivacyService(),
+		signer:          signer,
+	}
+}
+
+// UploadDocument oturum açmış kullanıcının belgesini yükler.
+// multipart/form-data alanları: file, document_type, expiry_date (YYYY-MM-DD, isteğe bağlı).
+func (h *DocumentHandler) UploadDocument(c *gin.Context) {
+	userID, exists := middleware.GetCurrentUserID(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Kullanıcı bulunamadı"})
+		return
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Dosya bulunamadı: " + err.Error()})
+		return
+	}
+
+	document := model.UserDocument{
+		UserID:       userID,
+		DocumentType: c.PostForm("document_type"),
+		Notes:        c.PostForm("notes"),
+	}
+
+	if value := c.PostForm("expiry_date"); value != "" {
+		expiryDate, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Geçersiz son geçerlilik tarihi, beklenen format: YYYY-MM-DD"})
+			return
+		}
+		document.ExpiryDate = &expiryDate
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Dosya açılamadı: " + err.Error()})
+		return
+	}
+	defer file.Close()
+
+	upload := services.FileUpload{
+		FileName:    fileHeader.Filename,
+		ContentType: fileHeader.Header.Get("Content-Type"),
+		Size:        fileHeader.Size,
+		Reader:      file,
+	}
+
+	if err := h.documentService.UploadDocument(c.Request.Context(), &document, upload); err != nil {
+		if errors.Is(err, services.ErrDocumentInfected) {
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "Belge yüklenemedi: " + err.Error()})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Belge yüklenemedi: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"data": document, "message": "Belge başarıyla yüklendi"})
+}
+
+// GetMyDocuments oturum açmış kullanıcının belgelerini getirir
+func (h *DocumentHandler) GetMyDocuments(c *gin.Context) {
+	userID, exists := middleware.GetCurrentUserID(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Kullanıcı bulunamadı"})
+		return
+	}
+
+	documents, err := h.documentService.GetDocumentsByUser(userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Belgeler getirilirken hata oluştu: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": documents})
+}
+
+// GetMyDocument oturum açmış kullanıcının belgesini getirir
+func (h *DocumentHandler) GetMyDocument(c *gin.Context) {
+	document, ok := h.loadOwnDocument(c)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": document})
+}
+
+// GetDownloadURL belge için kısa süreli imzalı indirme bağlantısı üretir
+func (h *DocumentHandler) GetDownloadURL(c *gin.Context) {
+	document, ok := h.loadOwnDocument(c)
+	if !ok {
+		return
+	}
+
+	link, expiresAt, err := h.documentService.DownloadURL(c.Request.Context(), document)
+	if errors.Is(err, services.ErrDocumentUnavailable) {
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "İndirme bağlantısı oluşturulamadı: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": gin.H{"url": link, "expires_at": expiresAt}})
+}
+
+// DeleteMyDocument oturum açmış kullanıcının belgesini siler
+func (h *DocumentHandler) DeleteMyDocument(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Geçersiz belge ID'si"})
+		return
+	}
+
+	userID, exists := middleware.GetCurrentUserID(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Kullanıcı bulunamadı"})
+		return
+	}
+
+	if err := h.documentService.DeleteDocument(userID, uint(id)); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Belge başarıyla silindi"})
+}
+
+// GetReviewQueue personel için belge inceleme kuyruğunu getirir.
+// Filtreler: status (varsayılan pending), type, scan (varsayılan yalnızca taramadan geçenler), user_id. Arama: q. Sıralama: sort. Sayfalama: limit, cursor.
+// Yöneticiler include_deleted=true ile silinmiş belgeleri de listeleyebilir.
+func (h *DocumentHandler) GetReviewQueue(c *gin.Context) {
+	if _, ok := currentStaff(c, h.staffService); !ok {
+		return
+	}
+
+	query, err := listquery.Parse(c.Request.URL.Query(), services.DocumentQueueSpec)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	filter := services.DocumentQueueFilter{
+		Status:       c.DefaultQuery("status", model.DocumentStatusPending),
+		DocumentType: c.Query("type"),
+		ScanStatus:   c.Query("scan"),
+	}
+	if !model.IsValidDocumentStatus(filter.Status) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Geçersiz belge durumu"})
+		return
+	}
+	if value := c.Query("user_id"); value != "" {
+		userID, err := strconv.ParseUint(value, 10, 32)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Geçersiz kullanıcı ID'si"})
+			return
+		}
+		filter.UserID = uint(userID)
+	}
+	withDeleted, ok := includeDeleted(c)
+	if !ok {
+		return
+	}
+	filter.IncludeDeleted = withDeleted
+
+	page, err := h.documentService.GetReviewQueue(filter, query)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Belgeler getirilirken hata oluştu: " + err.Error()})
+		return
+	}
+
+	viewer, ok := privacyViewer(c, h.privacyService)
+	if !ok {
+		return
+	}
+	items, err := services.FilterDocuments(page.Items, viewer)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": items,
+		"meta": gin.H{
+			"status":      filter.Status,
+			"limit":       page.Limit,
+			"has_more":    page.HasMore,
+			"next_cursor": page.NextCursor,
+		},
+	})
+}
+
+// GetDocumentForReview incelenecek belgeyi getirir
+func (h *DocumentHandler) GetDocumentForReview(c *gin.Context) {
+	document, ok := h.loadReviewDocument(c)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": document})
+}
+
+// GetReviewDownloadURL incelenecek belge için kısa süreli imzalı indirme bağlantısı üretir
+func (h *DocumentHandler) GetReviewDownloadURL(c *gin.Context) {
+	document, ok := h.loadReviewDocument(c)
+	if !ok {
+		return
+	}
+
+	link, expiresAt, err := h.documentService.DownloadURL(c.Request.Context(), document)
+	if errors.Is(err, services.ErrDocumentUnavailable) {
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "İndirme bağlantısı oluşturulamadı: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": gin.H{"url": link, "expires_at": expiresAt}})
+}
+
+// RestoreDocument silinmiş bir belgeyi geri yükler
+func (h *DocumentHandler) RestoreDocument(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Geçersiz belge ID'si"})
+		return
+	}
+
+	document, err := h.documentService.RestoreDocument(uint(id))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": document, "message": "Belge başarıyla geri yüklendi"})
+}
+
+// ApproveDocument bekleyen belgeyi onaylar
+func (h *DocumentHandler) ApproveDocument(c *gin.Context) {
+	h.review(c, true)
+}
+
+// RejectDocument bekleyen belgeyi gerekçesiyle reddeder
+func (h *DocumentHandler) RejectDocument(c *gin.Context) {
+	h.review(c, false)
+}
+
+func (h *DocumentHandler) review(c *gin.Context, approve bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Geçersiz belge ID'si"})
+		return
+	}
+
+	staff, ok := currentStaff(c, h.staffService)
+	if !ok {
+		return
+	}
+
+	var request struct {
+		Reason string `json:"reason"`
+	}
+
+	// Onayda gövde gönderilmeyebilir; ret gerekçesi serviste doğrulanır
+	if err := c.ShouldBindJSON(&request); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Geçersiz veri: " + err.Error()})
+		return
+	}
+
+	document, err := h.documentService.ReviewDocument(uint(id), staff.ID, staff.UserID, approve, request.Reason)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	message := "Belge reddedildi"
+	if approve {
+		message = "Belge onaylandı"
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": document, "message": message})
+}
+
+// DownloadSignedDocument imzalı bağlantı ile belge indirir; kimlik doğrulaması imza ile yapılır
+func (h *DocumentHandler) DownloadSignedDocument(c *gin.Context) {
+	if err := h.signer.Verify(c.Request.URL.Path, c.Request.URL.Query()); err != nil {
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Geçersiz belge ID'si"})
+		return
+	}
+
+	document, err := h.documentService.GetDocumentByID(uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	reader, err := h.documentService.OpenDocument(c.Request.Context(), document)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		if errors.Is(err, services.ErrDocumentUnavailable) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Belge açılamadı: " + err.Error()})
+		return
+	}
+	defer reader.Close()
+
+	c.Header("Content-Type", document.ContentType)
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, document.FileName))
+	c.Header("Content-Length", strconv.FormatInt(document.Size, 10))
+	c.Header("Cache-Control", "private, no-store")
+	c.Status(http.StatusOK)
+
+	if _, err := io.Copy(c.Writer, reader); err != nil {
+		log.Printf("Belge gönderilemedi (%d): %v", document.ID, err)
+	}
+}
+
+// loadOwnDocument yoldaki belgeyi oturum açmış kullanıcıya aitse getirir
+func (h *DocumentHandler) loadOwnDocument(c *gin.Context) (*model.UserDocument, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Geçersiz belge ID'si"})
+		return nil, false
+	}
+
+	userID, exists := middleware.GetCurrentUserID(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Kullanıcı bulunamadı"})
+		return nil, false
+	}
+
+	document, err := h.documentService.GetUserDocument(userID, uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return nil, false
+	}
+
+	return document, true
+}
+
+// loadReviewDocument yoldaki belgeyi personel kaydı olan kullanıcı için getirir
+func (h *DocumentHandler) loadReviewDocument(c *gin.Context) (*model.UserDocument, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Geçersiz belge ID'si"})
+		return nil, false
+	}
+
+	if _, ok := currentStaff(c, h.staffService); !ok {
+		return nil, false
+	}
+
+	document, err := h.documentService.GetDocumentByID(uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return nil, false
+	}
+
+	return document, true
+}
@@ -0,0 +1,368 @@
+package handler
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/UmutTKMN/go-backend/internal/app/model"
+	"github.com/UmutTKMN/go-backend/internal/app/services"
+	"github.com/UmutTKMN/go-backend/internal/pkg/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+// LeaveHandler izin işlemleri için handler
+type LeaveHandler struct {
+	leaveService   *services.LeaveService
+	staffService   *services.StaffService
+	privacyService *services.PrivacyService
+}
+
+// NewLeaveHandler yeni bir LeaveHandler örneği oluşturur
+func NewLeaveHandler() *LeaveHandler {
+	return &LeaveHandler{
+		leaveService:   services.NewLeaveService(),
+		staffService:   services.NewStaffService(),
+		privacyService: services.NewPrivacyService(),
+	}
+}
+
+// GetAllLeaveTypes tüm izin türlerini getirir
+func (h *LeaveHandler) GetAllLeaveTypes(c *gin.Context) {
+	leaveTypes, err := h.leaveService.GetAllLeaveTypes()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "İzin türleri getirilirken hata oluştu: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": leaveTypes})
+}
+
+// CreateLeaveType yeni bir izin türü oluşturur
+func (h *LeaveHandler) CreateLeaveType(c *gin.Context) {
+	var leaveType model.LeaveType
+	if err := c.ShouldBindJSON(&leaveType); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Geçersiz veri: " + err.Error()})
+		return
+	}
+
+	if err := h.leaveService.CreateLeaveType(&leaveType); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "İzin türü oluşturulurken hata oluştu: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"data": leaveType, "message": "İzin türü başarıyla oluşturuldu"})
+}
+
+// UpdateLeaveType bir izin türünü günceller
+func (h *LeaveHandler) UpdateLeaveType(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Geçersiz izin türü ID'si"})
+		return
+	}
+
+	var leaveType model.LeaveType
+	if err := c.ShouldBindJSON(&leaveType); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Geçersiz veri: " + err.Error()})
+		return
+	}
+
+	leaveType.ID = uint(id)
+	if err := h.leaveService.UpdateLeaveType(&leaveType); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "İzin türü güncellenirken hata oluştu: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": leaveType, "message": "İzin türü başarıyla güncellendi"})
+}
+
+// DeleteLeaveType bir izin türünü siler
+func (h *LeaveHandler) DeleteLeaveType(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Geçersiz izin türü ID'si"})
+		return
+	}
+
+	if err := h.leaveService.DeleteLeaveType(uint(id)); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "İzin türü başarıyla silindi"})
+}
+
+// GetMyBalances oturum açmış personelin izin bakiyelerini getirir
+func (h *LeaveHandler) GetMyBalances(c *gin.Context) {
+	staff, ok := currentStaff(c, h.staffService)
+	if !ok {
+		return
+	}
+
+	h.respondBalances(c, staff.ID)
+}
+
+// GetStaffBalances belirtilen personelin izin bakiyelerini getirir
+func (h *LeaveHandler) GetStaffBalances(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Geçersiz personel ID'si"})
+		return
+	}
+
+	h.respondBalances(c, uint(id))
+}
+
+func (h *LeaveHandler) respondBalances(c *gin.Context, staffID uint) {
+	year, err := strconv.Atoi(c.DefaultQuery("year", strconv.Itoa(time.Now().Year())))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Geçersiz yıl"})
+		return
+	}
+
+	balances, err := h.leaveService.GetLeaveBalances(staffID, year)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "İzin bakiyeleri getirilirken hata oluştu: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": balances, "year": year})
+}
+
+// GetMyLeaveRequests oturum açmış personelin izin taleplerini getirir
+func (h *LeaveHandler) GetMyLeaveRequests(c *gin.Context) {
+	staff, ok := currentStaff(c, h.staffService)
+	if !ok {
+		return
+	}
+
+	requests, err := h.leaveService.GetLeaveRequestsByStaff(staff.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "İzin talepleri getirilirken hata oluştu: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": requests})
+}
+
+// CreateLeaveRequest yeni bir izin talebi oluşturur
+func (h *LeaveHandler) CreateLeaveRequest(c *gin.Context) {
+	staff, ok := currentStaff(c, h.staffService)
+	if !ok {
+		return
+	}
+
+	var request struct {
+		LeaveTypeID uint   `json:"leave_type_id" binding:"required"`
+		StartDate   string `json:"start_date" binding:"required"`
+		EndDate     string `json:"end_date" binding:"required"`
+		Reason      string `json:"reason"`
+	}
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Geçersiz veri: " + err.Error()})
+		return
+	}
+
+	startDate, err := time.Parse("2006-01-02", request.StartDate)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Geçersiz başlangıç tarihi, beklenen format: YYYY-MM-DD"})
+		return
+	}
+
+	endDate, err := time.Parse("2006-01-02", request.EndDate)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Geçersiz bitiş tarihi, beklenen format: YYYY-MM-DD"})
+		return
+	}
+
+	leaveRequest := model.LeaveRequest{
+		StaffID:     staff.ID,
+		LeaveTypeID: request.LeaveTypeID,
+		StartDate:   startDate,
+		EndDate:     endDate,
+		Reason:      request.Reason,
+	}
+
+	if err := h.leaveService.CreateLeaveRequest(&leaveRequest); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "İzin talebi oluşturulamadı: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"data": leaveRequest, "message": "İzin talebi başarıyla oluşturuldu"})
+}
+
+// CancelLeaveRequest personelin kendi izin talebini iptal eder
+func (h *LeaveHandler) CancelLeaveRequest(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Geçersiz izin talebi ID'si"})
+		return
+	}
+
+	staff, ok := currentStaff(c, h.staffService)
+	if !ok {
+		return
+	}
+
+	if err := h.leaveService.CancelLeaveRequest(uint(id), staff.ID); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "İzin talebi iptal edildi"})
+}
+
+// GetPendingApprovals yöneticinin onayını bekleyen izin taleplerini getirir
+func (h *LeaveHandler) GetPendingApprovals(c *gin.Context) {
+	staff, ok := currentStaff(c, h.staffService)
+	if !ok {
+		return
+	}
+
+	requests, err := h.leaveService.GetPendingApprovals(staff.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Onay bekleyen talepler getirilirken hata oluştu: " + err.Error()})
+		return
+	}
+
+	items, ok := h.filterLeaveRequests(c, requests)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": items})
+}
+
+// ApproveLeaveRequest bir izin talebini onaylar
+func (h *LeaveHandler) ApproveLeaveRequest(c *gin.Context) {
+	h.decide(c, true)
+}
+
+// RejectLeaveRequest bir izin talebini reddeder
+func (h *LeaveHandler) RejectLeaveRequest(c *gin.Context) {
+	h.decide(c, false)
+}
+
+func (h *LeaveHandler) decide(c *gin.Context, approve bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Geçersiz izin talebi ID'si"})
+		return
+	}
+
+	staff, ok := currentStaff(c, h.staffService)
+	if !ok {
+		return
+	}
+
+	var request struct {
+		Notes string `json:"notes"`
+	}
+
+	// Notlar isteğe bağlı olduğundan boş gövdeye izin ver
+	if err := c.ShouldBindJSON(&request); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Geçersiz veri: " + err.Error()})
+		return
+	}
+
+	var leaveRequest *model.LeaveRequest
+	if approve {
+		leaveRequest, err = h.leaveService.ApproveLeaveRequest(uint(id), staff.ID, request.Notes)
+	} else {
+		leaveRequest, err = h.leaveService.RejectLeaveRequest(uint(id), staff.ID, request.Notes)
+	}
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	message := "İzin talebi reddedildi"
+	if approve {
+		message = "İzin talebi onaylandı"
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": leaveRequest, "message": message})
+}
+
+// GetDepartmentCalendar departmanın izin takvimini getirir
+func (h *LeaveHandler) GetDepartmentCalendar(c *gin.Context) {
+	department := c.Param("department")
+	if department == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Departman belirtilmedi"})
+		return
+	}
+
+	// Varsayılan olarak içinde bulunulan ayı göster
+	now := time.Now()
+	from := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+	to := from.AddDate(0, 1, -1)
+
+	var err error
+	if value := c.Query("from"); value != "" {
+		if from, err = time.Parse("2006-01-02", value); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Geçersiz başlangıç tarihi, beklenen format: YYYY-MM-DD"})
+			return
+		}
+	}
+	if value := c.Query("to"); value != "" {
+		if to, err = time.Parse("2006-01-02", value); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Geçersiz bitiş tarihi, beklenen format: YYYY-MM-DD"})
+			return
+		}
+	}
+
+	entries, err := h.leaveService.GetDepartmentCalendar(department, from, to)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "İzin takvimi getirilirken hata oluştu: " + err.Error()})
+		return
+	}
+
+	items, ok := h.filterLeaveRequests(c, entries)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": items,
+		"meta": gin.H{
+			"department": department,
+			"from":       from.Format("2006-01-02"),
+			"to":         to.Format("2006-01-02"),
+		},
+	})
+}
+
+// filterLeaveRequests izin taleplerine görüntüleyenin gizlilik kısıtlarını uygular; hata yanıtını kendisi yazar
+func (h *LeaveHandler) filterLeaveRequests(c *gin.Context, requests []model.LeaveRequest) ([]map[string]interface{}, bool) {
+	viewer, ok := privacyViewer(c, h.privacyService)
+	if !ok {
+		return nil, false
+	}
+	items, err := services.FilterLeaveRequests(requests, viewer)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return nil, false
+	}
+	return items, true
+}
+
+// currentStaff oturum açmış kullanıcının personel kaydını getirir, yoksa hata yanıtı döner
+func currentStaff(c *gin.Context, staffService *services.StaffService) (*model.Staff, bool) {
+	userID, exists := middleware.GetCurrentUserID(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Kullanıcı bulunamadı"})
+		return nil, false
+	}
+
+	staff, err := staffService.GetStaffByUserID(userID)
+	if err != nil {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Bu işlem için personel kaydınız olmalıdır"})
+		return nil, false
+	}
+
+	return staff, true
+}
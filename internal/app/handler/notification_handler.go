@@ -0,0 +1,219 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/UmutTKMN/go-backend/internal/app/model"
+	"github.com/UmutTKMN/go-backend/internal/app/services"
+	"github.com/UmutTKMN/go-backend/internal/pkg/listquery"
+	"github.com/UmutTKMN/go-backend/internal/pkg/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+// NotificationHandler kullanıcının bildirim gelen kutusu için handler
+type NotificationHandler struct {
+	notificationService *services.NotificationService
+}
+
+// NewNotificationHandler yeni bir NotificationHandler örneği oluşturur
+func NewNotificationHandler() *NotificationHandler {
+	return &NotificationHandler{
+		notificationService: services.NewNotificationService(),
+	}
+}
+
+// GetNotifications oturum açmış kullanıcının bildirimlerini getirir.
+// Filtreler: category, importance, unread. Arama: q. Sıralama: sort. Sayfalama: limit, cursor.
+func (h *NotificationHandler) GetNotifications(c *gin.Context) {
+	userID, exists := middleware.GetCurrentUserID(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Kullanıcı bulunamadı"})
+		return
+	}
+
+	query, err := listquery.Parse(c.Request.URL.Query(), services.InboxSpec)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	filter := services.InboxFilter{
+		Category:   c.Query("category"),
+		Importance: c.Query("importance"),
+	}
+	filter.UnreadOnly, _ = strconv.ParseBool(c.Query("unread"))
+
+	if filter.Category != "" && !model.IsValidNotificationCategory(filter.Category) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Geçersiz bildirim kategorisi"})
+		return
+	}
+
+	page, err := h.notificationService.GetInbox(userID, filter, query)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Bildirimler getirilirken hata oluştu: " + err.Error()})
+		return
+	}
+
+	unread, err := h.notificationService.GetUnreadCounts(userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Okunmamış bildirim sayısı alınamadı: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": page.Items,
+		"meta": gin.H{
+			"unread":      unread,
+			"limit":       page.Limit,
+			"has_more":    page.HasMore,
+			"next_cursor": page.NextCursor,
+		},
+	})
+}
+
+// GetUnreadCounts oturum açmış kullanıcının okunmamış bildirim sayılarını getirir
+func (h *NotificationHandler) GetUnreadCounts(c *gin.Context) {
+	userID, exists := middleware.GetCurrentUserID(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Kullanıcı bulunamadı"})
+		return
+	}
+
+	unread, err := h.notificationService.GetUnreadCounts(userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Okunmamış bildirim sayısı alınamadı: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": unread})
+}
+
+// GetNotification oturum açmış kullanıcının bildirimini getirir
+func (h *NotificationHandler) GetNotification(c *gin.Context) {
+	id, userID, ok := notificationParams(c)
+	if !ok {
+		return
+	}
+
+	communication, err := h.notificationService.GetNotification(userID, id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": communication})
+}
+
+// MarkRead bildirimi okundu olarak işaretler
+func (h *NotificationHandler) MarkRead(c *gin.Context) {
+	id, userID, ok := notificationParams(c)
+	if !ok {
+		return
+	}
+
+	communication, err := h.notificationService.MarkRead(userID, id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": communication, "message": "Bildirim okundu olarak işaretlendi"})
+}
+
+// MarkAllRead oturum açmış kullanıcının tüm bildirimlerini okundu olarak işaretler.
+// category sorgu parametresi ile yalnızca bir kategori işaretlenebilir.
+func (h *NotificationHandler) MarkAllRead(c *gin.Context) {
+	userID, exists := middleware.GetCurrentUserID(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Kullanıcı bulunamadı"})
+		return
+	}
+
+	category := c.Query("category")
+	if category != "" && !model.IsValidNotificationCategory(category) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Geçersiz bildirim kategorisi"})
+		return
+	}
+
+	count, err := h.notificationService.MarkAllRead(userID, category)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Bildirimler işaretlenemedi: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": gin.H{"updated": count}, "message": "Bildirimler okundu olarak işaretlendi"})
+}
+
+// DeleteNotification oturum açmış kullanıcının bildirimini siler
+func (h *NotificationHandler) DeleteNotification(c *gin.Context) {
+	id, userID, ok := notificationParams(c)
+	if !ok {
+		return
+	}
+
+	if err := h.notificationService.DeleteNotification(userID, id); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Bildirim başarıyla silindi"})
+}
+
+// GetPreferences oturum açmış kullanıcının bildirim tercihlerini getirir
+func (h *NotificationHandler) GetPreferences(c *gin.Context) {
+	userID, exists := middleware.GetCurrentUserID(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Kullanıcı bulunamadı"})
+		return
+	}
+
+	settings, err := h.notificationService.GetPreferences(userID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": settings})
+}
+
+// UpdatePreferences oturum açmış kullanıcının bildirim tercihlerini günceller.
+// Yalnızca gönderilen kategori/kanal değerleri değişir; quiet_hours gönderilirse tamamen değiştirilir.
+func (h *NotificationHandler) UpdatePreferences(c *gin.Context) {
+	userID, exists := middleware.GetCurrentUserID(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Kullanıcı bulunamadı"})
+		return
+	}
+
+	var preferences model.NotificationPreferences
+	if err := c.ShouldBindJSON(&preferences); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Geçersiz veri: " + err.Error()})
+		return
+	}
+
+	settings, err := h.notificationService.UpdatePreferences(userID, preferences)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": settings, "message": "Bildirim tercihleri başarıyla güncellendi"})
+}
+
+// notificationParams yoldaki bildirim ID'sini ve oturum açmış kullanıcıyı okur
+func notificationParams(c *gin.Context) (uint, uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Geçersiz bildirim ID'si"})
+		return 0, 0, false
+	}
+
+	userID, exists := middleware.GetCurrentUserID(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Kullanıcı bulunamadı"})
+		return 0, 0, false
+	}
+
+	return uint(id), userID, true
+}
@@ -0,0 +1,146 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/UmutTKMN/go-backend/internal/app/model"
+	"github.com/UmutTKMN/go-backend/internal/app/services"
+	"github.com/UmutTKMN/go-backend/internal/pkg/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+// PreferenceHandler kullanıcı tercihleri için handler
+type PreferenceHandler struct {
+	preferenceService *services.PreferenceService
+}
+
+// NewPreferenceHandler yeni bir PreferenceHandler örneği oluşturur
+func NewPreferenceHandler() *PreferenceHandler {
+	return &PreferenceHandler{
+		preferenceService: services.NewPreferenceService(),
+	}
+}
+
+// preferenceRequest tek tercih güncelleme isteği; value türü anahtarın tanımına göre değişir
+type preferenceRequest struct {
+	Value json.RawMessage `json:"value" binding:"required"`
+}
+
+// GetPreferences oturum açmış kullanıcının tüm tercihlerini varsayılanlarla birlikte getirir
+func (h *PreferenceHandler) GetPreferences(c *gin.Context) {
+	userID, exists := middleware.GetCurrentUserID(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Kullanıcı bulunamadı"})
+		return
+	}
+
+	set, err := h.preferenceService.GetPreferences(userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Tercihler getirilirken hata oluştu: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": set.Values,
+		"meta": gin.H{
+			"customized":  set.Customized,
+			"definitions": model.PreferenceDefinitions,
+		},
+	})
+}
+
+// UpdatePreferences birden fazla tercihi tek istekte günceller. Gövde {"anahtar": değer} biçimindedir;
+// null değer anahtarı varsayılana döndürür.
+func (h *PreferenceHandler) UpdatePreferences(c *gin.Context) {
+	userID, exists := middleware.GetCurrentUserID(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Kullanıcı bulunamadı"})
+		return
+	}
+
+	var values map[string]json.RawMessage
+	if err := c.ShouldBindJSON(&values); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Geçersiz veri: " + err.Error()})
+		return
+	}
+
+	set, err := h.preferenceService.SetPreferences(userID, values)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":    set.Values,
+		"meta":    gin.H{"customized": set.Customized},
+		"message": "Tercihler başarıyla güncellendi",
+	})
+}
+
+// GetPreference tek bir tercihin geçerli değerini getirir
+func (h *PreferenceHandler) GetPreference(c *gin.Context) {
+	userID, exists := middleware.GetCurrentUserID(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Kullanıcı bulunamadı"})
+		return
+	}
+
+	value, err := h.preferenceService.GetPreference(userID, c.Param("key"))
+	if err != nil {
+		respondPreferenceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": value})
+}
+
+// UpdatePreference tek bir tercihi kaydeder
+func (h *PreferenceHandler) UpdatePreference(c *gin.Context) {
+	userID, exists := middleware.GetCurrentUserID(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Kullanıcı bulunamadı"})
+		return
+	}
+
+	var request preferenceRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Geçersiz veri: " + err.Error()})
+		return
+	}
+
+	value, err := h.preferenceService.SetPreference(userID, c.Param("key"), request.Value)
+	if err != nil {
+		respondPreferenceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": value, "message": "Tercih başarıyla kaydedildi"})
+}
+
+// DeletePreference kayıtlı tercihi siler ve varsayılan değere döner
+func (h *PreferenceHandler) DeletePreference(c *gin.Context) {
+	userID, exists := middleware.GetCurrentUserID(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Kullanıcı bulunamadı"})
+		return
+	}
+
+	value, err := h.preferenceService.ResetPreference(userID, c.Param("key"))
+	if err != nil {
+		respondPreferenceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": value, "message": "Tercih varsayılana döndürüldü"})
+}
+
+// respondPreferenceError bilinmeyen anahtarlar için 404, diğer hatalar için 400 döndürür
+func respondPreferenceError(c *gin.Context, err error) {
+	if errors.Is(err, services.ErrUnknownPreference) {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+}
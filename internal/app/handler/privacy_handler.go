@@ -0,0 +1,79 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/UmutTKMN/go-backend/internal/app/model"
+	"github.com/UmutTKMN/go-backend/internal/app/services"
+	"github.com/UmutTKMN/go-backend/internal/pkg/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+// PrivacyHandler kullanıcı gizlilik ayarları için handler
+type PrivacyHandler struct {
+	privacyService *services.PrivacyService
+}
+
+// NewPrivacyHandler yeni bir PrivacyHandler örneği oluşturur
+func NewPrivacyHandler() *PrivacyHandler {
+	return &PrivacyHandler{
+		privacyService: services.NewPrivacyService(),
+	}
+}
+
+// GetSettings oturum açmış kullanıcının alan grubu görünürlüklerini getirir
+func (h *PrivacyHandler) GetSettings(c *gin.Context) {
+	userID, exists := middleware.GetCurrentUserID(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Kullanıcı bulunamadı"})
+		return
+	}
+
+	settings, err := h.privacyService.GetSettings(userID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": settings,
+		"meta": gin.H{
+			"groups":       model.PrivacyFieldGroups,
+			"visibilities": model.Visibilities,
+		},
+	})
+}
+
+// UpdateSettings verilen alan gruplarının görünürlüğünü günceller; belirtilmeyen gruplar değişmez
+func (h *PrivacyHandler) UpdateSettings(c *gin.Context) {
+	userID, exists := middleware.GetCurrentUserID(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Kullanıcı bulunamadı"})
+		return
+	}
+
+	var settings model.PrivacySettings
+	if err := c.ShouldBindJSON(&settings); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Geçersiz veri: " + err.Error()})
+		return
+	}
+
+	updated, err := h.privacyService.UpdateSettings(userID, settings)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": updated, "message": "Gizlilik ayarları başarıyla güncellendi"})
+}
+
+// privacyViewer isteği yapan kullanıcıya göre görüntüleyen bilgisini oluşturur; oturum yoksa anonimdir
+func privacyViewer(c *gin.Context, privacyService *services.PrivacyService) (services.PrivacyViewer, bool) {
+	userID, _ := middleware.GetCurrentUserID(c)
+	viewer, err := privacyService.Viewer(userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Rol kontrolü yapılırken hata oluştu"})
+		return viewer, false
+	}
+	return viewer, true
+}
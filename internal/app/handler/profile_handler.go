@@ -1,23 +1,71 @@
 package handler
 
 import (
+	"errors"
+	"io"
+	"log"
 	"net/http"
+	"strconv"
 
 	"github.com/UmutTKMN/go-backend/internal/app/services"
 	"github.com/UmutTKMN/go-backend/internal/pkg/middleware"
+	"github.com/UmutTKMN/go-backend/internal/pkg/storage"
 	"github.com/gin-gonic/gin"
 )
 
 type ProfileHandler struct {
-	userService *services.UserService
+	userService     *services.UserService
+	avatarService   *services.AvatarService
+	deletionService *services.AccountDeletionService
 }
 
-func NewProfileHandler(userService *services.UserService) *ProfileHandler {
+func NewProfileHandler(userService *services.UserService, avatarService *services.AvatarService, deletionService *services.AccountDeletionService) *ProfileHandler {
 	return &ProfileHandler{
-		userService: userService,
+		userService:     userService,
+		avatarService:   avatarService,
+		deletionService: deletionService,
 	}
 }
 
+// deleteAccountRequest hesap silme isteği; işlem şifre ile onaylanır
+type deleteAccountRequest struct {
+	Password string `json:"password" binding:"required"`
+}
+
+// DeleteAccount kullanıcının hesabını bekleme süresi sonunda silinmek üzere planlar. Açık oturumlar kapatılır;
+// bekleme süresi içinde yeniden giriş yapılırsa silme iptal edilir.
+func (h *ProfileHandler) DeleteAccount(c *gin.Context) {
+	userID, exists := middleware.GetCurrentUserID(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Kullanıcı bulunamadı"})
+		return
+	}
+
+	var req deleteAccountRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Geçersiz veri: " + err.Error()})
+		return
+	}
+
+	user, err := h.deletionService.RequestDeletion(userID, req.Password)
+	if err != nil {
+		if errors.Is(err, services.ErrAccountDeletionPending) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{
+		"data": gin.H{
+			"deletion_requested_at": user.DeletionRequestedAt,
+			"deletion_scheduled_at": user.DeletionScheduledAt,
+		},
+		"message": "Hesabınız silinmek üzere planlandı; iptal etmek için bu tarihten önce yeniden giriş yapın",
+	})
+}
+
 // GetProfile kullanıcının kendi profilini görüntüler
 func (h *ProfileHandler) GetProfile(c *gin.Context) {
 	// Context'ten kullanıcı bilgilerini al
@@ -56,9 +104,102 @@ func (h *ProfileHandler) UpdateProfile(c *gin.Context) {
 	// Kullanıcı bilgilerini güncelle
 	updatedUser, err := h.userService.UpdateUser(userID, updates)
 	if err != nil {
+		if errors.Is(err, services.ErrEmailTaken) || errors.Is(err, services.ErrUsernameTaken) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Profil güncellenemedi: " + err.Error()})
 		return
 	}
 
 	c.JSON(http.StatusOK, updatedUser)
 }
+
+// UploadAvatar kullanıcının profil fotoğrafını yükler.
+// multipart/form-data alanı: file (JPEG, PNG, GIF veya WebP).
+func (h *ProfileHandler) UploadAvatar(c *gin.Context) {
+	userID, exists := middleware.GetCurrentUserID(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Kullanıcı bulunamadı"})
+		return
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Dosya bulunamadı: " + err.Error()})
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Dosya açılamadı: " + err.Error()})
+		return
+	}
+	defer file.Close()
+
+	upload := services.FileUpload{
+		FileName:    fileHeader.Filename,
+		ContentType: fileHeader.Header.Get("Content-Type"),
+		Size:        fileHeader.Size,
+		Reader:      file,
+	}
+
+	user, err := h.avatarService.UploadAvatar(c.Request.Context(), userID, upload)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Profil fotoğrafı yüklenemedi: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": gin.H{
+			"profile_picture": user.ProfilePicture,
+			"sizes":           h.avatarService.AvatarURLs(user),
+		},
+		"message": "Profil fotoğrafı başarıyla güncellendi",
+	})
+}
+
+// DeleteAvatar kullanıcının profil fotoğrafını kaldırır
+func (h *ProfileHandler) DeleteAvatar(c *gin.Context) {
+	userID, exists := middleware.GetCurrentUserID(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Kullanıcı bulunamadı"})
+		return
+	}
+
+	if err := h.avatarService.RemoveAvatar(c.Request.Context(), userID); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Profil fotoğrafı kaldırıldı"})
+}
+
+// ServeAvatar profil fotoğrafının istenen boyutunu sunar. Bağlantılar her yüklemede
+// değiştiğinden yanıt uzun süre önbelleğe alınabilir.
+func (h *ProfileHandler) ServeAvatar(c *gin.Context) {
+	userID, err := strconv.ParseUint(c.Param("userId"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Geçersiz kullanıcı ID'si"})
+		return
+	}
+
+	reader, err := h.avatarService.OpenAvatar(c.Request.Context(), uint(userID), c.Param("token"), c.Param("size"))
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Profil fotoğrafı açılamadı: " + err.Error()})
+		return
+	}
+	defer reader.Close()
+
+	c.Header("Content-Type", "image/jpeg")
+	c.Header("Cache-Control", "public, max-age=31536000, immutable")
+	c.Status(http.StatusOK)
+
+	if _, err := io.Copy(c.Writer, reader); err != nil {
+		log.Printf("Profil fotoğrafı gönderilemedi (kullanıcı %d): %v", userID, err)
+	}
+}
@@ -0,0 +1,667 @@
+package handler
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"mime/multipart"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/UmutTKMN/go-backend/internal/app/model"
+	"github.com/UmutTKMN/go-backend/internal/app/services"
+	"github.com/UmutTKMN/go-backend/internal/pkg/listquery"
+	"github.com/UmutTKMN/go-backend/internal/pkg/middleware"
+	"github.com/UmutTKMN/go-backend/internal/pkg/storage"
+	"github.com/gin-gonic/gin"
+)
+
+// RequestHandler kullanıcı talepleri (destek kayıtları) için handler
+type RequestHandler struct {
+	requestService *services.RequestService
+	commentService *services.RequestCommentService
+	staffService   *services.StaffService
+	privacyService *services.PrivacyService
+}
+
+// NewRequestHandler yeni bir RequestHandler örneği oluşturur
+func NewRequestHandler(store storage.Storage) *RequestHandler {
+	return &RequestHandler{
+		requestService: services.NewRequestService(),
+		commentService: services.NewRequestCommentService(store),
+		staffService:   services.NewStaffService(),
+		privacyService: services.NewPrivacyService(),
+	}
+}
+
+// SubmitRequest oturum açmış kullanıcı adına yeni bir talep oluşturur
+func (h *RequestHandler) SubmitRequest(c *gin.Context) {
+	userID, exists := middleware.GetCurrentUserID(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Kullanıcı bulunamadı"})
+		return
+	}
+
+	var request struct {
+		RequestType    string `json:"request_type" binding:"required"`
+		RequestDetails string `json:"request_details" binding:"required"`
+		Priority       string `json:"priority"`
+	}
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Geçersiz veri: " + err.Error()})
+		return
+	}
+
+	userRequest := model.UserRequest{
+		UserID:         userID,
+		RequestType:    request.RequestType,
+		RequestDetails: request.RequestDetails,
+		Priority:       request.Priority,
+	}
+
+	if err := h.requestService.SubmitRequest(&userRequest); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Talep oluşturulamadı: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"data": userRequest, "message": "Talebiniz alındı"})
+}
+
+// GetMyRequests oturum açmış kullanıcının taleplerini getirir
+func (h *RequestHandler) GetMyRequests(c *gin.Context) {
+	userID, exists := middleware.GetCurrentUserID(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Kullanıcı bulunamadı"})
+		return
+	}
+
+	requests, err := h.requestService.GetRequestsByUser(userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Talepler getirilirken hata oluştu: " + err.Error()})
+		return
+	}
+
+	items, ok := h.filterRequests(c, requests)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": items})
+}
+
+// GetRequest talebi getirir; talep sahibi veya personel görüntüleyebilir
+func (h *RequestHandler) GetRequest(c *gin.Context) {
+	request, _, ok := h.loadAccessibleRequest(c)
+	if !ok {
+		return
+	}
+
+	h.respondRequest(c, request, "")
+}
+
+// CloseRequest talep sahibinin sonuçlanmış talebini kapatır
+func (h *RequestHandler) CloseRequest(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Geçersiz talep ID'si"})
+		return
+	}
+
+	userID, exists := middleware.GetCurrentUserID(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Kullanıcı bulunamadı"})
+		return
+	}
+
+	request, err := h.requestService.CloseRequest(uint(id), userID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	h.respondRequest(c, request, "Talep kapatıldı")
+}
+
+// DeleteRequest talebi geri yüklenebilir şekilde siler
+func (h *RequestHandler) DeleteRequest(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Geçersiz talep ID'si"})
+		return
+	}
+
+	if err := h.requestService.DeleteRequest(uint(id)); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Talep başarıyla silindi"})
+}
+
+// RestoreRequest silinmiş bir talebi geri yükler
+func (h *RequestHandler) RestoreRequest(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Geçersiz talep ID'si"})
+		return
+	}
+
+	request, err := h.requestService.RestoreRequest(uint(id))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": request, "message": "Talep başarıyla geri yüklendi"})
+}
+
+// GetQueueCounts durum bazında talep kuyruğu sayılarını getirir.
+// mine=true ile yalnızca personele atanmış talepler sayılır.
+func (h *RequestHandler) GetQueueCounts(c *gin.Context) {
+	staff, ok := currentStaff(c, h.staffService)
+	if !ok {
+		return
+	}
+
+	var staffID uint
+	if mine, _ := strconv.ParseBool(c.Query("mine")); mine {
+		staffID = staff.ID
+	}
+
+	counts, err := h.requestService.GetQueueCounts(staffID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Kuyruk bilgileri getirilirken hata oluştu: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": counts})
+}
+
+// GetQueue belirtilen durumdaki talep kuyruğunu getirir.
+// Filtreler: type, priority, mine, unassigned, sla (breached, at_risk). Arama: q. Sıralama: sort. Sayfalama: limit, cursor.
+// Yöneticiler include_deleted=true ile silinmiş talepleri de listeleyebilir.
+func (h *RequestHandler) GetQueue(c *gin.Context) {
+	staff, ok := currentStaff(c, h.staffService)
+	if !ok {
+		return
+	}
+
+	status := c.Param("status")
+	if !model.IsValidRequestStatus(status) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Geçersiz talep durumu"})
+		return
+	}
+
+	query, err := listquery.Parse(c.Request.URL.Query(), services.RequestQueueSpec)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	filter := services.RequestQueueFilter{
+		Status:      status,
+		RequestType: c.Query("type"),
+		Priority:    c.Query("priority"),
+		SLAStatus:   c.Query("sla"),
+	}
+	if mine, _ := strconv.ParseBool(c.Query("mine")); mine {
+		filter.AssignedTo = staff.ID
+	}
+	filter.Unassigned, _ = strconv.ParseBool(c.Query("unassigned"))
+	if filter.IncludeDeleted, ok = includeDeleted(c); !ok {
+		return
+	}
+
+	if filter.SLAStatus != "" && filter.SLAStatus != model.SLAStatusBreached && filter.SLAStatus != model.SLAStatusAtRisk {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Geçersiz SLA durumu, beklenen: breached veya at_risk"})
+		return
+	}
+
+	page, err := h.requestService.GetQueue(filter, query)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Talepler getirilirken hata oluştu: " + err.Error()})
+		return
+	}
+
+	items, ok := h.filterRequests(c, page.Items)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": items,
+		"meta": gin.H{
+			"status":      status,
+			"limit":       page.Limit,
+			"has_more":    page.HasMore,
+			"next_cursor": page.NextCursor,
+		},
+	})
+}
+
+// ClaimRequest atanmamış bir talebi oturum açmış personelin üzerine alır
+func (h *RequestHandler) ClaimRequest(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Geçersiz talep ID'si"})
+		return
+	}
+
+	staff, ok := currentStaff(c, h.staffService)
+	if !ok {
+		return
+	}
+
+	request, err := h.requestService.ClaimRequest(uint(id), staff.ID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	h.respondRequest(c, request, "Talep üstlenildi")
+}
+
+// AssignRequest talebi belirtilen personele atar; staff_id 0 ise atamayı kaldırır
+func (h *RequestHandler) AssignRequest(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Geçersiz talep ID'si"})
+		return
+	}
+
+	var request struct {
+		StaffID uint `json:"staff_id"`
+	}
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Geçersiz veri: " + err.Error()})
+		return
+	}
+
+	userRequest, err := h.requestService.AssignRequest(uint(id), request.StaffID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	h.respondRequest(c, userRequest, "Talep ataması güncellendi")
+}
+
+// UpdateRequestStatus talebin durumunu değiştirir
+func (h *RequestHandler) UpdateRequestStatus(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Geçersiz talep ID'si"})
+		return
+	}
+
+	staff, ok := currentStaff(c, h.staffService)
+	if !ok {
+		return
+	}
+
+	var request struct {
+		Status           string  `json:"status" binding:"required"`
+		DecisionNotes    *string `json:"decision_notes"`
+		FollowUpRequired *bool   `json:"follow_up_required"`
+		FollowUpDate     *string `json:"follow_up_date"`
+	}
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Geçersiz veri: " + err.Error()})
+		return
+	}
+
+	update := services.RequestStatusUpdate{
+		Status:           request.Status,
+		DecisionNotes:    request.DecisionNotes,
+		FollowUpRequired: request.FollowUpRequired,
+	}
+	if request.FollowUpDate != nil {
+		followUpDate, err := time.Parse("2006-01-02", *request.FollowUpDate)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Geçersiz takip tarihi, beklenen format: YYYY-MM-DD"})
+			return
+		}
+		update.FollowUpDate = &followUpDate
+	}
+
+	userRequest, err := h.requestService.UpdateStatus(uint(id), staff.ID, update)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	h.respondRequest(c, userRequest, "Talep durumu güncellendi")
+}
+
+// SetFollowUp talebin takip tarihini ve takip tarihinde yeniden açılıp açılmayacağını ayarlar
+func (h *RequestHandler) SetFollowUp(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Geçersiz talep ID'si"})
+		return
+	}
+
+	staff, ok := currentStaff(c, h.staffService)
+	if !ok {
+		return
+	}
+
+	var request struct {
+		FollowUpRequired bool    `json:"follow_up_required"`
+		FollowUpDate     *string `json:"follow_up_date"`
+		Reopen           bool    `json:"reopen"`
+	}
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Geçersiz veri: " + err.Error()})
+		return
+	}
+
+	update := services.FollowUpUpdate{
+		Required: request.FollowUpRequired,
+		Reopen:   request.Reopen,
+	}
+	if request.FollowUpDate != nil {
+		followUpDate, err := time.Parse("2006-01-02", *request.FollowUpDate)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Geçersiz takip tarihi, beklenen format: YYYY-MM-DD"})
+			return
+		}
+		update.Date = &followUpDate
+	}
+
+	userRequest, err := h.requestService.SetFollowUp(uint(id), staff.ID, update)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	h.respondRequest(c, userRequest, "Takip ayarları güncellendi")
+}
+
+// GetComments talebin yorumlarını getirir; iç notlar yalnızca personele gösterilir
+func (h *RequestHandler) GetComments(c *gin.Context) {
+	request, staff, ok := h.loadAccessibleRequest(c)
+	if !ok {
+		return
+	}
+
+	comments, err := h.commentService.GetComments(request.ID, staff != nil)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Yorumlar getirilirken hata oluştu: " + err.Error()})
+		return
+	}
+
+	viewer, ok := privacyViewer(c, h.privacyService)
+	if !ok {
+		return
+	}
+	items, err := services.FilterComments(comments, viewer)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": items})
+}
+
+// AddComment talebe yorum ekler. JSON veya multipart/form-data kabul edilir;
+// multipart isteklerde dosyalar "attachments" alanında gönderilir.
+// is_internal yalnızca personel tarafından kullanılabilir.
+func (h *RequestHandler) AddComment(c *gin.Context) {
+	request, staff, ok := h.loadAccessibleRequest(c)
+	if !ok {
+		return
+	}
+
+	userID, _ := middleware.GetCurrentUserID(c)
+
+	var body string
+	var isInternal bool
+	var files []*multipart.FileHeader
+
+	if strings.HasPrefix(c.ContentType(), "multipart/") {
+		form, err := c.MultipartForm()
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Geçersiz form verisi: " + err.Error()})
+			return
+		}
+		body = c.PostForm("body")
+		isInternal, _ = strconv.ParseBool(c.PostForm("is_internal"))
+		files = form.File["attachments"]
+	} else {
+		var input struct {
+			Body       string `json:"body" binding:"required"`
+			IsInternal bool   `json:"is_internal"`
+		}
+		if err := c.ShouldBindJSON(&input); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Geçersiz veri: " + err.Error()})
+			return
+		}
+		body = input.Body
+		isInternal = input.IsInternal
+	}
+
+	if isInternal && staff == nil {
+		c.JSON(http.StatusForbidden, gin.H{"error": "İç not yalnızca personel tarafından eklenebilir"})
+		return
+	}
+
+	uploads := make([]services.FileUpload, 0, len(files))
+	for _, fileHeader := range files {
+		file, err := fileHeader.Open()
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Dosya açılamadı: " + err.Error()})
+			return
+		}
+		defer file.Close()
+
+		uploads = append(uploads, services.FileUpload{
+			FileName:    fileHeader.Filename,
+			ContentType: fileHeader.Header.Get("Content-Type"),
+			Size:        fileHeader.Size,
+			Reader:      file,
+		})
+	}
+
+	comment := model.RequestComment{
+		RequestID:    request.ID,
+		AuthorUserID: userID,
+		Body:         body,
+		IsInternal:   isInternal,
+	}
+
+	if err := h.commentService.AddComment(c.Request.Context(), &comment, uploads); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Yorum eklenemedi: " + err.Error()})
+		return
+	}
+
+	h.respondComment(c, http.StatusCreated, &comment, "Yorum eklendi")
+}
+
+// EditComment oturum açmış kullanıcının kendi yorumunu düzenler
+func (h *RequestHandler) EditComment(c *gin.Context) {
+	comment, _, ok := h.loadAccessibleComment(c)
+	if !ok {
+		return
+	}
+
+	userID, _ := middleware.GetCurrentUserID(c)
+
+	var input struct {
+		Body string `json:"body" binding:"required"`
+	}
+
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Geçersiz veri: " + err.Error()})
+		return
+	}
+
+	updated, err := h.commentService.EditComment(comment.ID, userID, input.Body)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	h.respondComment(c, http.StatusOK, updated, "Yorum güncellendi")
+}
+
+// GetCommentHistory yorumun düzenleme geçmişini getirir
+func (h *RequestHandler) GetCommentHistory(c *gin.Context) {
+	comment, _, ok := h.loadAccessibleComment(c)
+	if !ok {
+		return
+	}
+
+	revisions, err := h.commentService.GetCommentHistory(comment.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Yorum geçmişi getirilirken hata oluştu: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": revisions})
+}
+
+// DownloadCommentAttachment yorum ekini indirir
+func (h *RequestHandler) DownloadCommentAttachment(c *gin.Context) {
+	comment, _, ok := h.loadAccessibleComment(c)
+	if !ok {
+		return
+	}
+
+	attachmentID, err := strconv.ParseUint(c.Param("attachmentId"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Geçersiz ek ID'si"})
+		return
+	}
+
+	attachment, reader, err := h.commentService.OpenAttachment(c.Request.Context(), comment.ID, uint(attachmentID))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	defer reader.Close()
+
+	contentType := attachment.ContentType
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	c.Header("Content-Type", contentType)
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, attachment.FileName))
+	c.Header("Content-Length", strconv.FormatInt(attachment.Size, 10))
+	c.Status(http.StatusOK)
+
+	if _, err := io.Copy(c.Writer, reader); err != nil {
+		log.Printf("Yorum eki gönderilemedi (%d): %v", attachment.ID, err)
+	}
+}
+
+// respondRequest talebi sahibi ve atanan personel bilgisi gizlilik ayarlarına göre sınırlanmış olarak döndürür
+func (h *RequestHandler) respondRequest(c *gin.Context, request *model.UserRequest, message string) {
+	viewer, ok := privacyViewer(c, h.privacyService)
+	if !ok {
+		return
+	}
+	data, err := services.FilterRequest(request, viewer)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	response := gin.H{"data": data}
+	if message != "" {
+		response["message"] = message
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// filterRequests talep listesine görüntüleyenin gizlilik kısıtlarını uygular; hata yanıtını kendisi yazar
+func (h *RequestHandler) filterRequests(c *gin.Context, requests []model.UserRequest) ([]map[string]interface{}, bool) {
+	viewer, ok := privacyViewer(c, h.privacyService)
+	if !ok {
+		return nil, false
+	}
+	items, err := services.FilterRequests(requests, viewer)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return nil, false
+	}
+	return items, true
+}
+
+// respondComment yorumu yazar bilgisi gizlilik ayarlarına göre sınırlanmış olarak döndürür
+func (h *RequestHandler) respondComment(c *gin.Context, status int, comment *model.RequestComment, message string) {
+	viewer, ok := privacyViewer(c, h.privacyService)
+	if !ok {
+		return
+	}
+	data, err := services.FilterComment(comment, viewer)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(status, gin.H{"data": data, "message": message})
+}
+
+// loadAccessibleRequest talebi getirir ve kullanıcının erişim yetkisini kontrol eder.
+// Talep sahibi dışındaki kullanıcıların personel kaydı olmalıdır.
+// Kullanıcı personelse personel kaydı da döner, değilse nil döner.
+func (h *RequestHandler) loadAccessibleRequest(c *gin.Context) (*model.UserRequest, *model.Staff, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Geçersiz talep ID'si"})
+		return nil, nil, false
+	}
+
+	userID, exists := middleware.GetCurrentUserID(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Kullanıcı bulunamadı"})
+		return nil, nil, false
+	}
+
+	request, err := h.requestService.GetRequestByID(uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return nil, nil, false
+	}
+
+	staff, err := h.staffService.GetStaffByUserID(userID)
+	if err != nil {
+		if request.UserID != userID {
+			c.JSON(http.StatusNotFound, gin.H{"error": "talep bulunamadı"})
+			return nil, nil, false
+		}
+		staff = nil
+	}
+
+	return request, staff, true
+}
+
+// loadAccessibleComment talebe erişimi kontrol ettikten sonra yorumu getirir.
+// İç notlar personel olmayan kullanıcılara bulunamadı olarak yanıtlanır.
+func (h *RequestHandler) loadAccessibleComment(c *gin.Context) (*model.RequestComment, *model.Staff, bool) {
+	request, staff, ok := h.loadAccessibleRequest(c)
+	if !ok {
+		return nil, nil, false
+	}
+
+	commentID, err := strconv.ParseUint(c.Param("commentId"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Geçersiz yorum ID'si"})
+		return nil, nil, false
+	}
+
+	comment, err := h.commentService.GetCommentByID(uint(commentID))
+	if err != nil || comment.RequestID != request.ID || (comment.IsInternal && staff == nil) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "yorum bulunamadı"})
+		return nil, nil, false
+	}
+
+	return comment, staff, true
+}
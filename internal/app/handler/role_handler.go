@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
@@ -23,7 +24,12 @@ func NewRoleHandler() *RoleHandler {
 
 // GetAllRoles tüm rolleri getirir
 func (h *RoleHandler) GetAllRoles(c *gin.Context) {
-	roles, err := h.roleService.GetAllRoles()
+	withDeleted, ok := includeDeleted(c)
+	if !ok {
+		return
+	}
+
+	roles, err := h.roleService.GetAllRoles(withDeleted)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Roller getirilirken hata oluştu: " + err.Error()})
 		return
@@ -64,6 +70,10 @@ func (h *RoleHandler) CreateRole(c *gin.Context) {
 	}
 
 	if err := h.roleService.CreateRole(&role); err != nil {
+		if errors.Is(err, services.ErrRoleNameTaken) || errors.Is(err, services.ErrRoleNameDeleted) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Rol oluşturulurken hata oluştu: " + err.Error()})
 		return
 	}
@@ -105,6 +115,10 @@ func (h *RoleHandler) UpdateRole(c *gin.Context) {
 	}
 
 	if err := h.roleService.UpdateRole(&updatedRole); err != nil {
+		if errors.Is(err, services.ErrRoleNameTaken) || errors.Is(err, services.ErrRoleNameDeleted) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Rol güncellenirken hata oluştu: " + err.Error()})
 		return
 	}
@@ -128,6 +142,23 @@ func (h *RoleHandler) DeleteRole(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"message": "Rol başarıyla silindi"})
 }
 
+// RestoreRole silinmiş bir rolü geri yükler
+func (h *RoleHandler) RestoreRole(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Geçersiz rol ID'si"})
+		return
+	}
+
+	role, err := h.roleService.RestoreRole(uint(id))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": role, "message": "Rol başarıyla geri yüklendi"})
+}
+
 // AssignRoleToUser kullanıcıya bir rol atar
 func (h *RoleHandler) AssignRoleToUser(c *gin.Context) {
 	var request struct {
@@ -0,0 +1,88 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/UmutTKMN/go-backend/internal/app/model"
+	"github.com/UmutTKMN/go-backend/internal/app/services"
+	"github.com/gin-gonic/gin"
+)
+
+// RoutingHandler talep yönlendirme kuralları için handler
+type RoutingHandler struct {
+	routingService *services.RoutingService
+}
+
+// NewRoutingHandler yeni bir RoutingHandler örneği oluşturur
+func NewRoutingHandler() *RoutingHandler {
+	return &RoutingHandler{
+		routingService: services.NewRoutingService(),
+	}
+}
+
+// GetAllRules tüm yönlendirme kurallarını uygulanma sırasına göre getirir
+func (h *RoutingHandler) GetAllRules(c *gin.Context) {
+	rules, err := h.routingService.GetAllRules()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Yönlendirme kuralları getirilirken hata oluştu: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": rules})
+}
+
+// CreateRule yeni bir yönlendirme kuralı oluşturur
+func (h *RoutingHandler) CreateRule(c *gin.Context) {
+	var rule model.RoutingRule
+	if err := c.ShouldBindJSON(&rule); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Geçersiz veri: " + err.Error()})
+		return
+	}
+
+	if err := h.routingService.CreateRule(&rule); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Yönlendirme kuralı oluşturulamadı: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"data": rule, "message": "Yönlendirme kuralı başarıyla oluşturuldu"})
+}
+
+// UpdateRule bir yönlendirme kuralını günceller
+func (h *RoutingHandler) UpdateRule(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Geçersiz kural ID'si"})
+		return
+	}
+
+	var rule model.RoutingRule
+	if err := c.ShouldBindJSON(&rule); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Geçersiz veri: " + err.Error()})
+		return
+	}
+
+	rule.ID = uint(id)
+	if err := h.routingService.UpdateRule(&rule); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Yönlendirme kuralı güncellenemedi: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": rule, "message": "Yönlendirme kuralı başarıyla güncellendi"})
+}
+
+// DeleteRule bir yönlendirme kuralını siler
+func (h *RoutingHandler) DeleteRule(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Geçersiz kural ID'si"})
+		return
+	}
+
+	if err := h.routingService.DeleteRule(uint(id)); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Yönlendirme kuralı başarıyla silindi"})
+}
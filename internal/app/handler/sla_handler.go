@@ -0,0 +1,244 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/UmutTKMN/go-backend/internal/app/model"
+	"github.com/UmutTKMN/go-backend/internal/app/services"
+	"github.com/gin-gonic/gin"
+)
+
+// SLAHandler SLA politikaları ve mesai takvimleri için handler
+type SLAHandler struct {
+	slaService     *services.SLAService
+	privacyService *services.PrivacyService
+}
+
+// NewSLAHandler yeni bir SLAHandler örneği oluşturur
+func NewSLAHandler() *SLAHandler {
+	return &SLAHandler{
+		slaService:     services.NewSLAService(),
+		privacyService: services.NewPrivacyService(),
+	}
+}
+
+// GetAllPolicies tüm SLA politikalarını getirir
+func (h *SLAHandler) GetAllPolicies(c *gin.Context) {
+	policies, err := h.slaService.GetAllPolicies()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "SLA politikaları getirilirken hata oluştu: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": policies})
+}
+
+// GetPolicyByID ID'ye göre SLA politikası getirir
+func (h *SLAHandler) GetPolicyByID(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Geçersiz SLA politikası ID'si"})
+		return
+	}
+
+	policy, err := h.slaService.GetPolicyByID(uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	viewer, ok := privacyViewer(c, h.privacyService)
+	if !ok {
+		return
+	}
+	data, err := services.FilterSLAPolicy(policy, viewer)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": data})
+}
+
+// CreatePolicy yeni bir SLA politikası oluşturur
+func (h *SLAHandler) CreatePolicy(c *gin.Context) {
+	var policy model.SLAPolicy
+	if err := c.ShouldBindJSON(&policy); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Geçersiz veri: " + err.Error()})
+		return
+	}
+
+	if err := h.slaService.CreatePolicy(&policy); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "SLA politikası oluşturulamadı: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"data": policy, "message": "SLA politikası başarıyla oluşturuldu"})
+}
+
+// UpdatePolicy bir SLA politikasını günceller
+func (h *SLAHandler) UpdatePolicy(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Geçersiz SLA politikası ID'si"})
+		return
+	}
+
+	var policy model.SLAPolicy
+	if err := c.ShouldBindJSON(&policy); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Geçersiz veri: " + err.Error()})
+		return
+	}
+
+	policy.ID = uint(id)
+	if err := h.slaService.UpdatePolicy(&policy); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "SLA politikası güncellenemedi: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": policy, "message": "SLA politikası başarıyla güncellendi"})
+}
+
+// DeletePolicy bir SLA politikasını siler
+func (h *SLAHandler) DeletePolicy(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Geçersiz SLA politikası ID'si"})
+		return
+	}
+
+	if err := h.slaService.DeletePolicy(uint(id)); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "SLA politikası başarıyla silindi"})
+}
+
+// GetAllCalendars tüm mesai takvimlerini getirir
+func (h *SLAHandler) GetAllCalendars(c *gin.Context) {
+	calendars, err := h.slaService.GetAllCalendars()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Mesai takvimleri getirilirken hata oluştu: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": calendars})
+}
+
+// CreateCalendar yeni bir mesai takvimi oluşturur
+func (h *SLAHandler) CreateCalendar(c *gin.Context) {
+	var calendar model.BusinessCalendar
+	if err := c.ShouldBindJSON(&calendar); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Geçersiz veri: " + err.Error()})
+		return
+	}
+
+	if err := h.slaService.CreateCalendar(&calendar); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Mesai takvimi oluşturulamadı: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"data": calendar, "message": "Mesai takvimi başarıyla oluşturuldu"})
+}
+
+// UpdateCalendar bir mesai takvimini günceller
+func (h *SLAHandler) UpdateCalendar(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Geçersiz takvim ID'si"})
+		return
+	}
+
+	var calendar model.BusinessCalendar
+	if err := c.ShouldBindJSON(&calendar); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Geçersiz veri: " + err.Error()})
+		return
+	}
+
+	calendar.ID = uint(id)
+	if err := h.slaService.UpdateCalendar(&calendar); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Mesai takvimi güncellenemedi: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": calendar, "message": "Mesai takvimi başarıyla güncellendi"})
+}
+
+// DeleteCalendar bir mesai takvimini siler
+func (h *SLAHandler) DeleteCalendar(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Geçersiz takvim ID'si"})
+		return
+	}
+
+	if err := h.slaService.DeleteCalendar(uint(id)); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Mesai takvimi başarıyla silindi"})
+}
+
+// AddHoliday mesai takvimine tatil günü ekler
+func (h *SLAHandler) AddHoliday(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Geçersiz takvim ID'si"})
+		return
+	}
+
+	var request struct {
+		Date string `json:"date" binding:"required"`
+		Name string `json:"name"`
+	}
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Geçersiz veri: " + err.Error()})
+		return
+	}
+
+	date, err := time.Parse("2006-01-02", request.Date)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Geçersiz tarih, beklenen format: YYYY-MM-DD"})
+		return
+	}
+
+	holiday := model.BusinessHoliday{
+		CalendarID: uint(id),
+		Date:       date,
+		Name:       request.Name,
+	}
+
+	if err := h.slaService.AddHoliday(&holiday); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"data": holiday, "message": "Tatil günü eklendi"})
+}
+
+// RemoveHoliday mesai takviminden tatil gününü kaldırır
+func (h *SLAHandler) RemoveHoliday(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Geçersiz takvim ID'si"})
+		return
+	}
+
+	holidayID, err := strconv.ParseUint(c.Param("holidayId"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Geçersiz tatil ID'si"})
+		return
+	}
+
+	if err := h.slaService.RemoveHoliday(uint(id), uint(holidayID)); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Tatil günü kaldırıldı"})
+}
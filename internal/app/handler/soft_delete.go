@@ -0,0 +1,28 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/UmutTKMN/go-backend/internal/app/services"
+	"github.com/gin-gonic/gin"
+)
+
+// includeDeleted listelerde silinmiş kayıtların da istenip istenmediğini döndürür. include_deleted seçeneği
+// yalnızca yöneticilere açıktır; yetkisiz isteklerde yanıt yazılır ve ikinci değer false döner.
+func includeDeleted(c *gin.Context) (bool, bool) {
+	include, _ := strconv.ParseBool(c.Query("include_deleted"))
+	if !include {
+		return false, true
+	}
+
+	viewer, ok := privacyViewer(c, services.NewPrivacyService())
+	if !ok {
+		return false, false
+	}
+	if !viewer.IsAdmin {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Silinmiş kayıtları yalnızca yöneticiler görüntüleyebilir"})
+		return false, false
+	}
+	return true, true
+}
@@ -0,0 +1,45 @@
+package handler
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/UmutTKMN/go-backend/internal/pkg/spreadsheet"
+	"github.com/gin-gonic/gin"
+)
+
+// streamSpreadsheet sorgudaki formata (csv veya xlsx) göre satırları yanıt gövdesine akış olarak yazar.
+// produce her satır için kendisine verilen fonksiyonu çağırmalıdır.
+func streamSpreadsheet(c *gin.Context, name string, columns []string, produce func(write func([]string) error) error) {
+	format := c.DefaultQuery("format", spreadsheet.FormatCSV)
+	if format != spreadsheet.FormatCSV && format != spreadsheet.FormatXLSX {
+		c.JSON(http.StatusBadRequest, gin.H{"error": spreadsheet.ErrUnsupportedFormat.Error()})
+		return
+	}
+
+	filename := fmt.Sprintf("%s-%s.%s", name, time.Now().Format("20060102-150405"), format)
+	c.Header("Content-Type", spreadsheet.ContentType(format))
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+	c.Status(http.StatusOK)
+
+	writer, err := spreadsheet.NewWriter(format, c.Writer)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Dışa aktarma başlatılamadı: " + err.Error()})
+		return
+	}
+
+	if err := writer.WriteRow(columns); err == nil {
+		err = produce(writer.WriteRow)
+	}
+	if err == nil {
+		err = writer.Close()
+	}
+
+	// Yanıt gövdesi yazılmaya başlandığından hata yalnızca günlüğe kaydedilebilir
+	if err != nil {
+		log.Printf("Dışa aktarma yarıda kesildi (%s): %v", name, err)
+		c.Abort()
+	}
+}
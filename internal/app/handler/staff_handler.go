@@ -1,37 +1,147 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/UmutTKMN/go-backend/internal/app/model"
 	"github.com/UmutTKMN/go-backend/internal/app/services"
+	"github.com/UmutTKMN/go-backend/internal/pkg/listquery"
+	"github.com/UmutTKMN/go-backend/internal/pkg/spreadsheet"
 	"github.com/gin-gonic/gin"
 )
 
 // StaffHandler personel işlemleri için handler
 type StaffHandler struct {
-	staffService *services.StaffService
-	userService  *services.UserService
+	staffService       *services.StaffService
+	staffImportService *services.StaffImportService
+	userService        *services.UserService
+	requestService     *services.RequestService
+	privacyService     *services.PrivacyService
 }
 
 // NewStaffHandler yeni bir StaffHandler örneği oluşturur
 func NewStaffHandler() *StaffHandler {
 	return &StaffHandler{
-		staffService: services.NewStaffService(),
-		userService:  services.NewUserService(),
+		staffService:       services.NewStaffService(),
+		staffImportService: services.NewStaffImportService(),
+		userService:        services.NewUserService(),
+		requestService:     services.NewRequestService(),
+		privacyService:     services.NewPrivacyService(),
 	}
 }
 
-// GetAllStaff tüm personel bilgilerini getirir
+// GetAllStaff personel listesini filtreleyerek, sıralayarak ve sayfalayarak getirir.
+// Filtreler: department, role_id, status, manager_id, hired_from, hired_to, access_level,
+// min_access_level, max_access_level. Arama: q. Sıralama: sort. Sayfalama: limit, cursor.
+// Yöneticiler include_deleted=true ile silinmiş kayıtları da listeleyebilir.
 func (h *StaffHandler) GetAllStaff(c *gin.Context) {
-	staffList, err := h.staffService.GetAllStaff()
+	query, err := listquery.Parse(c.Request.URL.Query(), services.StaffListSpec)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	filter, err := parseStaffFilter(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	withDeleted, ok := includeDeleted(c)
+	if !ok {
+		return
+	}
+	filter.IncludeDeleted = withDeleted
+
+	page, err := h.staffService.SearchStaff(filter, query)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Personel bilgileri getirilirken hata oluştu: " + err.Error()})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"data": staffList})
+	items, ok := h.filterStaffList(c, page.Items)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": items,
+		"meta": gin.H{
+			"limit":       page.Limit,
+			"has_more":    page.HasMore,
+			"next_cursor": page.NextCursor,
+		},
+	})
+}
+
+// parseStaffFilter personel listesi filtrelerini sorgu parametrelerinden okur
+func parseStaffFilter(c *gin.Context) (services.StaffFilter, error) {
+	filter := services.StaffFilter{
+		Department: c.Query("department"),
+		Status:     c.Query("status"),
+	}
+
+	if value := c.Query("role_id"); value != "" {
+		id, err := strconv.ParseUint(value, 10, 32)
+		if err != nil {
+			return filter, errors.New("geçersiz rol ID'si")
+		}
+		filter.RoleID = uint(id)
+	}
+
+	if value := c.Query("manager_id"); value != "" {
+		id, err := strconv.ParseUint(value, 10, 32)
+		if err != nil {
+			return filter, errors.New("geçersiz yönetici ID'si")
+		}
+		filter.ManagerID = uint(id)
+	}
+
+	if value := c.Query("hired_from"); value != "" {
+		date, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			return filter, errors.New("geçersiz işe giriş başlangıç tarihi, beklenen format: YYYY-MM-DD")
+		}
+		filter.HiredFrom = &date
+	}
+
+	if value := c.Query("hired_to"); value != "" {
+		date, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			return filter, errors.New("geçersiz işe giriş bitiş tarihi, beklenen format: YYYY-MM-DD")
+		}
+		filter.HiredTo = &date
+	}
+
+	// access_level tam eşleşme için alt ve üst sınırı birlikte ayarlar
+	if value := c.Query("access_level"); value != "" {
+		level, err := strconv.Atoi(value)
+		if err != nil {
+			return filter, errors.New("geçersiz erişim seviyesi")
+		}
+		filter.MinAccessLevel = &level
+		filter.MaxAccessLevel = &level
+	}
+
+	if value := c.Query("min_access_level"); value != "" {
+		level, err := strconv.Atoi(value)
+		if err != nil {
+			return filter, errors.New("geçersiz en düşük erişim seviyesi")
+		}
+		filter.MinAccessLevel = &level
+	}
+
+	if value := c.Query("max_access_level"); value != "" {
+		level, err := strconv.Atoi(value)
+		if err != nil {
+			return filter, errors.New("geçersiz en yüksek erişim seviyesi")
+		}
+		filter.MaxAccessLevel = &level
+	}
+
+	return filter, nil
 }
 
 // GetStaffByID ID'ye göre personel bilgisi getirir
@@ -48,7 +158,7 @@ func (h *StaffHandler) GetStaffByID(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"data": staff})
+	h.respondStaff(c, staff)
 }
 
 // GetStaffByUserID kullanıcı ID'sine göre personel bilgisi getirir
@@ -65,7 +175,44 @@ func (h *StaffHandler) GetStaffByUserID(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"data": staff})
+	h.respondStaff(c, staff)
+}
+
+// respondStaff personel kaydını kullanıcı bilgisi gizlilik ayarlarına göre sınırlanmış olarak döndürür
+func (h *StaffHandler) respondStaff(c *gin.Context, staff *model.Staff) {
+	viewer, ok := privacyViewer(c, h.privacyService)
+	if !ok {
+		return
+	}
+	data, err := services.FilterStaff(staff, viewer)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": data})
+}
+
+// respondStaffList personel listesini kullanıcı bilgileri gizlilik ayarlarına göre sınırlanmış olarak döndürür
+func (h *StaffHandler) respondStaffList(c *gin.Context, staffList []model.Staff) {
+	items, ok := h.filterStaffList(c, staffList)
+	if !ok {
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": items})
+}
+
+// filterStaffList personel listesine görüntüleyenin gizlilik kısıtlarını uygular; hata yanıtını kendisi yazar
+func (h *StaffHandler) filterStaffList(c *gin.Context, staffList []model.Staff) ([]map[string]interface{}, bool) {
+	viewer, ok := privacyViewer(c, h.privacyService)
+	if !ok {
+		return nil, false
+	}
+	items, err := services.FilterStaffList(staffList, viewer)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return nil, false
+	}
+	return items, true
 }
 
 // CreateStaff yeni bir personel kaydı oluşturur
@@ -141,6 +288,23 @@ func (h *StaffHandler) DeleteStaff(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"message": "Personel kaydı başarıyla silindi"})
 }
 
+// RestoreStaff silinmiş bir personel kaydını geri yükler
+func (h *StaffHandler) RestoreStaff(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Geçersiz personel ID'si"})
+		return
+	}
+
+	staff, err := h.staffService.RestoreStaff(uint(id))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	h.respondStaff(c, staff)
+}
+
 // GetStaffByDepartment departmana göre personel listesi getirir
 func (h *StaffHandler) GetStaffByDepartment(c *gin.Context) {
 	department := c.Param("department")
@@ -155,7 +319,7 @@ func (h *StaffHandler) GetStaffByDepartment(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"data": staffList})
+	h.respondStaffList(c, staffList)
 }
 
 // GetStaffByManager yöneticiye göre personel listesi getirir
@@ -172,7 +336,7 @@ func (h *StaffHandler) GetStaffByManager(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"data": staffList})
+	h.respondStaffList(c, staffList)
 }
 
 // GetStaffByRole role göre personel listesi getirir
@@ -189,7 +353,7 @@ func (h *StaffHandler) GetStaffByRole(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"data": staffList})
+	h.respondStaffList(c, staffList)
 }
 
 // UpdateStaffPosition personelin pozisyonunu günceller
@@ -242,3 +406,92 @@ func (h *StaffHandler) UpdateStaffManager(c *gin.Context) {
 
 	c.JSON(http.StatusOK, gin.H{"message": "Personel yöneticisi başarıyla güncellendi"})
 }
+
+// ImportStaff CSV veya XLSX dosyasından toplu personel kaydı oluşturur.
+// dry_run=true ile yalnızca satır bazlı doğrulama sonuçları döner.
+func (h *StaffHandler) ImportStaff(c *gin.Context) {
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Dosya bulunamadı: " + err.Error()})
+		return
+	}
+
+	format, err := spreadsheet.FormatFromFilename(fileHeader.Filename)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	dryRun, _ := strconv.ParseBool(c.DefaultQuery("dry_run", "false"))
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Dosya açılamadı: " + err.Error()})
+		return
+	}
+	defer file.Close()
+
+	rows, err := spreadsheet.ReadRows(format, file)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Dosya okunamadı: " + err.Error()})
+		return
+	}
+
+	result, err := h.staffImportService.ImportStaff(rows, dryRun)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Personel içe aktarılamadı: " + err.Error()})
+		return
+	}
+
+	switch {
+	case len(result.Errors) > 0:
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"data": result, "error": "Dosyada hatalı satırlar var, hiçbir kayıt oluşturulmadı"})
+	case dryRun:
+		c.JSON(http.StatusOK, gin.H{"data": result, "message": "Dosya doğrulandı, hata bulunamadı"})
+	default:
+		c.JSON(http.StatusCreated, gin.H{"data": result, "message": "Personel kayıtları başarıyla içe aktarıldı"})
+	}
+}
+
+// ExportStaff tüm personel kayıtlarını CSV veya XLSX olarak dışa aktarır
+func (h *StaffHandler) ExportStaff(c *gin.Context) {
+	streamSpreadsheet(c, "staff", services.StaffExportColumns, h.staffImportService.ExportStaff)
+}
+
+// GetMyFollowUps oturum açmış personele atanmış, takip tarihi geçmiş ve yaklaşan talepleri getirir.
+// days ile yaklaşan takiplerin kaç gün ilerisine bakılacağı belirlenir (varsayılan 14).
+func (h *StaffHandler) GetMyFollowUps(c *gin.Context) {
+	staff, ok := currentStaff(c, h.staffService)
+	if !ok {
+		return
+	}
+
+	days, err := strconv.Atoi(c.DefaultQuery("days", "14"))
+	if err != nil || days < 0 || days > 365 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Geçersiz gün sayısı, 0 ile 365 arasında olmalıdır"})
+		return
+	}
+
+	followUps, err := h.requestService.GetFollowUps(staff.ID, days)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Takipler getirilirken hata oluştu: " + err.Error()})
+		return
+	}
+
+	viewer, ok := privacyViewer(c, h.privacyService)
+	if !ok {
+		return
+	}
+	overdue, err := services.FilterRequests(followUps.Overdue, viewer)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	upcoming, err := services.FilterRequests(followUps.Upcoming, viewer)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": gin.H{"overdue": overdue, "upcoming": upcoming}})
+}
@@ -0,0 +1,188 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/UmutTKMN/go-backend/internal/app/services"
+	"github.com/UmutTKMN/go-backend/internal/pkg/middleware"
+	"github.com/UmutTKMN/go-backend/internal/pkg/realtime"
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+)
+
+// Akış bağlantısı ayarları
+const (
+	streamHeartbeatInterval = 25 * time.Second
+	streamRetryMillis       = 5000
+	websocketWriteTimeout   = 10 * time.Second
+	websocketPongTimeout    = 60 * time.Second
+	websocketReadLimit      = 512
+)
+
+// StreamHandler bildirimler ve diğer anlık olaylar için SSE/WebSocket akışı sağlar
+type StreamHandler struct {
+	hub      *realtime.Hub
+	upgrader websocket.Upgrader
+}
+
+// NewStreamHandler yeni bir StreamHandler örneği oluşturur
+func NewStreamHandler(hub *realtime.Hub) *StreamHandler {
+	return &StreamHandler{
+		hub: hub,
+		upgrader: websocket.Upgrader{
+			ReadBufferSize:  1024,
+			WriteBufferSize: 1024,
+			// Kimlik doğrulama çerez değil token ile yapıldığından kaynak kısıtlaması gerekmez
+			CheckOrigin: func(r *http.Request) bool { return true },
+		},
+	}
+}
+
+// Stream oturum açmış kullanıcının anlık olaylarını iletir. WebSocket yükseltme isteği gelirse
+// WebSocket, aksi halde Server-Sent Events kullanılır. session.logout olayından sonra veya token'ın
+// süresi dolduğunda bağlantı kapatılır; istemci yeni token ile yeniden bağlanır.
+func (h *StreamHandler) Stream(c *gin.Context) {
+	userID, exists := middleware.GetCurrentUserID(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Kullanıcı bulunamadı"})
+		return
+	}
+
+	// Süre bilgisi olmayan token'larla açılan bağlantılar süresiz kalmaz, hemen kapanır
+	expiresAt, _ := middleware.GetTokenExpiry(c)
+	expiry := time.NewTimer(time.Until(expiresAt))
+	defer expiry.Stop()
+
+	if websocket.IsWebSocketUpgrade(c.Request) {
+		h.serveWebSocket(c, userID, expiry.C)
+		return
+	}
+	h.serveSSE(c, userID, expiry.C)
+}
+
+// serveSSE olayları text/event-stream olarak iletir; bağlantıyı açık tutmak için düzenli yorum satırı gönderir
+func (h *StreamHandler) serveSSE(c *gin.Context, userID uint, expired <-chan time.Time) {
+	subscription := h.hub.Subscribe(userID)
+	defer subscription.Close()
+
+	header := c.Writer.Header()
+	header.Set("Content-Type", "text/event-stream")
+	header.Set("Cache-Control", "no-cache")
+	header.Set("Connection", "keep-alive")
+	header.Set("X-Accel-Buffering", "no") // Ters vekil sunucuların arabelleğe almasını engeller
+	c.Status(http.StatusOK)
+
+	fmt.Fprintf(c.Writer, "retry: %d\n\n", streamRetryMillis)
+	writeSSE(c.Writer, realtime.Event{Type: "ready", UserID: userID})
+	c.Writer.Flush()
+
+	heartbeat := time.NewTicker(streamHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		case <-expired:
+			return
+		case event, ok := <-subscription.Events():
+			// Kanal, abone olayları yeterince hızlı okuyamadığında kapatılır; istemci yeniden bağlanır
+			if !ok {
+				return
+			}
+			writeSSE(c.Writer, event)
+			c.Writer.Flush()
+			if event.Type == services.EventForcedLogout {
+				return
+			}
+		case <-heartbeat.C:
+			fmt.Fprint(c.Writer, ": ping\n\n")
+			c.Writer.Flush()
+		}
+	}
+}
+
+// writeSSE olayı SSE biçiminde yazar; JSON çıktısı satır sonu içermediğinden tek data satırı yeterlidir
+func writeSSE(w gin.ResponseWriter, event realtime.Event) {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return
+	}
+	fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event.Type, payload)
+}
+
+// serveWebSocket olayları JSON metin mesajları olarak iletir. İstemciden gelen mesajlar yalnızca
+// bağlantının kapandığını fark etmek için okunur.
+func (h *StreamHandler) serveWebSocket(c *gin.Context, userID uint, expired <-chan time.Time) {
+	conn, err := h.upgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		// Upgrader hata yanıtını kendisi yazar
+		return
+	}
+	defer conn.Close()
+
+	subscription := h.hub.Subscribe(userID)
+	defer subscription.Close()
+
+	conn.SetReadLimit(websocketReadLimit)
+	conn.SetReadDeadline(time.Now().Add(websocketPongTimeout))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(websocketPongTimeout))
+	})
+
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	write := func(event realtime.Event) error {
+		conn.SetWriteDeadline(time.Now().Add(websocketWriteTimeout))
+		return conn.WriteJSON(event)
+	}
+	if err := write(realtime.Event{Type: "ready", UserID: userID}); err != nil {
+		return
+	}
+
+	heartbeat := time.NewTicker(streamHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-closed:
+			return
+		case <-expired:
+			conn.WriteControl(websocket.CloseMessage,
+				websocket.FormatCloseMessage(websocket.ClosePolicyViolation, "oturum süresi doldu"),
+				time.Now().Add(websocketWriteTimeout))
+			return
+		case event, ok := <-subscription.Events():
+			if !ok {
+				conn.WriteControl(websocket.CloseMessage,
+					websocket.FormatCloseMessage(websocket.CloseTryAgainLater, "yeniden bağlanın"),
+					time.Now().Add(websocketWriteTimeout))
+				return
+			}
+			if err := write(event); err != nil {
+				return
+			}
+			if event.Type == services.EventForcedLogout {
+				conn.WriteControl(websocket.CloseMessage,
+					websocket.FormatCloseMessage(websocket.ClosePolicyViolation, "oturum sonlandırıldı"),
+					time.Now().Add(websocketWriteTimeout))
+				return
+			}
+		case <-heartbeat.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(websocketWriteTimeout)); err != nil {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,130 @@
+package handler
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/UmutTKMN/go-backend/internal/app/model"
+	"github.com/UmutTKMN/go-backend/internal/app/services"
+	"github.com/UmutTKMN/go-backend/internal/pkg/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+// TemplateHandler mesaj şablonlarının yönetimi için handler
+type TemplateHandler struct {
+	templateService *services.TemplateService
+}
+
+// NewTemplateHandler yeni bir TemplateHandler örneği oluşturur
+func NewTemplateHandler() *TemplateHandler {
+	return &TemplateHandler{
+		templateService: services.NewTemplateService(),
+	}
+}
+
+// templateRequest şablon düzenleme ve önizleme isteği
+type templateRequest struct {
+	Subject  string                 `json:"subject"`
+	BodyText string                 `json:"body_text"`
+	BodyHTML string                 `json:"body_html"`
+	Data     map[string]interface{} `json:"data"`
+}
+
+// GetAllTemplates tüm şablonları varsayılan ve düzenlenmiş sürüm bilgisiyle listeler
+func (h *TemplateHandler) GetAllTemplates(c *gin.Context) {
+	infos, err := h.templateService.ListTemplates()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Şablonlar getirilirken hata oluştu: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": infos})
+}
+
+// GetTemplate ad ve dil için geçerli şablonu getirir
+func (h *TemplateHandler) GetTemplate(c *gin.Context) {
+	tmpl, isCustom, err := h.templateService.GetTemplate(c.Param("name"), c.Param("language"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": tmpl, "meta": gin.H{"is_custom": isCustom}})
+}
+
+// UpdateTemplate şablonun düzenlenmiş sürümünü kaydeder
+func (h *TemplateHandler) UpdateTemplate(c *gin.Context) {
+	userID, exists := middleware.GetCurrentUserID(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Kullanıcı bulunamadı"})
+		return
+	}
+
+	var request templateRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Geçersiz veri: " + err.Error()})
+		return
+	}
+
+	tmpl := model.MessageTemplate{
+		Name:      c.Param("name"),
+		Language:  c.Param("language"),
+		Subject:   request.Subject,
+		BodyText:  request.BodyText,
+		BodyHTML:  request.BodyHTML,
+		UpdatedBy: userID,
+	}
+	if err := h.templateService.SaveTemplate(&tmpl); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Şablon kaydedilemedi: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": tmpl, "message": "Şablon başarıyla kaydedildi"})
+}
+
+// DeleteTemplate şablonun düzenlenmiş sürümünü siler ve varsayılana döner
+func (h *TemplateHandler) DeleteTemplate(c *gin.Context) {
+	if err := h.templateService.DeleteTemplate(c.Param("name"), c.Param("language")); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Şablon varsayılana döndürüldü"})
+}
+
+// PreviewTemplate şablonu verilen örnek verilerle işler. Gövdede subject ve body_text
+// gönderilirse kaydedilmemiş taslak, aksi halde geçerli şablon önizlenir.
+func (h *TemplateHandler) PreviewTemplate(c *gin.Context) {
+	var request templateRequest
+
+	// Yalnızca geçerli şablon önizlenecekse gövde boş olabilir
+	if err := c.ShouldBindJSON(&request); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Geçersiz veri: " + err.Error()})
+		return
+	}
+
+	tmpl := &model.MessageTemplate{
+		Name:     c.Param("name"),
+		Language: c.Param("language"),
+		Subject:  request.Subject,
+		BodyText: request.BodyText,
+		BodyHTML: request.BodyHTML,
+	}
+	if request.Subject == "" && request.BodyText == "" {
+		current, _, err := h.templateService.GetTemplate(tmpl.Name, tmpl.Language)
+		if err != nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		tmpl = current
+	}
+
+	message, err := h.templateService.Preview(tmpl, request.Data)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Şablon işlenemedi: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": message})
+}
@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
@@ -10,14 +11,16 @@ import (
 )
 
 type UserHandler struct {
-	userService *services.UserService
-	authService *services.AuthService
+	userService    *services.UserService
+	authService    *services.AuthService
+	privacyService *services.PrivacyService
 }
 
 func NewUserHandler(userService *services.UserService, authService *services.AuthService) *UserHandler {
 	return &UserHandler{
-		userService: userService,
-		authService: authService,
+		userService:    userService,
+		authService:    authService,
+		privacyService: services.NewPrivacyService(),
 	}
 }
 
@@ -31,6 +34,10 @@ func (h *UserHandler) Register(c *gin.Context) {
 
 	user, err := h.authService.Register(&req)
 	if err != nil {
+		if errors.Is(err, services.ErrEmailTaken) || errors.Is(err, services.ErrUsernameTaken) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -55,7 +62,7 @@ func (h *UserHandler) Login(c *gin.Context) {
 	c.JSON(http.StatusOK, response)
 }
 
-// GetUser kullanıcı bilgilerini getirir
+// GetUser kullanıcı bilgilerini, kullanıcının gizlilik ayarlarına göre görüntüleyenin görebileceği alanlarla getirir
 func (h *UserHandler) GetUser(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
@@ -69,7 +76,17 @@ func (h *UserHandler) GetUser(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, user)
+	viewer, ok := privacyViewer(c, h.privacyService)
+	if !ok {
+		return
+	}
+	data, err := services.FilterUser(user, viewer)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, data)
 }
 
 // UpdateUser kullanıcı bilgilerini günceller
@@ -88,6 +105,10 @@ func (h *UserHandler) UpdateUser(c *gin.Context) {
 
 	user, err := h.userService.UpdateUser(uint(id), updates)
 	if err != nil {
+		if errors.Is(err, services.ErrEmailTaken) || errors.Is(err, services.ErrUsernameTaken) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -95,7 +116,7 @@ func (h *UserHandler) UpdateUser(c *gin.Context) {
 	c.JSON(http.StatusOK, user)
 }
 
-// DeleteUser kullanıcıyı siler
+// DeleteUser kullanıcıyı geri yüklenebilir şekilde siler; saklama süresi sonunda kişisel verileri anonimleştirilir
 func (h *UserHandler) DeleteUser(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
@@ -104,19 +125,57 @@ func (h *UserHandler) DeleteUser(c *gin.Context) {
 	}
 
 	if err := h.userService.DeleteUser(uint(id)); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		if errors.Is(err, services.ErrUserHasStaff) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{"message": "Kullanıcı başarıyla silindi"})
 }
 
-// GetAllUsers tüm kullanıcıları getirir
+// RestoreUser silinmiş kullanıcıyı geri yükler
+func (h *UserHandler) RestoreUser(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Geçersiz kullanıcı ID'si"})
+		return
+	}
+
+	user, err := h.userService.RestoreUser(uint(id))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":    user,
+		"message": "Kullanıcı başarıyla geri yüklendi",
+	})
+}
+
+// GetAllUsers tüm kullanıcıları gizlilik ayarlarına göre sınırlanmış alanlarla getirir
 func (h *UserHandler) GetAllUsers(c *gin.Context) {
 	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
 	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
 
-	users, total, err := h.userService.GetAllUsers(page, limit)
+	viewer, ok := privacyViewer(c, h.privacyService)
+	if !ok {
+		return
+	}
+	withDeleted, ok := includeDeleted(c)
+	if !ok {
+		return
+	}
+
+	list, total, err := h.userService.GetAllUsers(page, limit, withDeleted)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	users, err := services.FilterUsers(list, viewer)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -132,3 +191,8 @@ func (h *UserHandler) GetAllUsers(c *gin.Context) {
 		},
 	})
 }
+
+// ExportUsers tüm kullanıcıları CSV veya XLSX olarak dışa aktarır
+func (h *UserHandler) ExportUsers(c *gin.Context) {
+	streamSpreadsheet(c, "users", services.UserExportColumns, h.userService.ExportUsers)
+}
@@ -0,0 +1,60 @@
+package model
+
+import "time"
+
+// Duyuru durumları
+const (
+	AnnouncementStatusScheduled = "scheduled"
+	AnnouncementStatusSending   = "sending"
+	AnnouncementStatusSent      = "sent"
+	AnnouncementStatusCancelled = "cancelled"
+)
+
+// AnnouncementCategories duyurularda kullanılabilecek bildirim kategorileri
+var AnnouncementCategories = []string{
+	NotificationCategoryAnnouncements,
+	NotificationCategorySecurity,
+	NotificationCategoryMarketing,
+}
+
+// IsValidAnnouncementCategory kategorinin duyurularda kullanılıp kullanılamayacağını döndürür
+func IsValidAnnouncementCategory(category string) bool {
+	return contains(AnnouncementCategories, category)
+}
+
+// AnnouncementAudience duyurunun hedef kitlesi. All seçilmezse kullanıcı verilen rollerden, departmanlardan
+// (personel kaydına göre) veya hesap türlerinden herhangi birine uyuyorsa duyuruyu alır.
+type AnnouncementAudience struct {
+	All          bool     `json:"all"`
+	RoleIDs      []uint   `json:"role_ids,omitempty"`
+	Departments  []string `json:"departments,omitempty"`
+	AccountTypes []string `json:"account_types,omitempty"`
+}
+
+// IsEmpty hedef kitlede hiçbir seçim olup olmadığını döndürür
+func (a AnnouncementAudience) IsEmpty() bool {
+	return !a.All && len(a.RoleIDs) == 0 && len(a.Departments) == 0 && len(a.AccountTypes) == 0
+}
+
+// Announcement yöneticilerin hedef kitleye gönderdiği toplu duyuru.
+// Dağıtım arka planda kullanıcı ID sırasıyla parçalar halinde yapılır; LastUserID kaldığı yeri tutar.
+type Announcement struct {
+	ID             uint                 `json:"id" gorm:"primaryKey"`
+	Subject        string               `json:"subject" gorm:"not null"`
+	Content        string               `json:"content" gorm:"type:text;not null"`
+	Category       string               `json:"category" gorm:"not null;default:'announcements'"`
+	Importance     string               `json:"importance" gorm:"not null;default:'normal'"`
+	Audience       AnnouncementAudience `json:"audience" gorm:"serializer:json;type:json"`
+	Status         string               `json:"status" gorm:"not null;default:'scheduled';index:idx_announcement_due,priority:1"`
+	ScheduledAt    time.Time            `json:"scheduled_at" gorm:"index:idx_announcement_due,priority:2"`
+	CreatedBy      uint                 `json:"created_by"`
+	RecipientCount int                  `json:"recipient_count" gorm:"not null;default:0"`
+	LastUserID     uint                 `json:"-" gorm:"not null;default:0"`
+	StartedAt      *time.Time           `json:"started_at"`
+	CompletedAt    *time.Time           `json:"completed_at"`
+	CreatedAt      time.Time            `json:"created_at"`
+	UpdatedAt      time.Time            `json:"updated_at"`
+
+	// İlişkiler
+	Creator *User `json:"creator,omitempty" gorm:"foreignKey:CreatedBy"`
+}
@@ -24,8 +24,9 @@ type LoginRequest struct {
 
 // AuthResponse kimlik doğrulama yanıtını temsil eder
 type AuthResponse struct {
-	Token string `json:"token"`
-	User  User   `json:"user"`
+	Token             string `json:"token"`
+	User              User   `json:"user"`
+	DeletionCancelled bool   `json:"deletion_cancelled,omitempty"` // Giriş, planlanmış hesap silme işlemini iptal etti
 }
 
 // TokenClaims token içindeki bilgileri temsil eder
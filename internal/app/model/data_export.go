@@ -0,0 +1,41 @@
+package model
+
+import "time"
+
+// Kişisel veri dışa aktarma durumları
+const (
+	DataExportStatusPending    = "pending"
+	DataExportStatusProcessing = "processing"
+	DataExportStatusReady      = "ready"
+	DataExportStatusFailed     = "failed"
+	DataExportStatusExpired    = "expired"
+)
+
+// DataExport kullanıcının kişisel verilerinin ZIP arşivi olarak dışa aktarılma isteği.
+// Arşiv arka planda hazırlanır ve ExpiresAt zamanına kadar indirilebilir, sonra silinir.
+type DataExport struct {
+	ID            uint       `json:"id" gorm:"primaryKey"`
+	UserID        uint       `json:"user_id" gorm:"not null;index"`
+	Status        string     `json:"status" gorm:"not null;default:'pending';index:idx_data_export_due,priority:1"`
+	AvailableAt   time.Time  `json:"-" gorm:"index:idx_data_export_due,priority:2"` // İşlenmeye uygun olduğu zaman; işlenirken kira süresi
+	Attempts      int        `json:"attempts" gorm:"not null;default:0"`
+	LastError     string     `json:"-" gorm:"type:text"`
+	StoragePath   string     `json:"-"`
+	FileName      string     `json:"file_name"`
+	Size          int64      `json:"size"`
+	Checksum      string     `json:"checksum"` // SHA-256, onaltılık
+	StartedAt     *time.Time `json:"started_at"`
+	CompletedAt   *time.Time `json:"completed_at"`
+	ExpiresAt     *time.Time `json:"expires_at"`
+	DownloadCount int        `json:"download_count" gorm:"not null;default:0"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+
+	// İlişkiler
+	User *User `json:"-" gorm:"foreignKey:UserID"`
+}
+
+// IsDownloadable arşivin hazır olduğunu ve süresinin dolmadığını döndürür
+func (e *DataExport) IsDownloadable(now time.Time) bool {
+	return e.Status == DataExportStatusReady && e.StoragePath != "" && e.ExpiresAt != nil && now.Before(*e.ExpiresAt)
+}
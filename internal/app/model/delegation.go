@@ -0,0 +1,36 @@
+package model
+
+import "time"
+
+// Yetki devri kapsamları. Yetki devirleri yalnızca izin onayında kontrol edilir; "all" ileride eklenecek
+// onay türlerini de kapsar.
+const (
+	DelegationScopeAll   = "all"
+	DelegationScopeLeave = "leave"
+)
+
+// Yetki devri durumları
+const (
+	DelegationStatusActive    = "active"
+	DelegationStatusCancelled = "cancelled"
+)
+
+// Delegation bir yöneticinin onay yetkisini belirli bir süre için başka bir personele devretmesini tanımlar
+type Delegation struct {
+	ID               uint       `json:"id" gorm:"primaryKey"`
+	DelegatorStaffID uint       `json:"delegator_staff_id" gorm:"not null;index"`
+	DelegateStaffID  uint       `json:"delegate_staff_id" gorm:"not null;index"`
+	StartDate        time.Time  `json:"start_date" gorm:"not null"`
+	EndDate          time.Time  `json:"end_date" gorm:"not null"`
+	Scope            string     `json:"scope" gorm:"not null;default:'all'"`
+	Status           string     `json:"status" gorm:"not null;default:'active'"`
+	Reason           string     `json:"reason" gorm:"type:text"`
+	CreatedByUserID  uint       `json:"created_by_user_id"`
+	CancelledAt      *time.Time `json:"cancelled_at"`
+	CreatedAt        time.Time  `json:"created_at"`
+	UpdatedAt        time.Time  `json:"updated_at"`
+
+	// İlişkiler
+	Delegator *Staff `json:"delegator,omitempty" gorm:"foreignKey:DelegatorStaffID"`
+	Delegate  *Staff `json:"delegate,omitempty" gorm:"foreignKey:DelegateStaffID"`
+}
@@ -0,0 +1,34 @@
+package model
+
+// Belge doğrulama durumları
+const (
+	DocumentStatusPending  = "pending"
+	DocumentStatusApproved = "approved"
+	DocumentStatusRejected = "rejected"
+)
+
+// DocumentStatuses geçerli belge doğrulama durumları
+var DocumentStatuses = []string{
+	DocumentStatusPending,
+	DocumentStatusApproved,
+	DocumentStatusRejected,
+}
+
+// Belge tarama durumları
+const (
+	ScanStatusPending  = "pending"
+	ScanStatusClean    = "clean"
+	ScanStatusInfected = "infected" // Dosya karantinaya alındı
+	ScanStatusFailed   = "failed"   // Tarama yapılamadı, yeniden denenecek
+	ScanStatusSkipped  = "skipped"  // Tarayıcı yapılandırılmamış
+)
+
+// IsAvailable belgenin taramadan geçtiğini ve indirilebileceğini döndürür
+func (d *UserDocument) IsAvailable() bool {
+	return d.ScanStatus == ScanStatusClean || d.ScanStatus == ScanStatusSkipped
+}
+
+// IsValidDocumentStatus belge doğrulama durumunun tanımlı olup olmadığını döndürür
+func IsValidDocumentStatus(status string) bool {
+	return contains(DocumentStatuses, status)
+}
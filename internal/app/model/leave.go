@@ -0,0 +1,74 @@
+package model
+
+import "time"
+
+// İzin talebi durumları
+const (
+	LeaveStatusPending   = "pending"
+	LeaveStatusApproved  = "approved"
+	LeaveStatusRejected  = "rejected"
+	LeaveStatusCancelled = "cancelled"
+)
+
+// LeaveType izin türlerini ve yıllık haklarını tanımlar.
+// YearlyAllowance 0 ise izin türü için bakiye sınırı uygulanmaz.
+type LeaveType struct {
+	ID               uint      `json:"id" gorm:"primaryKey;column:leave_type_id"`
+	Name             string    `json:"name" gorm:"unique;not null"`
+	Description      string    `json:"description" gorm:"type:text"`
+	YearlyAllowance  float64   `json:"yearly_allowance" gorm:"not null;default:0"`
+	IsPaid           bool      `json:"is_paid" gorm:"not null;default:true"`
+	RequiresApproval bool      `json:"requires_approval" gorm:"not null;default:true"`
+	CreatedAt        time.Time `json:"created_at"`
+	UpdatedAt        time.Time `json:"updated_at"`
+}
+
+// LeaveBalance personelin yıllık izin bakiyesini tutar
+type LeaveBalance struct {
+	ID          uint      `json:"id" gorm:"primaryKey"`
+	StaffID     uint      `json:"staff_id" gorm:"not null;uniqueIndex:idx_leave_balance"`
+	LeaveTypeID uint      `json:"leave_type_id" gorm:"not null;uniqueIndex:idx_leave_balance"`
+	Year        int       `json:"year" gorm:"not null;uniqueIndex:idx_leave_balance"`
+	Allowance   float64   `json:"allowance" gorm:"not null;default:0"`
+	Used        float64   `json:"used" gorm:"not null;default:0"`
+	Remaining   float64   `json:"remaining" gorm:"-"` // Database'de saklanmayacak, hesaplanacak
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+
+	// İlişkiler
+	LeaveType *LeaveType `json:"leave_type,omitempty" gorm:"foreignKey:LeaveTypeID"`
+}
+
+// LeaveRequest personelin izin talebini tanımlar
+type LeaveRequest struct {
+	ID              uint       `json:"id" gorm:"primaryKey"`
+	StaffID         uint       `json:"staff_id" gorm:"not null;index"`
+	LeaveTypeID     uint       `json:"leave_type_id" gorm:"not null"`
+	StartDate       time.Time  `json:"start_date" gorm:"not null"`
+	EndDate         time.Time  `json:"end_date" gorm:"not null"`
+	Days            float64    `json:"days" gorm:"not null"`
+	Reason          string     `json:"reason" gorm:"type:text"`
+	Status          string     `json:"status" gorm:"not null;default:'pending';index"`
+	ApproverStaffID *uint      `json:"approver_staff_id"`
+	DecidedAt       *time.Time `json:"decided_at"`
+	DecisionNotes   string     `json:"decision_notes" gorm:"type:text"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+
+	// İlişkiler
+	Staff     *Staff     `json:"staff,omitempty" gorm:"foreignKey:StaffID"`
+	LeaveType *LeaveType `json:"leave_type,omitempty" gorm:"foreignKey:LeaveTypeID"`
+	Approver  *Staff     `json:"approver,omitempty" gorm:"foreignKey:ApproverStaffID"`
+}
+
+// Covers izin talebinin verilen günü kapsayıp kapsamadığını döndürür.
+// İzin tarihleri UTC gece yarısı olarak saklanır; day kendi saat dilimindeki takvim günüyle karşılaştırılır.
+func (r *LeaveRequest) Covers(day time.Time) bool {
+	day = truncateDay(day)
+	return !day.Before(truncateDay(r.StartDate.UTC())) && !day.After(truncateDay(r.EndDate.UTC()))
+}
+
+// truncateDay t'nin kendi saat dilimindeki takvim gününü UTC gece yarısı olarak döndürür
+func truncateDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,36 @@
+package model
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLeaveRequestCoversAcrossTimeZones(t *testing.T) {
+	// Tarihler istekten YYYY-MM-DD olarak UTC gece yarısı alınır, veritabanından yerel saatle okunabilir
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("saat dilimi yüklenemedi: %v", err)
+	}
+	istanbul := time.FixedZone("Europe/Istanbul", 3*60*60)
+
+	request := LeaveRequest{
+		StartDate: time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC).In(newYork),
+		EndDate:   time.Date(2026, 3, 12, 0, 0, 0, 0, time.UTC).In(newYork),
+	}
+
+	tests := []struct {
+		name string
+		at   time.Time
+		want bool
+	}{
+		{"başlangıç günü gece yarısından hemen sonra", time.Date(2026, 3, 10, 0, 30, 0, 0, istanbul), true},
+		{"başlangıçtan önceki gece", time.Date(2026, 3, 9, 23, 30, 0, 0, istanbul), false},
+		{"bitiş günü gece yarısından hemen önce", time.Date(2026, 3, 12, 23, 30, 0, 0, newYork), true},
+		{"bitişten sonraki gün", time.Date(2026, 3, 13, 0, 30, 0, 0, newYork), false},
+	}
+	for _, tt := range tests {
+		if got := request.Covers(tt.at); got != tt.want {
+			t.Errorf("%s: Covers(%s) = %v, beklenen %v", tt.name, tt.at, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package model
+
+import "time"
+
+// Bildirim kategorileri; UserCommunication.CommunicationType alanında saklanır
+const (
+	NotificationCategorySecurity      = "security"
+	NotificationCategoryRequests      = "requests"
+	NotificationCategoryMarketing     = "marketing"
+	NotificationCategoryAnnouncements = "announcements"
+)
+
+// NotificationCategories tanımlı bildirim kategorileri
+var NotificationCategories = []string{
+	NotificationCategorySecurity,
+	NotificationCategoryRequests,
+	NotificationCategoryMarketing,
+	NotificationCategoryAnnouncements,
+}
+
+// Bildirim kanalları; uygulama içi gelen kutusu her zaman kullanılır
+const (
+	NotificationChannelInApp = "in_app"
+	NotificationChannelEmail = "email"
+	NotificationChannelSMS   = "sms"
+	NotificationChannelPush  = "push"
+)
+
+// NotificationPreferenceChannels kullanıcının kategori bazında açıp kapatabildiği kanallar
+var NotificationPreferenceChannels = []string{
+	NotificationChannelEmail,
+	NotificationChannelSMS,
+	NotificationChannelPush,
+}
+
+// IsValidNotificationCategory kategorinin tanımlı olup olmadığını döndürür
+func IsValidNotificationCategory(category string) bool {
+	return contains(NotificationCategories, category)
+}
+
+// IsValidNotificationPreferenceChannel kanalın tercihlerde kullanılabilir olup olmadığını döndürür
+func IsValidNotificationPreferenceChannel(channel string) bool {
+	return contains(NotificationPreferenceChannels, channel)
+}
+
+// NotificationPreferences User.NotificationPreferences alanında saklanan bildirim tercihleri.
+// Categories kategori -> kanal -> açık/kapalı eşlemesidir; belirtilmeyen değerler varsayılanı kullanır.
+type NotificationPreferences struct {
+	Categories map[string]map[string]bool `json:"categories"`
+	QuietHours *QuietHours                `json:"quiet_hours,omitempty"`
+}
+
+// QuietHours SMS ve push bildirimlerinin ertelendiği sessiz saatler.
+// Saatler kullanıcının Timezone alanına göre "15:04" biçimindedir; gece yarısını aşabilir (22:00-08:00).
+type QuietHours struct {
+	Enabled bool   `json:"enabled"`
+	Start   string `json:"start"`
+	End     string `json:"end"`
+}
+
+// Bildirim önem dereceleri
+const (
+	ImportanceLow    = "low"
+	ImportanceNormal = "normal"
+	ImportanceHigh   = "high"
+)
+
+// IsValidImportance önem derecesinin tanımlı olup olmadığını döndürür
+func IsValidImportance(importance string) bool {
+	return contains([]string{ImportanceLow, ImportanceNormal, ImportanceHigh}, importance)
+}
+
+// Bildirim teslim durumları
+const (
+	DeliveryStatusPending   = "pending"
+	DeliveryStatusDelivered = "delivered"
+	DeliveryStatusFailed    = "failed"
+)
+
+// NotificationDelivery bir bildirimin uygulama dışı bir kanala iletilmesi için kuyruk kaydı
+type NotificationDelivery struct {
+	ID              uint       `json:"id" gorm:"primaryKey"`
+	CommunicationID uint       `json:"communication_id" gorm:"index"`
+	UserID          uint       `json:"user_id"`
+	Channel         string     `json:"channel"`
+	Status          string     `json:"status" gorm:"index:idx_delivery_queue,priority:1"`
+	Attempts        int        `json:"attempts"`
+	NextAttemptAt   time.Time  `json:"next_attempt_at" gorm:"index:idx_delivery_queue,priority:2"`
+	LastError       string     `json:"last_error,omitempty" gorm:"type:text"`
+	DeliveredAt     *time.Time `json:"delivered_at"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+}
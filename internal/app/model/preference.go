@@ -0,0 +1,152 @@
+package model
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"unicode/utf8"
+)
+
+// Tercih değer türleri
+const (
+	PreferenceTypeString = "string"
+	PreferenceTypeBool   = "bool"
+	PreferenceTypeInt    = "int"
+	PreferenceTypeEnum   = "enum"
+)
+
+// PreferenceDefinition izin verilen bir tercih anahtarının türünü, varsayılanını ve sınırlarını tanımlar
+type PreferenceDefinition struct {
+	Key         string      `json:"key"`
+	Type        string      `json:"type"`
+	Default     interface{} `json:"default"`
+	Options     []string    `json:"options,omitempty"`
+	Min         *int        `json:"min,omitempty"`
+	Max         *int        `json:"max,omitempty"`
+	MaxLength   int         `json:"max_length,omitempty"`
+	Description string      `json:"description"`
+}
+
+func intPtr(v int) *int {
+	return &v
+}
+
+// PreferenceDefinitions kullanıcıların saklayabileceği tercih anahtarları. Listede olmayan anahtarlar
+// reddedilir; kayıtlı olmayan değerler için Default kullanılır.
+var PreferenceDefinitions = []PreferenceDefinition{
+	{
+		Key:         "dashboard.default_view",
+		Type:        PreferenceTypeEnum,
+		Default:     "overview",
+		Options:     []string{"overview", "requests", "documents", "notifications"},
+		Description: "Girişten sonra açılan panel görünümü",
+	},
+	{
+		Key:         "dashboard.page_size",
+		Type:        PreferenceTypeInt,
+		Default:     20,
+		Min:         intPtr(10),
+		Max:         intPtr(100),
+		Description: "Listelerde sayfa başına gösterilen kayıt sayısı",
+	},
+	{
+		Key:         "sidebar.collapsed",
+		Type:        PreferenceTypeBool,
+		Default:     false,
+		Description: "Kenar çubuğunun daraltılmış olarak açılması",
+	},
+	{
+		Key:         "format.date",
+		Type:        PreferenceTypeEnum,
+		Default:     "DD.MM.YYYY",
+		Options:     []string{"DD.MM.YYYY", "YYYY-MM-DD", "MM/DD/YYYY"},
+		Description: "Tarih gösterim biçimi",
+	},
+	{
+		Key:         "format.time",
+		Type:        PreferenceTypeEnum,
+		Default:     "24h",
+		Options:     []string{"24h", "12h"},
+		Description: "Saat gösterim biçimi",
+	},
+	{
+		Key:         "calendar.week_start",
+		Type:        PreferenceTypeEnum,
+		Default:     "monday",
+		Options:     []string{"monday", "sunday"},
+		Description: "Takvimde haftanın ilk günü",
+	},
+	{
+		Key:         "requests.signature",
+		Type:        PreferenceTypeString,
+		Default:     "",
+		MaxLength:   500,
+		Description: "Talep yorumlarına eklenen imza",
+	},
+}
+
+// FindPreferenceDefinition anahtarın tanımını döndürür
+func FindPreferenceDefinition(key string) (*PreferenceDefinition, bool) {
+	for i := range PreferenceDefinitions {
+		if PreferenceDefinitions[i].Key == key {
+			return &PreferenceDefinitions[i], true
+		}
+	}
+	return nil, false
+}
+
+// Parse JSON olarak verilen değeri tanımdaki türe göre çözümler ve sınırlarını doğrular
+func (d *PreferenceDefinition) Parse(raw json.RawMessage) (interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, errors.New("geçersiz tercih değeri")
+	}
+
+	switch d.Type {
+	case PreferenceTypeBool:
+		b, ok := value.(bool)
+		if !ok {
+			return nil, fmt.Errorf("%s için true veya false değeri gereklidir", d.Key)
+		}
+		return b, nil
+
+	case PreferenceTypeInt:
+		number, ok := value.(json.Number)
+		if !ok {
+			return nil, fmt.Errorf("%s için tam sayı değeri gereklidir", d.Key)
+		}
+		n, err := number.Int64()
+		if err != nil {
+			return nil, fmt.Errorf("%s için tam sayı değeri gereklidir", d.Key)
+		}
+		if d.Min != nil && n < int64(*d.Min) {
+			return nil, fmt.Errorf("%s en az %d olmalıdır", d.Key, *d.Min)
+		}
+		if d.Max != nil && n > int64(*d.Max) {
+			return nil, fmt.Errorf("%s en fazla %d olabilir", d.Key, *d.Max)
+		}
+		return int(n), nil
+
+	case PreferenceTypeString:
+		s, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("%s için metin değeri gereklidir", d.Key)
+		}
+		if d.MaxLength > 0 && utf8.RuneCountInString(s) > d.MaxLength {
+			return nil, fmt.Errorf("%s en fazla %d karakter olabilir", d.Key, d.MaxLength)
+		}
+		return s, nil
+
+	case PreferenceTypeEnum:
+		s, ok := value.(string)
+		if !ok || !contains(d.Options, s) {
+			return nil, fmt.Errorf("%s için geçersiz seçenek", d.Key)
+		}
+		return s, nil
+	}
+	return nil, fmt.Errorf("bilinmeyen tercih türü: %s", d.Type)
+}
@@ -0,0 +1,90 @@
+package model
+
+// Alan görünürlük düzeyleri
+const (
+	VisibilityPublic = "public" // Herkes
+	VisibilityStaff  = "staff"  // Personel ve yöneticiler
+	VisibilitySelf   = "self"   // Yalnızca kullanıcının kendisi (ve Admin rolü)
+)
+
+// Visibilities tanımlı görünürlük düzeyleri
+var Visibilities = []string{VisibilityPublic, VisibilityStaff, VisibilitySelf}
+
+// IsValidVisibility görünürlük düzeyinin tanımlı olup olmadığını döndürür
+func IsValidVisibility(visibility string) bool {
+	return contains(Visibilities, visibility)
+}
+
+// PrivacyFieldGroups kullanıcının gizlilik ayarlarıyla görünürlüğünü belirleyebildiği alan grupları
+// ve gruptaki JSON alanları
+var PrivacyFieldGroups = map[string][]string{
+	"email":       {"email", "secondary_email"},
+	"phone":       {"phone_number"},
+	"address":     {"address_line1", "address_line2", "state_province", "postal_code"},
+	"location":    {"city", "country"},
+	"coordinates": {"latitude", "longitude"},
+	"birth_date":  {"birth_date", "age"},
+	"bio":         {"bio"},
+	"activity":    {"last_login", "last_activity"},
+	"social":      {"facebook_id", "google_id", "twitter_id", "linkedin_id", "github_id", "apple_id", "social_logins"},
+}
+
+// PrivacyFieldDefaults kullanıcı ayar yapmadığında alan gruplarının görünürlüğü
+var PrivacyFieldDefaults = map[string]string{
+	"email":       VisibilityStaff,
+	"phone":       VisibilityStaff,
+	"address":     VisibilitySelf,
+	"location":    VisibilityPublic,
+	"coordinates": VisibilitySelf,
+	"birth_date":  VisibilitySelf,
+	"bio":         VisibilityPublic,
+	"activity":    VisibilityStaff,
+	"social":      VisibilityStaff,
+}
+
+// FixedFieldVisibility kullanıcının değiştiremediği alanların görünürlüğü.
+// Ne gruplarda ne burada bulunan alanlar yalnızca kullanıcının kendisine gösterilir.
+var FixedFieldVisibility = map[string]string{
+	"id":                 VisibilityPublic,
+	"username":           VisibilityPublic,
+	"first_name":         VisibilityPublic,
+	"last_name":          VisibilityPublic,
+	"display_name":       VisibilityPublic,
+	"profile_picture":    VisibilityPublic,
+	"created_at":         VisibilityPublic,
+	"is_active":          VisibilityStaff,
+	"is_verified":        VisibilityStaff,
+	"email_verified_at":  VisibilityStaff,
+	"phone_verified_at":  VisibilityStaff,
+	"two_factor_enabled": VisibilityStaff,
+	"registration_date":  VisibilityStaff,
+	"account_type":       VisibilityStaff,
+	"roles":              VisibilityStaff,
+	"staff":              VisibilityStaff,
+}
+
+// PrivacySettings User.PrivacySettings alanında saklanan gizlilik ayarları; Fields alan grubu -> görünürlük eşlemesidir
+type PrivacySettings struct {
+	Fields map[string]string `json:"fields"`
+}
+
+// Visibility alan grubunun geçerli görünürlüğünü döndürür
+func (p *PrivacySettings) Visibility(group string) string {
+	if visibility, ok := p.Fields[group]; ok && IsValidVisibility(visibility) {
+		return visibility
+	}
+	return PrivacyFieldDefaults[group]
+}
+
+// FieldVisibility JSON alanının bu ayarlara göre görünürlüğünü döndürür; tanımsız alanlar yalnızca kullanıcıya açıktır
+func (p *PrivacySettings) FieldVisibility(field string) string {
+	if visibility, ok := FixedFieldVisibility[field]; ok {
+		return visibility
+	}
+	for group, fields := range PrivacyFieldGroups {
+		if contains(fields, field) {
+			return p.Visibility(group)
+		}
+	}
+	return VisibilitySelf
+}
@@ -0,0 +1,72 @@
+package model
+
+// Kullanıcı talebi durumları
+const (
+	RequestStatusSubmitted  = "submitted"
+	RequestStatusInProgress = "in_progress"
+	RequestStatusResolved   = "resolved"
+	RequestStatusRejected   = "rejected"
+	RequestStatusClosed     = "closed"
+)
+
+// Kullanıcı talebi öncelikleri
+const (
+	RequestPriorityLow    = "low"
+	RequestPriorityNormal = "normal"
+	RequestPriorityHigh   = "high"
+	RequestPriorityUrgent = "urgent"
+)
+
+// RequestStatuses tüm talep durumları, kuyruk sırasına göre
+var RequestStatuses = []string{
+	RequestStatusSubmitted,
+	RequestStatusInProgress,
+	RequestStatusResolved,
+	RequestStatusRejected,
+	RequestStatusClosed,
+}
+
+// RequestPriorities geçerli talep öncelikleri
+var RequestPriorities = []string{
+	RequestPriorityLow,
+	RequestPriorityNormal,
+	RequestPriorityHigh,
+	RequestPriorityUrgent,
+}
+
+// requestTransitions her durumdan geçilebilecek durumları tanımlar
+var requestTransitions = map[string][]string{
+	RequestStatusSubmitted:  {RequestStatusInProgress},
+	RequestStatusInProgress: {RequestStatusResolved, RequestStatusRejected},
+	RequestStatusResolved:   {RequestStatusClosed},
+	RequestStatusRejected:   {RequestStatusClosed},
+}
+
+// CanTransitionRequest talebin from durumundan to durumuna geçip geçemeyeceğini döndürür
+func CanTransitionRequest(from, to string) bool {
+	for _, next := range requestTransitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// IsValidRequestStatus durumun tanımlı olup olmadığını döndürür
+func IsValidRequestStatus(status string) bool {
+	return contains(RequestStatuses, status)
+}
+
+// IsValidRequestPriority önceliğin tanımlı olup olmadığını döndürür
+func IsValidRequestPriority(priority string) bool {
+	return contains(RequestPriorities, priority)
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
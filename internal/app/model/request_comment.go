@@ -0,0 +1,44 @@
+package model
+
+import "time"
+
+// RequestComment kullanıcı talebine yazılan yorumu tanımlar.
+// IsInternal true ise yorum yalnızca personel tarafından görülebilir.
+type RequestComment struct {
+	ID           uint       `json:"id" gorm:"primaryKey"`
+	RequestID    uint       `json:"request_id" gorm:"not null;index"`
+	AuthorUserID uint       `json:"author_user_id" gorm:"not null"`
+	Body         string     `json:"body" gorm:"type:text;not null"`
+	IsInternal   bool       `json:"is_internal" gorm:"not null;default:false"`
+	EditedAt     *time.Time `json:"edited_at"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+
+	// İlişkiler
+	Request     *UserRequest               `json:"-" gorm:"foreignKey:RequestID"`
+	Author      *User                      `json:"author,omitempty" gorm:"foreignKey:AuthorUserID"`
+	Attachments []RequestCommentAttachment `json:"attachments,omitempty" gorm:"foreignKey:CommentID"`
+}
+
+// RequestCommentRevision yorumun düzenlenmeden önceki halini saklar
+type RequestCommentRevision struct {
+	ID             uint      `json:"id" gorm:"primaryKey"`
+	CommentID      uint      `json:"comment_id" gorm:"not null;index"`
+	Body           string    `json:"body" gorm:"type:text;not null"`
+	EditedByUserID uint      `json:"edited_by_user_id"`
+	CreatedAt      time.Time `json:"created_at"`
+
+	// İlişkiler
+	Comment *RequestComment `json:"-" gorm:"foreignKey:CommentID"`
+}
+
+// RequestCommentAttachment yoruma eklenen dosyayı tanımlar
+type RequestCommentAttachment struct {
+	ID          uint      `json:"id" gorm:"primaryKey"`
+	CommentID   uint      `json:"comment_id" gorm:"not null;index"`
+	FileName    string    `json:"file_name" gorm:"not null"`
+	ContentType string    `json:"content_type"`
+	Size        int64     `json:"size"`
+	StorageKey  string    `json:"-" gorm:"not null"`
+	CreatedAt   time.Time `json:"created_at"`
+}
@@ -3,18 +3,21 @@ package model
 import (
 	"database/sql"
 	"time"
+
+	"gorm.io/gorm"
 )
 
 // Role kullanıcı rollerini tanımlar
 type Role struct {
-	ID              uint      `json:"id" gorm:"primaryKey;column:role_id"`
-	RoleName        string    `json:"role_name" gorm:"unique;not null"`
-	Description     string    `json:"description" gorm:"type:text"`
-	PermissionLevel int       `json:"permission_level" gorm:"not null;default:0"`
-	IsSystemRole    bool      `json:"is_system_role" gorm:"not null;default:false"`
-	CreatedAt       time.Time `json:"created_at"`
-	UpdatedAt       time.Time `json:"updated_at"`
-	CreatedBy       uint      `json:"created_by"`
+	ID              uint           `json:"id" gorm:"primaryKey;column:role_id"`
+	RoleName        string         `json:"role_name" gorm:"unique;not null"`
+	Description     string         `json:"description" gorm:"type:text"`
+	PermissionLevel int            `json:"permission_level" gorm:"not null;default:0"`
+	IsSystemRole    bool           `json:"is_system_role" gorm:"not null;default:false"`
+	CreatedAt       time.Time      `json:"created_at"`
+	UpdatedAt       time.Time      `json:"updated_at"`
+	DeletedAt       gorm.DeletedAt `json:"deleted_at" gorm:"index"`
+	CreatedBy       uint           `json:"created_by"`
 
 	// İlişkiler
 	Creator *User   `json:"creator,omitempty" gorm:"foreignKey:CreatedBy"`
@@ -22,6 +25,13 @@ type Role struct {
 	Users   []User  `json:"users,omitempty" gorm:"many2many:user_roles"`
 }
 
+// Personel çalışma durumları
+const (
+	EmployeeStatusActive     = "active"
+	EmployeeStatusOnLeave    = "on_leave"
+	EmployeeStatusTerminated = "terminated"
+)
+
 // Staff personel bilgilerini tanımlar
 type Staff struct {
 	ID               uint           `json:"id" gorm:"primaryKey;column:staff_id"`
@@ -32,6 +42,7 @@ type Staff struct {
 	HireDate         time.Time      `json:"hire_date"`
 	ManagerID        *uint          `json:"manager_id"`
 	EmployeeStatus   string         `json:"employee_status" gorm:"default:'active'"`
+	OnLeaveBySync    bool           `json:"-" gorm:"not null;default:false"` // Durum izin eşitlemesi tarafından on_leave yapıldıysa true
 	AccessLevel      int            `json:"access_level" gorm:"default:1"`
 	ShiftPattern     string         `json:"shift_pattern"`
 	Permissions      sql.NullString `json:"permissions" gorm:"type:json"`
@@ -41,6 +52,7 @@ type Staff struct {
 	Notes            string         `json:"notes" gorm:"type:text"`
 	CreatedAt        time.Time      `json:"created_at"`
 	UpdatedAt        time.Time      `json:"updated_at"`
+	DeletedAt        gorm.DeletedAt `json:"deleted_at" gorm:"index"`
 
 	// İlişkiler
 	User    *User  `json:"user,omitempty" gorm:"foreignKey:UserID"`
@@ -0,0 +1,39 @@
+package model
+
+import (
+	"strings"
+	"time"
+)
+
+// RoutingRule yeni talepleri bir departmana veya personel grubuna (role) yönlendiren kuralı tanımlar.
+// Boş bırakılan eşleşme alanları her değeri kabul eder; kurallar SortOrder sırasıyla denenir
+// ve ilk eşleşen kural uygulanır.
+type RoutingRule struct {
+	ID              uint      `json:"id" gorm:"primaryKey"`
+	Name            string    `json:"name" gorm:"not null"`
+	SortOrder       int       `json:"sort_order" gorm:"not null;default:0;index"`
+	RequestType     string    `json:"request_type"`
+	RequestPriority string    `json:"request_priority"`
+	Country         string    `json:"country"`
+	AccountType     string    `json:"account_type"`
+	Department      string    `json:"department"`
+	RoleID          *uint     `json:"role_id"`
+	IsActive        bool      `json:"is_active" gorm:"not null;default:true"`
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+
+	// İlişkiler
+	Role *Role `json:"role,omitempty" gorm:"foreignKey:RoleID"`
+}
+
+// Matches kuralın verilen talep ve talep sahibi için geçerli olup olmadığını döndürür
+func (r *RoutingRule) Matches(request *UserRequest, user *User) bool {
+	return matchesRule(r.RequestType, request.RequestType) &&
+		matchesRule(r.RequestPriority, request.Priority) &&
+		matchesRule(r.Country, user.Country) &&
+		matchesRule(r.AccountType, user.AccountType)
+}
+
+func matchesRule(expected, actual string) bool {
+	return expected == "" || strings.EqualFold(expected, actual)
+}
@@ -0,0 +1,121 @@
+package model
+
+import "time"
+
+// SLA ihlal durumu hesaplamasında süresinin bu oranı dolan hedefler risk altında sayılır
+const SLAAtRiskRatio = 0.75
+
+// SLA durumları
+const (
+	SLAStatusNone     = "none"
+	SLAStatusOnTrack  = "on_track"
+	SLAStatusAtRisk   = "at_risk"
+	SLAStatusBreached = "breached"
+	SLAStatusMet      = "met"
+)
+
+// SLA ihlalinde uygulanacak yükseltme eylemleri
+const (
+	SLAEscalationNotify   = "notify"
+	SLAEscalationReassign = "reassign"
+)
+
+// BusinessCalendar SLA sürelerinin hesaplandığı mesai takvimini tanımlar.
+// Workdays virgülle ayrılmış gün numaralarıdır (0 = Pazar).
+type BusinessCalendar struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	Name      string    `json:"name" gorm:"unique;not null"`
+	Timezone  string    `json:"timezone" gorm:"not null;default:'UTC'"`
+	DayStart  string    `json:"day_start" gorm:"not null;default:'09:00'"`
+	DayEnd    string    `json:"day_end" gorm:"not null;default:'18:00'"`
+	Workdays  string    `json:"workdays" gorm:"not null;default:'1,2,3,4,5'"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	// İlişkiler
+	Holidays []BusinessHoliday `json:"holidays,omitempty" gorm:"foreignKey:CalendarID"`
+}
+
+// BusinessHoliday mesai takvimindeki tatil gününü tanımlar
+type BusinessHoliday struct {
+	ID         uint      `json:"id" gorm:"primaryKey"`
+	CalendarID uint      `json:"calendar_id" gorm:"not null;uniqueIndex:idx_calendar_holiday"`
+	Date       time.Time `json:"date" gorm:"not null;uniqueIndex:idx_calendar_holiday"`
+	Name       string    `json:"name"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// SLAPolicy talep türü ve önceliğine göre ilk yanıt ve çözüm hedeflerini tanımlar.
+// RequestType boşsa politika o öncelikteki tüm talep türleri için geçerlidir.
+// Hedef süreleri 0 ise ilgili hedef uygulanmaz; CalendarID boşsa süreler 7/24 işler.
+type SLAPolicy struct {
+	ID                   uint      `json:"id" gorm:"primaryKey"`
+	Name                 string    `json:"name" gorm:"not null"`
+	RequestType          string    `json:"request_type" gorm:"not null;default:'';uniqueIndex:idx_sla_policy"`
+	Priority             string    `json:"priority" gorm:"not null;uniqueIndex:idx_sla_policy"`
+	FirstResponseMinutes int       `json:"first_response_minutes" gorm:"not null;default:0"`
+	ResolutionMinutes    int       `json:"resolution_minutes" gorm:"not null;default:0"`
+	CalendarID           *uint     `json:"calendar_id"`
+	EscalationAction     string    `json:"escalation_action" gorm:"not null;default:'notify'"`
+	EscalateToStaffID    *uint     `json:"escalate_to_staff_id"`
+	IsActive             bool      `json:"is_active" gorm:"not null;default:true"`
+	CreatedAt            time.Time `json:"created_at"`
+	UpdatedAt            time.Time `json:"updated_at"`
+
+	// İlişkiler
+	Calendar   *BusinessCalendar `json:"calendar,omitempty" gorm:"foreignKey:CalendarID"`
+	EscalateTo *Staff            `json:"escalate_to,omitempty" gorm:"foreignKey:EscalateToStaffID"`
+}
+
+// ComputeSLAStatus talebin SLA hedeflerine göre durumunu hesaplar.
+// Birden fazla hedef varsa en kötü durum döner; tüm hedefler karşılanmışsa "met" döner.
+func (r *UserRequest) ComputeSLAStatus(now time.Time) string {
+	statuses := []string{
+		slaTargetStatus(r.SubmittedAt, r.FirstResponseDueAt, r.FirstRespondedAt, now),
+		slaTargetStatus(r.SubmittedAt, r.ResolutionDueAt, r.CompletedAt, now),
+	}
+
+	result := SLAStatusNone
+	for _, status := range statuses {
+		if slaSeverity(status) > slaSeverity(result) {
+			result = status
+		}
+	}
+	return result
+}
+
+func slaTargetStatus(start time.Time, due, done *time.Time, now time.Time) string {
+	if due == nil {
+		return SLAStatusNone
+	}
+	if done != nil {
+		if done.After(*due) {
+			return SLAStatusBreached
+		}
+		return SLAStatusMet
+	}
+	if now.After(*due) {
+		return SLAStatusBreached
+	}
+
+	threshold := start.Add(time.Duration(float64(due.Sub(start)) * SLAAtRiskRatio))
+	if now.After(threshold) {
+		return SLAStatusAtRisk
+	}
+	return SLAStatusOnTrack
+}
+
+func slaSeverity(status string) int {
+	switch status {
+	case SLAStatusBreached:
+		return 4
+	case SLAStatusAtRisk:
+		return 3
+	case SLAStatusOnTrack:
+		return 2
+	case SLAStatusMet:
+		return 1
+	default:
+		return 0
+	}
+}
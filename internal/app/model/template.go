@@ -0,0 +1,17 @@
+package model
+
+import "time"
+
+// MessageTemplate yönetici tarafından düzenlenen mesaj şablonu.
+// Aynı ad ve dildeki gömülü varsayılan şablonun yerine kullanılır.
+type MessageTemplate struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	Name      string    `json:"name" gorm:"not null;uniqueIndex:idx_message_template_name_language"`
+	Language  string    `json:"language" gorm:"not null;uniqueIndex:idx_message_template_name_language"`
+	Subject   string    `json:"subject" gorm:"not null"`
+	BodyText  string    `json:"body_text" gorm:"type:text;not null"`
+	BodyHTML  string    `json:"body_html" gorm:"type:text"`
+	UpdatedBy uint      `json:"updated_by"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
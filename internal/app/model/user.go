@@ -3,6 +3,8 @@ package model
 import (
 	"database/sql"
 	"time"
+
+	"gorm.io/gorm"
 )
 
 // User temel kullanıcı modelini tanımlar
@@ -18,6 +20,7 @@ type User struct {
 	PhoneNumber    string `json:"phone_number"`
 	SecondaryEmail string `json:"secondary_email"`
 	ProfilePicture string `json:"profile_picture"`
+	AvatarPath     string `json:"-"` // Profil fotoğrafı küçük resimlerinin depolama öneki
 	Bio            string `json:"bio" gorm:"type:text"`
 
 	// Adres Bilgileri
@@ -31,14 +34,15 @@ type User struct {
 	Longitude     float64 `json:"longitude"`
 
 	// Zaman Bilgileri
-	CreatedAt          time.Time  `json:"created_at"`
-	UpdatedAt          time.Time  `json:"updated_at"`
-	LastLogin          *time.Time `json:"last_login"`
-	LastPasswordChange *time.Time `json:"last_password_change"`
-	LastActivity       *time.Time `json:"last_activity"`
-	RegistrationDate   time.Time  `json:"registration_date"`
-	BirthDate          *time.Time `json:"birth_date"`
-	Age                int        `json:"age" gorm:"-"` // Database'de saklanmayacak, hesaplanacak
+	CreatedAt          time.Time      `json:"created_at"`
+	UpdatedAt          time.Time      `json:"updated_at"`
+	DeletedAt          gorm.DeletedAt `json:"deleted_at" gorm:"index"` // Geri yüklenebilir silme
+	LastLogin          *time.Time     `json:"last_login"`
+	LastPasswordChange *time.Time     `json:"last_password_change"`
+	LastActivity       *time.Time     `json:"last_activity"`
+	RegistrationDate   time.Time      `json:"registration_date"`
+	BirthDate          *time.Time     `json:"birth_date"`
+	Age                int            `json:"age" gorm:"-"` // Database'de saklanmayacak, hesaplanacak
 
 	// Durum ve Doğrulama
 	IsActive           bool       `json:"is_active" gorm:"default:true"`
@@ -51,6 +55,11 @@ type User struct {
 	LoginAttempts      int        `json:"login_attempts" gorm:"default:0"`
 	AccountLockedUntil *time.Time `json:"account_locked_until"`
 
+	// Hesap silme; DeletionScheduledAt dolduğunda kişisel veriler anonimleştirilir
+	DeletionRequestedAt *time.Time `json:"deletion_requested_at"`
+	DeletionScheduledAt *time.Time `json:"deletion_scheduled_at" gorm:"index"`
+	AnonymizedAt        *time.Time `json:"anonymized_at"`
+
 	// Tercihler
 	PreferredLanguage       string         `json:"preferred_language" gorm:"default:'tr'"`
 	Timezone                string         `json:"timezone" gorm:"default:'Europe/Istanbul'"`
@@ -106,31 +115,57 @@ type UserRequest struct {
 	SubmittedAt         time.Time  `json:"submitted_at"`
 	ProcessingStartedAt *time.Time `json:"processing_started_at"`
 	CompletedAt         *time.Time `json:"completed_at"`
-	HandledByStaffID    uint       `json:"handled_by_staff_id"`
+	HandledByStaffID    *uint      `json:"handled_by_staff_id"`
+	RoutingRuleID       *uint      `json:"routing_rule_id"`
 	DecisionNotes       string     `json:"decision_notes" gorm:"type:text"`
 	FollowUpRequired    bool       `json:"follow_up_required"`
 	FollowUpDate        *time.Time `json:"follow_up_date"`
-	CreatedAt           time.Time  `json:"created_at"`
-	UpdatedAt           time.Time  `json:"updated_at"`
+	FollowUpReopen      bool       `json:"follow_up_reopen"`
+	FollowUpNotifiedAt  *time.Time `json:"follow_up_notified_at"`
+
+	// SLA hedefleri
+	SLAPolicyID              *uint      `json:"sla_policy_id"`
+	FirstResponseDueAt       *time.Time `json:"first_response_due_at"`
+	ResolutionDueAt          *time.Time `json:"resolution_due_at"`
+	FirstRespondedAt         *time.Time `json:"first_responded_at"`
+	FirstResponseEscalatedAt *time.Time `json:"first_response_escalated_at"`
+	ResolutionEscalatedAt    *time.Time `json:"resolution_escalated_at"`
+	SLAStatus                string     `json:"sla_status" gorm:"-"` // Database'de saklanmayacak, hesaplanacak
+
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `json:"deleted_at" gorm:"index"`
 
 	// İlişkiler
-	User *User `json:"user,omitempty" gorm:"foreignKey:UserID"`
+	User      *User      `json:"user,omitempty" gorm:"foreignKey:UserID"`
+	HandledBy *Staff     `json:"handled_by,omitempty" gorm:"foreignKey:HandledByStaffID"`
+	SLAPolicy *SLAPolicy `json:"sla_policy,omitempty" gorm:"foreignKey:SLAPolicyID"`
 }
 
 // UserDocument kullanıcı belge tablosu
 type UserDocument struct {
-	ID                 uint       `json:"id" gorm:"primaryKey"`
-	UserID             uint       `json:"user_id"`
-	DocumentType       string     `json:"document_type"`
-	DocumentPath       string     `json:"document_path"`
-	UploadDate         time.Time  `json:"upload_date"`
-	VerificationStatus string     `json:"verification_status"`
-	VerifiedBy         uint       `json:"verified_by"`
-	VerifiedAt         *time.Time `json:"verified_at"`
-	ExpiryDate         *time.Time `json:"expiry_date"`
-	Notes              string     `json:"notes" gorm:"type:text"`
-	CreatedAt          time.Time  `json:"created_at"`
-	UpdatedAt          time.Time  `json:"updated_at"`
+	ID                 uint           `json:"id" gorm:"primaryKey"`
+	UserID             uint           `json:"user_id"`
+	DocumentType       string         `json:"document_type"`
+	DocumentPath       string         `json:"-"` // Depolama anahtarı; indirme imzalı bağlantı ile yapılır
+	FileName           string         `json:"file_name"`
+	ContentType        string         `json:"content_type"`
+	Size               int64          `json:"size"`
+	Checksum           string         `json:"checksum"` // SHA-256, onaltılık
+	ScanStatus         string         `json:"scan_status" gorm:"index;default:'pending'"`
+	ScanSignature      string         `json:"scan_signature,omitempty"`
+	ScannedAt          *time.Time     `json:"scanned_at"`
+	UploadDate         time.Time      `json:"upload_date"`
+	VerificationStatus string         `json:"verification_status" gorm:"index"`
+	VerifiedBy         uint           `json:"verified_by"` // İnceleyen personelin ID'si
+	VerifiedAt         *time.Time     `json:"verified_at"`
+	RejectionReason    string         `json:"rejection_reason" gorm:"type:text"`
+	ExpiryDate         *time.Time     `json:"expiry_date"`
+	ExpiryNotifiedAt   *time.Time     `json:"expiry_notified_at"`
+	Notes              string         `json:"notes" gorm:"type:text"`
+	CreatedAt          time.Time      `json:"created_at"`
+	UpdatedAt          time.Time      `json:"updated_at"`
+	DeletedAt          gorm.DeletedAt `json:"deleted_at" gorm:"index"` // Dosya kalıcı silinene kadar depolamada tutulur
 
 	// İlişkiler
 	User *User `json:"user,omitempty" gorm:"foreignKey:UserID"`
@@ -158,11 +193,12 @@ type UserDevice struct {
 	User *User `json:"user,omitempty" gorm:"foreignKey:UserID"`
 }
 
-// UserPreference kullanıcı tercih tablosu
+// UserPreference kullanıcı tercih tablosu. PreferenceValue, PreferenceDefinitions'taki türe göre
+// JSON olarak kodlanmış değerdir; her kullanıcı için bir anahtar yalnızca bir kez bulunur.
 type UserPreference struct {
 	ID              uint      `json:"id" gorm:"primaryKey"`
-	UserID          uint      `json:"user_id"`
-	PreferenceKey   string    `json:"preference_key"`
+	UserID          uint      `json:"user_id" gorm:"not null;uniqueIndex:idx_user_preference_key"`
+	PreferenceKey   string    `json:"preference_key" gorm:"not null;uniqueIndex:idx_user_preference_key"`
 	PreferenceValue string    `json:"preference_value"`
 	UpdatedAt       time.Time `json:"updated_at"`
 	CreatedAt       time.Time `json:"created_at"`
@@ -174,19 +210,22 @@ type UserPreference struct {
 // UserCommunication kullanıcı iletişim tablosu
 type UserCommunication struct {
 	ID                uint       `json:"id" gorm:"primaryKey"`
-	UserID            uint       `json:"user_id"`
+	UserID            uint       `json:"user_id" gorm:"index"`
 	CommunicationType string     `json:"communication_type"`
 	SentAt            time.Time  `json:"sent_at"`
 	DeliveryStatus    string     `json:"delivery_status"`
 	ReadAt            *time.Time `json:"read_at"`
 	Subject           string     `json:"subject"`
 	Content           string     `json:"content" gorm:"type:text"`
+	ContentHTML       string     `json:"content_html,omitempty" gorm:"type:text"`
 	AttachmentPaths   string     `json:"attachment_paths"`
 	SenderID          uint       `json:"sender_id"`
 	Importance        string     `json:"importance"`
+	AnnouncementID    *uint      `json:"announcement_id,omitempty" gorm:"index"`
 	CreatedAt         time.Time  `json:"created_at"`
 	UpdatedAt         time.Time  `json:"updated_at"`
 
 	// İlişkiler
-	User *User `json:"user,omitempty" gorm:"foreignKey:UserID"`
+	User       *User                  `json:"user,omitempty" gorm:"foreignKey:UserID"`
+	Deliveries []NotificationDelivery `json:"deliveries,omitempty" gorm:"foreignKey:CommunicationID"`
 }
@@ -0,0 +1,267 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/UmutTKMN/go-backend/internal/app/model"
+	"github.com/UmutTKMN/go-backend/internal/pkg/database"
+	"github.com/UmutTKMN/go-backend/internal/pkg/storage"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// DefaultAccountDeletionGracePeriod silme isteği ile anonimleştirme arasındaki varsayılan süre
+const DefaultAccountDeletionGracePeriod = 30 * 24 * time.Hour
+
+// anonymizedDisplayName anonimleştirilen hesapların görünen adı
+const anonymizedDisplayName = "Silinmiş Kullanıcı"
+
+// ErrAccountDeletionPending hesap için planlanmış bir silme işlemi zaten var
+var ErrAccountDeletionPending = errors.New("hesabınız için silme işlemi zaten planlandı")
+
+// AccountDeletionService hesap silme isteklerini ve bekleme süresi dolan hesapların anonimleştirilmesini yönetir.
+// Kullanıcı satırı silinmez; talepler, yorumlar ve personel kaydı gibi geçmiş kayıtların bağlantısı korunur.
+type AccountDeletionService struct {
+	db            *gorm.DB
+	storage       storage.Storage
+	gracePeriod   time.Duration
+	notifications *NotificationService
+}
+
+// NewAccountDeletionService yeni bir AccountDeletionService örneği oluşturur.
+// gracePeriod sıfır veya negatifse DefaultAccountDeletionGracePeriod kullanılır.
+func NewAccountDeletionService(store storage.Storage, gracePeriod time.Duration) *AccountDeletionService {
+	if gracePeriod <= 0 {
+		gracePeriod = DefaultAccountDeletionGracePeriod
+	}
+	return &AccountDeletionService{
+		db:            database.DB,
+		storage:       store,
+		gracePeriod:   gracePeriod,
+		notifications: NewNotificationService(),
+	}
+}
+
+// RequestDeletion şifreyi doğrulayıp hesabın bekleme süresi sonunda silinmesini planlar ve açık oturumları
+// kapatır. Bekleme süresi içinde yeniden giriş yapılırsa silme iptal edilir.
+func (s *AccountDeletionService) RequestDeletion(userID uint, password string) (*model.User, error) {
+	var user model.User
+	err := runInTransaction(s.db, func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&user, userID).Error; err != nil {
+			return errors.New("kullanıcı bulunamadı")
+		}
+		if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(password)); err != nil {
+			return errors.New("geçersiz şifre")
+		}
+		if user.DeletionScheduledAt != nil {
+			return ErrAccountDeletionPending
+		}
+
+		now := time.Now()
+		scheduledAt := now.Add(s.gracePeriod)
+		user.DeletionRequestedAt = &now
+		user.DeletionScheduledAt = &scheduledAt
+		return tx.Model(&user).Updates(map[string]interface{}{
+			"deletion_requested_at": now,
+			"deletion_scheduled_at": scheduledAt,
+		}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	publishForcedLogout(user.ID, LogoutReasonDeletionRequested)
+
+	_, err = s.notifications.Notify(user.ID, Notification{
+		Category:  model.NotificationCategorySecurity,
+		Template:  "account_deletion_scheduled",
+		Mandatory: true,
+		Data: map[string]interface{}{
+			"ScheduledAt": user.DeletionScheduledAt.Format("2006-01-02 15:04"),
+		},
+		Importance: model.ImportanceHigh,
+	})
+	if err != nil {
+		log.Printf("Hesap silme bildirimi gönderilemedi (kullanıcı %d): %v", user.ID, err)
+	}
+	return &user, nil
+}
+
+// cancelAccountDeletion kullanıcının planlanmış silme işlemini iptal eder; iptal edildiyse true döner.
+// Değişiklik çağıranın kullanıcıyı kaydetmesiyle kalıcı olur.
+func cancelAccountDeletion(user *model.User) bool {
+	if user.DeletionScheduledAt == nil || user.AnonymizedAt != nil {
+		return false
+	}
+	user.DeletionRequestedAt = nil
+	user.DeletionScheduledAt = nil
+	return true
+}
+
+// ProcessDeletions bekleme süresi dolan hesapları anonimleştirir
+func (s *AccountDeletionService) ProcessDeletions(ctx context.Context) error {
+	var ids []uint
+	err := s.db.WithContext(ctx).Unscoped().Model(&model.User{}).
+		Where("deletion_scheduled_at <= ? AND anonymized_at IS NULL", time.Now()).
+		Order("deletion_scheduled_at").
+		Pluck("id", &ids).Error
+	if err != nil {
+		return err
+	}
+
+	for _, id := range ids {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := s.Anonymize(ctx, id, DeletionDue); err != nil {
+			log.Printf("Hesap anonimleştirilemedi (kullanıcı %d): %v", id, err)
+		}
+	}
+	return nil
+}
+
+// AnonymizeCondition anonimleştirmenin hâlâ gerekli olup olmadığını kilitli kullanıcı satırı üzerinde doğrular.
+// Aday kullanıcılar kilit alınmadan seçildiğinden arada iptal edilen silme veya geri yüklenen hesap atlanır.
+type AnonymizeCondition func(user *model.User, now time.Time) bool
+
+// DeletionDue hesap silme bekleme süresinin dolup dolmadığını döndürür
+func DeletionDue(user *model.User, now time.Time) bool {
+	return user.DeletionScheduledAt != nil && !user.DeletionScheduledAt.After(now)
+}
+
+// DeletedBefore hesabın cutoff anında veya öncesinde silinmiş olup olmadığını döndüren koşulu oluşturur
+func DeletedBefore(cutoff time.Time) AnonymizeCondition {
+	return func(user *model.User, _ time.Time) bool {
+		return user.DeletedAt.Valid && !user.DeletedAt.Time.After(cutoff)
+	}
+}
+
+// Anonymize kullanıcının kişisel verilerini temizler; cihazlarını, tercihlerini, bildirimlerini, belgelerini ve
+// dışa aktarma arşivlerini kalıcı olarak siler, rollerini kaldırır. Geri yüklenebilir şekilde silinmiş hesaplar da
+// anonimleştirilir ve silinmiş olarak kalır. Depolamadaki dosyalar işlem tamamlandıktan sonra silinir.
+// Kilitli satır eligible koşulunu artık sağlamıyorsa kullanıcı değiştirilmeden atlanır.
+func (s *AccountDeletionService) Anonymize(ctx context.Context, userID uint, eligible AnonymizeCondition) error {
+	var files []string
+	var skipped bool
+	err := runInTransaction(s.db.WithContext(ctx).Unscoped(), func(tx *gorm.DB) error {
+		var user model.User
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&user, userID).Error; err != nil {
+			return errors.New("kullanıcı bulunamadı")
+		}
+		if user.AnonymizedAt != nil {
+			return errors.New("kullanıcı zaten silinmiş")
+		}
+		if !eligible(&user, time.Now()) {
+			skipped = true
+			return nil
+		}
+
+		var documentKeys []string
+		if err := tx.Model(&model.UserDocument{}).Where("user_id = ?", userID).Pluck("document_path", &documentKeys).Error; err != nil {
+			return err
+		}
+		var exportKeys []string
+		err := tx.Model(&model.DataExport{}).Where("user_id = ? AND storage_path <> ''", userID).Pluck("storage_path", &exportKeys).Error
+		if err != nil {
+			return err
+		}
+		files = append(documentKeys, exportKeys...)
+		if user.AvatarPath != "" {
+			for name := range AvatarSizes {
+				files = append(files, avatarKey(user.AvatarPath, name))
+			}
+		}
+
+		for _, record := range []interface{}{
+			&model.UserDocument{},
+			&model.DataExport{},
+			&model.UserDevice{},
+			&model.UserPreference{},
+			&model.NotificationDelivery{},
+			&model.UserCommunication{},
+		} {
+			if err := tx.Where("user_id = ?", userID).Delete(record).Error; err != nil {
+				return err
+			}
+		}
+		if err := tx.Exec("DELETE FROM user_roles WHERE user_id = ?", userID).Error; err != nil {
+			return err
+		}
+
+		// Personel kaydı geçmiş için korunur, üçüncü kişilere ait iletişim bilgisi temizlenir
+		if err := tx.Model(&model.Staff{}).Where("user_id = ?", userID).Update("emergency_contact", "").Error; err != nil {
+			return err
+		}
+
+		now := time.Now()
+		fields := anonymizedUserFields(userID, now)
+		fields["deleted_at"] = gorm.Expr("COALESCE(deleted_at, ?)", now)
+		return tx.Model(&user).Updates(fields).Error
+	})
+	if err != nil || skipped {
+		return err
+	}
+
+	for _, key := range files {
+		if err := s.storage.Delete(ctx, key); err != nil && !errors.Is(err, storage.ErrNotFound) {
+			log.Printf("Silinen hesabın dosyası kaldırılamadı (%s): %v", key, err)
+		}
+	}
+	publishForcedLogout(userID, LogoutReasonAccountDeleted)
+	return nil
+}
+
+// anonymizedUserFields kişisel verileri temizleyen alan değerlerini döndürür. Benzersiz alanlar kullanıcı
+// ID'sinden türetilir; şifre hiçbir bcrypt karşılaştırmasıyla eşleşmeyecek şekilde boşaltılır.
+func anonymizedUserFields(userID uint, now time.Time) map[string]interface{} {
+	return map[string]interface{}{
+		"username":                 fmt.Sprintf("deleted-user-%d", userID),
+		"email":                    fmt.Sprintf("deleted-user-%d@deleted.invalid", userID),
+		"password":                 "",
+		"first_name":               "",
+		"last_name":                "",
+		"display_name":             anonymizedDisplayName,
+		"phone_number":             "",
+		"secondary_email":          "",
+		"profile_picture":          "",
+		"avatar_path":              "",
+		"bio":                      "",
+		"address_line1":            "",
+		"address_line2":            "",
+		"city":                     "",
+		"state_province":           "",
+		"country":                  "",
+		"postal_code":              "",
+		"latitude":                 0,
+		"longitude":                0,
+		"birth_date":               nil,
+		"last_login":               nil,
+		"last_activity":            nil,
+		"is_active":                false,
+		"is_verified":              false,
+		"verification_token":       "",
+		"two_factor_enabled":       false,
+		"notification_preferences": nil,
+		"privacy_settings":         nil,
+		"accessibility_settings":   nil,
+		"subscription_id":          "",
+		"api_key":                  "",
+		"ip_registration":          "",
+		"referral_code":            "",
+		"referred_by":              "",
+		"facebook_id":              "",
+		"google_id":                "",
+		"twitter_id":               "",
+		"linkedin_id":              "",
+		"github_id":                "",
+		"apple_id":                 "",
+		"social_logins":            nil,
+		"deletion_scheduled_at":    nil,
+		"anonymized_at":            now,
+	}
+}
@@ -0,0 +1,377 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/UmutTKMN/go-backend/internal/app/model"
+	"github.com/UmutTKMN/go-backend/internal/pkg/database"
+	"github.com/UmutTKMN/go-backend/internal/pkg/listquery"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// AnnouncementBatchSize bir dağıtım adımında bildirim oluşturulan en fazla kullanıcı sayısı
+const AnnouncementBatchSize = 500
+
+// AnnouncementListSpec duyuru listesinde izin verilen sıralama alanları ve sayfa sınırları
+var AnnouncementListSpec = listquery.Spec{
+	SortFields: map[string]listquery.SortField{
+		"id":           {Column: "id", Kind: listquery.KindInt},
+		"scheduled_at": {Column: "scheduled_at", Kind: listquery.KindTime},
+	},
+	DefaultSort:  "scheduled_at",
+	DefaultDesc:  true,
+	IDColumn:     "id",
+	DefaultLimit: 20,
+	MaxLimit:     100,
+}
+
+// AnnouncementStats duyurunun teslim ve okunma istatistikleri
+type AnnouncementStats struct {
+	Recipients int64   `json:"recipients"`
+	Read       int64   `json:"read"`
+	Unread     int64   `json:"unread"`
+	ReadRate   float64 `json:"read_rate"`
+
+	// Deliveries kanal -> teslim durumu -> teslimat sayısı
+	Deliveries map[string]map[string]int64 `json:"deliveries"`
+}
+
+// AnnouncementService toplu duyuruların planlanması ve dağıtımı için servis
+type AnnouncementService struct {
+	db            *gorm.DB
+	notifications *NotificationService
+}
+
+// NewAnnouncementService yeni bir AnnouncementService örneği oluşturur
+func NewAnnouncementService() *AnnouncementService {
+	return &AnnouncementService{
+		db:            database.DB,
+		notifications: NewNotificationService(),
+	}
+}
+
+// CreateAnnouncement duyuruyu doğrulayıp gönderime planlar. ScheduledAt boşsa duyuru hemen gönderilir;
+// dağıtım isteği bekletmeden DispatchAnnouncements işi tarafından yapılır.
+func (s *AnnouncementService) CreateAnnouncement(announcement *model.Announcement) error {
+	announcement.Subject = strings.TrimSpace(announcement.Subject)
+	if announcement.Subject == "" || strings.TrimSpace(announcement.Content) == "" {
+		return errors.New("duyuru konusu ve içeriği zorunludur")
+	}
+
+	if announcement.Category == "" {
+		announcement.Category = model.NotificationCategoryAnnouncements
+	}
+	if !model.IsValidAnnouncementCategory(announcement.Category) {
+		return errors.New("geçersiz duyuru kategorisi")
+	}
+	if announcement.Importance == "" {
+		announcement.Importance = model.ImportanceNormal
+	}
+	if !model.IsValidImportance(announcement.Importance) {
+		return errors.New("geçersiz önem derecesi")
+	}
+
+	if err := s.validateAudience(&announcement.Audience); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	if announcement.ScheduledAt.IsZero() || announcement.ScheduledAt.Before(now) {
+		announcement.ScheduledAt = now
+	}
+	announcement.Status = model.AnnouncementStatusScheduled
+	announcement.RecipientCount = 0
+	announcement.LastUserID = 0
+
+	return s.db.Create(announcement).Error
+}
+
+// validateAudience hedef kitleyi doğrular ve tekrarlanan değerleri temizler
+func (s *AnnouncementService) validateAudience(audience *model.AnnouncementAudience) error {
+	if audience.All {
+		*audience = model.AnnouncementAudience{All: true}
+		return nil
+	}
+
+	audience.Departments = uniqueTrimmed(audience.Departments)
+	audience.AccountTypes = uniqueTrimmed(audience.AccountTypes)
+
+	seen := map[uint]bool{}
+	roleIDs := audience.RoleIDs[:0]
+	for _, id := range audience.RoleIDs {
+		if !seen[id] {
+			seen[id] = true
+			roleIDs = append(roleIDs, id)
+		}
+	}
+	audience.RoleIDs = roleIDs
+
+	if audience.IsEmpty() {
+		return errors.New("duyuru için hedef kitle seçilmelidir")
+	}
+
+	if len(audience.RoleIDs) > 0 {
+		var count int64
+		if err := s.db.Model(&model.Role{}).Where("role_id IN ?", audience.RoleIDs).Count(&count).Error; err != nil {
+			return err
+		}
+		if count != int64(len(audience.RoleIDs)) {
+			return errors.New("hedef kitlede bulunamayan rol var")
+		}
+	}
+	return nil
+}
+
+func uniqueTrimmed(values []string) []string {
+	seen := map[string]bool{}
+	var result []string
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		if value != "" && !seen[value] {
+			seen[value] = true
+			result = append(result, value)
+		}
+	}
+	return result
+}
+
+// GetAnnouncements duyuruları durum filtresiyle sayfalı olarak getirir
+func (s *AnnouncementService) GetAnnouncements(status string, query *listquery.Query) (*listquery.Page[model.Announcement], error) {
+	db := s.db.Model(&model.Announcement{})
+	if status != "" {
+		db = db.Where("status = ?", status)
+	}
+	if query.Search != "" {
+		pattern := "%" + escapeLike(query.Search) + "%"
+		db = db.Where("(subject ILIKE ? OR content ILIKE ?)", pattern, pattern)
+	}
+
+	return listquery.Paginate(db, query, AnnouncementListSpec, func(announcement *model.Announcement) (interface{}, uint) {
+		if query.SortKey == "scheduled_at" {
+			return announcement.ScheduledAt, announcement.ID
+		}
+		return announcement.ID, announcement.ID
+	})
+}
+
+// GetAnnouncement ID'ye göre duyuruyu getirir
+func (s *AnnouncementService) GetAnnouncement(id uint) (*model.Announcement, error) {
+	var announcement model.Announcement
+	if err := s.db.Preload("Creator").First(&announcement, id).Error; err != nil {
+		return nil, errors.New("duyuru bulunamadı")
+	}
+	return &announcement, nil
+}
+
+// CancelAnnouncement planlanmış veya gönderimi süren duyuruyu iptal eder.
+// Gönderim sürüyorsa o ana kadar oluşturulan bildirimler kullanıcılarda kalır.
+func (s *AnnouncementService) CancelAnnouncement(id uint) (*model.Announcement, error) {
+	var announcement model.Announcement
+	err := runInTransaction(s.db, func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&announcement, id).Error; err != nil {
+			return errors.New("duyuru bulunamadı")
+		}
+		if announcement.Status != model.AnnouncementStatusScheduled && announcement.Status != model.AnnouncementStatusSending {
+			return errors.New("yalnızca gönderimi tamamlanmamış duyurular iptal edilebilir")
+		}
+
+		now := time.Now()
+		announcement.Status = model.AnnouncementStatusCancelled
+		announcement.CompletedAt = &now
+		return tx.Model(&announcement).Updates(map[string]interface{}{
+			"status":       announcement.Status,
+			"completed_at": now,
+		}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &announcement, nil
+}
+
+// GetAnnouncementStats duyurudan üretilen bildirimlerin okunma ve kanal teslim istatistiklerini getirir
+func (s *AnnouncementService) GetAnnouncementStats(id uint) (*AnnouncementStats, error) {
+	if err := s.db.Select("id").First(&model.Announcement{}, id).Error; err != nil {
+		return nil, errors.New("duyuru bulunamadı")
+	}
+
+	var counts struct {
+		Recipients int64
+		Read       int64
+	}
+	err := s.db.Model(&model.UserCommunication{}).
+		Select("COUNT(*) AS recipients, COUNT(read_at) AS read").
+		Where("announcement_id = ?", id).
+		Scan(&counts).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []struct {
+		Channel string
+		Status  string
+		Count   int64
+	}
+	err = s.db.Model(&model.NotificationDelivery{}).
+		Select("notification_deliveries.channel, notification_deliveries.status, COUNT(*) AS count").
+		Joins("JOIN user_communications ON user_communications.id = notification_deliveries.communication_id").
+		Where("user_communications.announcement_id = ?", id).
+		Group("notification_deliveries.channel, notification_deliveries.status").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &AnnouncementStats{
+		Recipients: counts.Recipients,
+		Read:       counts.Read,
+		Unread:     counts.Recipients - counts.Read,
+		Deliveries: map[string]map[string]int64{},
+	}
+	if stats.Recipients > 0 {
+		stats.ReadRate = float64(stats.Read) / float64(stats.Recipients)
+	}
+	for _, row := range rows {
+		if stats.Deliveries[row.Channel] == nil {
+			stats.Deliveries[row.Channel] = map[string]int64{}
+		}
+		stats.Deliveries[row.Channel][row.Status] = row.Count
+	}
+	return stats, nil
+}
+
+// DispatchAnnouncements zamanı gelmiş duyuruları hedef kitleye parçalar halinde dağıtır.
+// Her parça kendi işleminde (transaction) yazılır ve kaldığı yer kaydedilir; kesintiden sonra dağıtım
+// tekrar eden bildirim oluşturmadan devam eder. Kilitli duyurular atlandığından birden fazla örnek çalışabilir.
+func (s *AnnouncementService) DispatchAnnouncements(ctx context.Context) error {
+	var ids []uint
+	err := s.db.WithContext(ctx).Model(&model.Announcement{}).
+		Where("status IN ? AND scheduled_at <= ?", []string{model.AnnouncementStatusScheduled, model.AnnouncementStatusSending}, time.Now()).
+		Order("scheduled_at").
+		Pluck("id", &ids).Error
+	if err != nil {
+		return err
+	}
+
+	for _, id := range ids {
+		for {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			done, err := s.dispatchBatch(ctx, id)
+			if err != nil {
+				return err
+			}
+			if done {
+				break
+			}
+		}
+	}
+	return nil
+}
+
+// dispatchBatch duyurunun bir sonraki alıcı parçasına bildirim oluşturur; dağıtım bittiyse veya duyuru
+// başka bir örnek tarafından işleniyorsa true döner
+func (s *AnnouncementService) dispatchBatch(ctx context.Context, id uint) (bool, error) {
+	done := false
+	var communications []model.UserCommunication
+	err := runInTransaction(s.db.WithContext(ctx), func(tx *gorm.DB) error {
+		var announcement model.Announcement
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).First(&announcement, id).Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				done = true
+				return nil
+			}
+			return err
+		}
+		if announcement.Status != model.AnnouncementStatusScheduled && announcement.Status != model.AnnouncementStatusSending {
+			done = true
+			return nil
+		}
+
+		now := time.Now()
+		updates := map[string]interface{}{}
+		if announcement.Status == model.AnnouncementStatusScheduled {
+			updates["status"] = model.AnnouncementStatusSending
+			updates["started_at"] = now
+		}
+
+		var users []model.User
+		err = s.audienceQuery(tx, announcement.Audience).
+			Where("users.id > ?", announcement.LastUserID).
+			Order("users.id").
+			Limit(AnnouncementBatchSize).
+			Find(&users).Error
+		if err != nil {
+			return err
+		}
+
+		communications, err = s.notifications.notifyUsers(tx, users, Notification{
+			Category:       announcement.Category,
+			Subject:        announcement.Subject,
+			Content:        announcement.Content,
+			Importance:     announcement.Importance,
+			SenderID:       announcement.CreatedBy,
+			AnnouncementID: &announcement.ID,
+		})
+		if err != nil {
+			return err
+		}
+
+		if len(users) > 0 {
+			updates["last_user_id"] = users[len(users)-1].ID
+			updates["recipient_count"] = announcement.RecipientCount + len(communications)
+		}
+		if len(users) < AnnouncementBatchSize {
+			updates["status"] = model.AnnouncementStatusSent
+			updates["completed_at"] = now
+			done = true
+		}
+		return tx.Model(&announcement).Updates(updates).Error
+	})
+	if err != nil {
+		return false, err
+	}
+
+	publishNotifications(communications)
+	return done, nil
+}
+
+// audienceQuery hedef kitledeki etkin kullanıcıları seçen sorguyu oluşturur
+func (s *AnnouncementService) audienceQuery(tx *gorm.DB, audience model.AnnouncementAudience) *gorm.DB {
+	db := tx.Model(&model.User{}).Where("users.is_active = ?", true)
+	if audience.All {
+		return db
+	}
+
+	conditions := tx.Session(&gorm.Session{NewDB: true})
+	var group *gorm.DB
+	or := func(query string, args ...interface{}) {
+		if group == nil {
+			group = conditions.Where(query, args...)
+			return
+		}
+		group = group.Or(query, args...)
+	}
+
+	if len(audience.RoleIDs) > 0 {
+		or("users.id IN (SELECT ur.user_id FROM user_roles ur JOIN roles r ON ur.role_id = r.role_id "+
+			"WHERE r.role_id IN ? AND r.deleted_at IS NULL)", audience.RoleIDs)
+	}
+	if len(audience.Departments) > 0 {
+		or("users.id IN (SELECT user_id FROM staffs WHERE department IN ? AND employee_status <> ? AND deleted_at IS NULL)",
+			audience.Departments, model.EmployeeStatusTerminated)
+	}
+	if len(audience.AccountTypes) > 0 {
+		or("users.account_type IN ?", audience.AccountTypes)
+	}
+	if group == nil {
+		return db.Where("1 = 0")
+	}
+	return db.Where(group)
+}
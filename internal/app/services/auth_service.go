@@ -8,30 +8,24 @@ import (
 	"github.com/UmutTKMN/go-backend/internal/pkg/database"
 	"github.com/golang-jwt/jwt/v5"
 	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type AuthService struct {
+	db        *gorm.DB
 	secretKey []byte
 }
 
 func NewAuthService(secretKey string) *AuthService {
 	return &AuthService{
+		db:        database.DB,
 		secretKey: []byte(secretKey),
 	}
 }
 
 // Register yeni kullanıcı kaydı yapar
 func (s *AuthService) Register(req *model.RegisterRequest) (*model.User, error) {
-	// Email veya kullanıcı adı zaten var mı kontrol et
-	var existingUser model.User
-	result := database.DB.Where("email = ? OR username = ?", req.Email, req.Username).First(&existingUser)
-	if result.RowsAffected > 0 {
-		if existingUser.Email == req.Email {
-			return nil, errors.New("bu email adresi zaten kullanılıyor")
-		}
-		return nil, errors.New("bu kullanıcı adı zaten kullanılıyor")
-	}
-
 	// Şifreyi hashle
 	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
 	if err != nil {
@@ -81,8 +75,17 @@ func (s *AuthService) Register(req *model.RegisterRequest) (*model.User, error)
 		}
 	}
 
-	// Veritabanına kaydet
-	if err := database.DB.Create(user).Error; err != nil {
+	// Benzersizlik kontrolü ve kayıt aynı işlem içinde yapılır
+	err = runInTransaction(s.db, func(tx *gorm.DB) error {
+		// Email veya kullanıcı adı silinmiş hesaplar dahil zaten var mı kontrol et
+		if err := ensureUserUnique(tx, 0, req.Email, req.Username); err != nil {
+			return err
+		}
+
+		// Veritabanına kaydet
+		return tx.Create(user).Error
+	})
+	if err != nil {
 		return nil, err
 	}
 
@@ -94,46 +97,62 @@ func (s *AuthService) Register(req *model.RegisterRequest) (*model.User, error)
 
 // Login kullanıcı girişi yapar ve token döndürür
 func (s *AuthService) Login(req *model.LoginRequest) (*model.AuthResponse, error) {
-	// Veritabanından kullanıcıyı bul
 	var user model.User
-	if err := database.DB.Where("email = ?", req.Email).First(&user).Error; err != nil {
-		return nil, errors.New("kullanıcı bulunamadı")
-	}
+	var loginErr error
+	var deletionCancelled bool
+
+	// Kullanıcı satırı kilitlenir, böylece eşzamanlı denemeler sayacı birbirinin üzerine yazmaz
+	err := runInTransaction(s.db, func(tx *gorm.DB) error {
+		// Veritabanından kullanıcıyı bul
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("email = ?", req.Email).First(&user).Error; err != nil {
+			return errors.New("kullanıcı bulunamadı")
+		}
 
-	// Kullanıcı aktif mi kontrol et
-	if !user.IsActive {
-		return nil, errors.New("hesabınız aktif değil")
-	}
+		// Kullanıcı aktif mi kontrol et
+		if !user.IsActive {
+			return errors.New("hesabınız aktif değil")
+		}
 
-	// Hesap kilitli mi kontrol et
-	if user.AccountLockedUntil != nil && user.AccountLockedUntil.After(time.Now()) {
-		return nil, errors.New("hesabınız geçici olarak kilitlendi, lütfen daha sonra tekrar deneyin")
-	}
+		// Hesap kilitli mi kontrol et
+		if user.AccountLockedUntil != nil && user.AccountLockedUntil.After(time.Now()) {
+			return errors.New("hesabınız geçici olarak kilitlendi, lütfen daha sonra tekrar deneyin")
+		}
+
+		// Şifre kontrolü
+		if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(req.Password)); err != nil {
+			// Başarısız giriş denemesini kaydet
+			user.LoginAttempts++
 
-	// Şifre kontrolü
-	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(req.Password)); err != nil {
-		// Başarısız giriş denemesini kaydet
-		user.LoginAttempts++
+			// 5 başarısız denemeden sonra hesabı geçici olarak kilitle
+			if user.LoginAttempts >= 5 {
+				lockUntil := time.Now().Add(time.Minute * 30) // 30 dakika kilitle
+				user.AccountLockedUntil = &lockUntil
+			}
 
-		// 5 başarısız denemeden sonra hesabı geçici olarak kilitle
-		if user.LoginAttempts >= 5 {
-			lockUntil := time.Now().Add(time.Minute * 30) // 30 dakika kilitle
-			user.AccountLockedUntil = &lockUntil
+			// Sayaç kaydedilsin diye işlem geri alınmaz, hata işlem dışında döndürülür
+			loginErr = errors.New("geçersiz şifre")
+			return tx.Save(&user).Error
 		}
 
-		database.DB.Save(&user)
-		return nil, errors.New("geçersiz şifre")
-	}
+		// Başarılı giriş işlemleri
+		now := time.Now()
+		user.LastLogin = &now
+		user.LastActivity = &now
+		user.LoginAttempts = 0        // Başarılı giriş, sayacı sıfırla
+		user.AccountLockedUntil = nil // Kilidi kaldır
 
-	// Başarılı giriş işlemleri
-	now := time.Now()
-	user.LastLogin = &now
-	user.LastActivity = &now
-	user.LoginAttempts = 0        // Başarılı giriş, sayacı sıfırla
-	user.AccountLockedUntil = nil // Kilidi kaldır
+		// Bekleme süresi içindeki giriş planlanmış hesap silme işlemini iptal eder
+		deletionCancelled = cancelAccountDeletion(&user)
 
-	// Kullanıcıyı güncelle
-	database.DB.Save(&user)
+		// Kullanıcıyı güncelle
+		return tx.Save(&user).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	if loginErr != nil {
+		return nil, loginErr
+	}
 
 	// JWT token oluştur
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
@@ -148,8 +167,9 @@ func (s *AuthService) Login(req *model.LoginRequest) (*model.AuthResponse, error
 	}
 
 	return &model.AuthResponse{
-		Token: tokenString,
-		User:  user,
+		Token:             tokenString,
+		User:              user,
+		DeletionCancelled: deletionCancelled,
 	}, nil
 }
 
@@ -0,0 +1,36 @@
+package services
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/UmutTKMN/go-backend/internal/app/model"
+)
+
+func TestRegisterRejectsSoftDeletedAccounts(t *testing.T) {
+	db := openTestDB(t)
+	deleted := createTestUser(t, db, "soft_deleted")
+	if err := db.Delete(deleted).Error; err != nil {
+		t.Fatalf("kullanıcı silinemedi: %v", err)
+	}
+
+	service := &AuthService{db: db, secretKey: []byte("test")}
+
+	_, err := service.Register(&model.RegisterRequest{
+		Username: "soft_deleted_new",
+		Email:    deleted.Email,
+		Password: "secret123",
+	})
+	if !errors.Is(err, ErrEmailTaken) {
+		t.Errorf("e-posta için beklenen hata %v, alınan %v", ErrEmailTaken, err)
+	}
+
+	_, err = service.Register(&model.RegisterRequest{
+		Username: deleted.Username,
+		Email:    "soft_deleted_new@example.com",
+		Password: "secret123",
+	})
+	if !errors.Is(err, ErrUsernameTaken) {
+		t.Errorf("kullanıcı adı için beklenen hata %v, alınan %v", ErrUsernameTaken, err)
+	}
+}
@@ -0,0 +1,223 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	_ "image/gif" // GIF çözücüsünü kaydeder
+	"image/jpeg"
+	_ "image/png" // PNG çözücüsünü kaydeder
+	"io"
+	"log"
+	"regexp"
+	"strings"
+
+	"github.com/UmutTKMN/go-backend/internal/app/model"
+	"github.com/UmutTKMN/go-backend/internal/pkg/database"
+	"github.com/UmutTKMN/go-backend/internal/pkg/imaging"
+	"github.com/UmutTKMN/go-backend/internal/pkg/storage"
+	_ "golang.org/x/image/webp" // WebP çözücüsünü kaydeder
+	"gorm.io/gorm"
+)
+
+// Profil fotoğrafı yükleme sınırları
+const (
+	MaxAvatarSize      = 5 << 20 // 5 MB
+	MinAvatarDimension = 128
+	MaxAvatarDimension = 4096
+	avatarJPEGQuality  = 85
+)
+
+// DefaultAvatarSize ProfilePicture alanına yazılan küçük resim boyutu
+const DefaultAvatarSize = "medium"
+
+// AvatarSizes üretilen küçük resimler ve kenar uzunlukları (piksel)
+var AvatarSizes = map[string]int{
+	"small":  64,
+	"medium": 256,
+	"large":  512,
+}
+
+// AllowedAvatarFormats yüklenebilecek görsel biçimleri
+var AllowedAvatarFormats = map[string]bool{
+	"jpeg": true,
+	"png":  true,
+	"gif":  true,
+	"webp": true,
+}
+
+var avatarTokenPattern = regexp.MustCompile(`^[0-9a-f]{32}$`)
+
+// AvatarService profil fotoğraflarının işlenmesi ve sunulması için servis
+type AvatarService struct {
+	db      *gorm.DB
+	storage storage.Storage
+	baseURL string
+}
+
+// NewAvatarService yeni bir AvatarService örneği oluşturur. baseURL profil fotoğrafı bağlantılarının başına eklenir.
+func NewAvatarService(store storage.Storage, baseURL string) *AvatarService {
+	return &AvatarService{
+		db:      database.DB,
+		storage: store,
+		baseURL: strings.TrimRight(baseURL, "/"),
+	}
+}
+
+// UploadAvatar görseli doğrular, meta verilerinden arındırılmış küçük resimlerini depolamaya yazar
+// ve kullanıcının ProfilePicture alanını sunulan bağlantıyla günceller. Önceki fotoğrafın dosyaları silinir.
+func (s *AvatarService) UploadAvatar(ctx context.Context, userID uint, upload FileUpload) (*model.User, error) {
+	if upload.Size <= 0 {
+		return nil, errors.New("dosya boş olamaz")
+	}
+	if upload.Size > MaxAvatarSize {
+		return nil, fmt.Errorf("dosya boyutu en fazla %d MB olabilir", MaxAvatarSize>>20)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(upload.Reader, MaxAvatarSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > MaxAvatarSize {
+		return nil, fmt.Errorf("dosya boyutu en fazla %d MB olabilir", MaxAvatarSize>>20)
+	}
+
+	// Boyutlar görüntü çözülmeden önce kontrol edilir; aşırı büyük görseller belleğe açılmaz
+	config, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil || !AllowedAvatarFormats[format] {
+		return nil, errors.New("desteklenmeyen görsel biçimi, izin verilenler: JPEG, PNG, GIF, WebP")
+	}
+	if config.Width < MinAvatarDimension || config.Height < MinAvatarDimension {
+		return nil, fmt.Errorf("görsel en az %dx%d piksel olmalıdır", MinAvatarDimension, MinAvatarDimension)
+	}
+	if config.Width > MaxAvatarDimension || config.Height > MaxAvatarDimension {
+		return nil, fmt.Errorf("görsel en fazla %dx%d piksel olabilir", MaxAvatarDimension, MaxAvatarDimension)
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, errors.New("görsel okunamadı: " + err.Error())
+	}
+
+	orientation := 1
+	if format == "jpeg" {
+		orientation = imaging.Orientation(data)
+	}
+
+	var user model.User
+	if err := s.db.First(&user, userID).Error; err != nil {
+		return nil, errors.New("kullanıcı bulunamadı")
+	}
+
+	prefix, err := newStorageKey(fmt.Sprintf("avatars/%d", userID), "")
+	if err != nil {
+		return nil, err
+	}
+
+	var written []string
+	for name, size := range AvatarSizes {
+		// Kare kırpma döndürmeden bağımsız olduğundan yönlendirme küçük resme uygulanır
+		thumbnail := imaging.Orient(imaging.Thumbnail(img, size), orientation)
+
+		var buf bytes.Buffer
+		if err := jpeg.Encode(&buf, thumbnail, &jpeg.Options{Quality: avatarJPEGQuality}); err != nil {
+			s.removeFiles(ctx, written)
+			return nil, err
+		}
+
+		key := avatarKey(prefix, name)
+		if err := s.storage.Put(ctx, key, &buf, int64(buf.Len()), "image/jpeg"); err != nil {
+			s.removeFiles(ctx, written)
+			return nil, err
+		}
+		written = append(written, key)
+	}
+
+	updates := map[string]interface{}{
+		"profile_picture": s.AvatarURL(userID, prefix, DefaultAvatarSize),
+		"avatar_path":     prefix,
+	}
+	if err := s.db.Model(&user).Updates(updates).Error; err != nil {
+		s.removeFiles(ctx, written)
+		return nil, err
+	}
+
+	if user.AvatarPath != "" {
+		s.removeAvatar(ctx, user.AvatarPath)
+	}
+
+	return NewUserService().GetUser(userID)
+}
+
+// RemoveAvatar kullanıcının profil fotoğrafını kaldırır ve dosyalarını siler
+func (s *AvatarService) RemoveAvatar(ctx context.Context, userID uint) error {
+	var user model.User
+	if err := s.db.First(&user, userID).Error; err != nil {
+		return errors.New("kullanıcı bulunamadı")
+	}
+	if user.AvatarPath == "" {
+		return errors.New("profil fotoğrafı bulunamadı")
+	}
+
+	updates := map[string]interface{}{
+		"profile_picture": "",
+		"avatar_path":     "",
+	}
+	if err := s.db.Model(&user).Updates(updates).Error; err != nil {
+		return err
+	}
+
+	s.removeAvatar(ctx, user.AvatarPath)
+	return nil
+}
+
+// AvatarURLs kullanıcının profil fotoğrafının tüm boyutlarının bağlantılarını döndürür
+func (s *AvatarService) AvatarURLs(user *model.User) map[string]string {
+	if user.AvatarPath == "" {
+		return nil
+	}
+
+	urls := make(map[string]string, len(AvatarSizes))
+	for name := range AvatarSizes {
+		urls[name] = s.AvatarURL(user.ID, user.AvatarPath, name)
+	}
+	return urls
+}
+
+// AvatarURL profil fotoğrafının belirtilen boyutu için sunulan bağlantıyı döndürür
+func (s *AvatarService) AvatarURL(userID uint, prefix, size string) string {
+	token := prefix[strings.LastIndex(prefix, "/")+1:]
+	return fmt.Sprintf("%s/api/v1/files/avatars/%d/%s/%s", s.baseURL, userID, token, size)
+}
+
+// OpenAvatar profil fotoğrafının belirtilen boyutunu okumak için açar
+func (s *AvatarService) OpenAvatar(ctx context.Context, userID uint, token, size string) (io.ReadCloser, error) {
+	if _, ok := AvatarSizes[size]; !ok || !avatarTokenPattern.MatchString(token) {
+		return nil, storage.ErrNotFound
+	}
+	return s.storage.Open(ctx, avatarKey(fmt.Sprintf("avatars/%d/%s", userID, token), size))
+}
+
+// removeAvatar profil fotoğrafının tüm boyutlarını depolamadan siler
+func (s *AvatarService) removeAvatar(ctx context.Context, prefix string) {
+	keys := make([]string, 0, len(AvatarSizes))
+	for name := range AvatarSizes {
+		keys = append(keys, avatarKey(prefix, name))
+	}
+	s.removeFiles(ctx, keys)
+}
+
+// removeFiles depolamadaki dosyaları siler; hatalar yalnızca günlüğe kaydedilir
+func (s *AvatarService) removeFiles(ctx context.Context, keys []string) {
+	for _, key := range keys {
+		if err := s.storage.Delete(ctx, key); err != nil {
+			log.Printf("Profil fotoğrafı dosyası silinemedi (%s): %v", key, err)
+		}
+	}
+}
+
+func avatarKey(prefix, size string) string {
+	return prefix + "/" + size + ".jpg"
+}
@@ -0,0 +1,507 @@
+package services
+
+import (
+	"archive/zip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path"
+	"time"
+
+	"github.com/UmutTKMN/go-backend/internal/app/model"
+	"github.com/UmutTKMN/go-backend/internal/pkg/database"
+	"github.com/UmutTKMN/go-backend/internal/pkg/signedurl"
+	"github.com/UmutTKMN/go-backend/internal/pkg/storage"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Kişisel veri dışa aktarma ayarları
+const (
+	DataExportRetention     = 72 * time.Hour   // Arşivin indirilebileceği süre
+	MaxDataExportAttempts   = 3                // Arşiv hazırlama deneme sınırı
+	dataExportLease         = 30 * time.Minute // İşlenen isteğin diğer örnekler tarafından alınmayacağı süre
+	dataExportRetryDelay    = 5 * time.Minute
+	dataExportContentType   = "application/zip"
+	dataExportStoragePrefix = "exports"
+)
+
+// ErrDataExportInProgress kullanıcının bekleyen veya hazırlanan bir dışa aktarma isteği var
+var ErrDataExportInProgress = errors.New("hazırlanmakta olan bir veri dışa aktarma isteğiniz zaten var")
+
+// ErrDataExportUnavailable arşiv henüz hazır değil veya süresi dolmuş
+var ErrDataExportUnavailable = errors.New("dışa aktarma arşivi indirilebilir durumda değil")
+
+// DataExportService kullanıcıların kişisel verilerini ZIP arşivi olarak dışa aktarır
+type DataExportService struct {
+	db            *gorm.DB
+	storage       storage.Storage
+	signer        *signedurl.Signer
+	notifications *NotificationService
+}
+
+// NewDataExportService yeni bir DataExportService örneği oluşturur
+func NewDataExportService(store storage.Storage, signer *signedurl.Signer) *DataExportService {
+	return &DataExportService{
+		db:            database.DB,
+		storage:       store,
+		signer:        signer,
+		notifications: NewNotificationService(),
+	}
+}
+
+// RequestExport kullanıcı için yeni bir dışa aktarma isteği oluşturur. Arşiv ProcessExports tarafından
+// hazırlanır; aynı anda yalnızca bir bekleyen istek bulunabilir.
+func (s *DataExportService) RequestExport(userID uint) (*model.DataExport, error) {
+	export := &model.DataExport{
+		UserID:      userID,
+		Status:      model.DataExportStatusPending,
+		AvailableAt: time.Now(),
+	}
+	err := runInTransaction(s.db, func(tx *gorm.DB) error {
+		// Kullanıcı satırı kilitlenerek eşzamanlı isteklerin ikisinin de oluşturulması engellenir
+		var user model.User
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&user, userID).Error; err != nil {
+			return errors.New("kullanıcı bulunamadı")
+		}
+
+		var active int64
+		err := tx.Model(&model.DataExport{}).
+			Where("user_id = ? AND status IN ?", userID, []string{model.DataExportStatusPending, model.DataExportStatusProcessing}).
+			Count(&active).Error
+		if err != nil {
+			return err
+		}
+		if active > 0 {
+			return ErrDataExportInProgress
+		}
+		return tx.Create(export).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return export, nil
+}
+
+// GetExports kullanıcının dışa aktarma isteklerini yeniden eskiye getirir
+func (s *DataExportService) GetExports(userID uint) ([]model.DataExport, error) {
+	var exports []model.DataExport
+	err := s.db.Where("user_id = ?", userID).Order("created_at DESC").Find(&exports).Error
+	return exports, err
+}
+
+// GetExport kullanıcıya ait dışa aktarma isteğini getirir
+func (s *DataExportService) GetExport(userID, id uint) (*model.DataExport, error) {
+	var export model.DataExport
+	if err := s.db.Where("id = ? AND user_id = ?", id, userID).First(&export).Error; err != nil {
+		return nil, errors.New("dışa aktarma isteği bulunamadı")
+	}
+	return &export, nil
+}
+
+// GetExportByID dışa aktarma isteğini ID'ye göre getirir; imzalı bağlantı ile indirmede kullanılır
+func (s *DataExportService) GetExportByID(id uint) (*model.DataExport, error) {
+	var export model.DataExport
+	if err := s.db.First(&export, id).Error; err != nil {
+		return nil, errors.New("dışa aktarma isteği bulunamadı")
+	}
+	return &export, nil
+}
+
+// DownloadURL arşiv için süresi arşivin silinme zamanında dolan bir indirme bağlantısı üretir.
+// Depolama arka ucu kendi imzalı bağlantısını üretebiliyorsa (ör. S3) o kullanılır.
+func (s *DataExportService) DownloadURL(ctx context.Context, export *model.DataExport) (string, time.Time, error) {
+	now := time.Now()
+	if !export.IsDownloadable(now) {
+		return "", time.Time{}, ErrDataExportUnavailable
+	}
+
+	expiry := export.ExpiresAt.Sub(now)
+	if signer, ok := s.storage.(storage.URLSigner); ok {
+		link, err := signer.SignedURL(ctx, export.StoragePath, expiry, export.FileName)
+		return link, *export.ExpiresAt, err
+	}
+
+	link, expiresAt := s.signer.URL(DataExportFilePath(export.ID), expiry)
+	return link, expiresAt, nil
+}
+
+// OpenExport arşivin içeriğini okumak için açar ve indirme sayısını artırır
+func (s *DataExportService) OpenExport(ctx context.Context, export *model.DataExport) (io.ReadCloser, error) {
+	if !export.IsDownloadable(time.Now()) {
+		return nil, ErrDataExportUnavailable
+	}
+	reader, err := s.storage.Open(ctx, export.StoragePath)
+	if err != nil {
+		return nil, err
+	}
+	s.db.Model(&model.DataExport{}).Where("id = ?", export.ID).
+		UpdateColumn("download_count", gorm.Expr("download_count + 1"))
+	return reader, nil
+}
+
+// DataExportFilePath imzalı bağlantıyla arşiv indirmek için kullanılan uygulama yolunu döndürür
+func DataExportFilePath(id uint) string {
+	return fmt.Sprintf("/api/v1/files/exports/%d", id)
+}
+
+// DataExportLinkPath oturum açmış kullanıcıya arşivin indirme bağlantısını üreten uç noktanın yolunu döndürür
+func DataExportLinkPath(id uint) string {
+	return fmt.Sprintf("/api/v1/profile/exports/%d/download-url", id)
+}
+
+// ProcessExports bekleyen dışa aktarma isteklerinin arşivlerini hazırlar. İstekler kira süresiyle alındığından
+// birden fazla uygulama örneği aynı anda çalışabilir; yarıda kalan istekler kira dolunca yeniden denenir.
+func (s *DataExportService) ProcessExports(ctx context.Context) error {
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		export, err := s.claimExport(ctx)
+		if err != nil {
+			return err
+		}
+		if export == nil {
+			return nil
+		}
+
+		if err := s.processExport(ctx, export); err != nil {
+			log.Printf("Dışa aktarma kaydı güncellenemedi (dışa aktarma %d): %v", export.ID, err)
+		}
+	}
+}
+
+// claimExport zamanı gelmiş bir isteği alır, işleniyor olarak işaretler ve kira süresi kadar erteler
+func (s *DataExportService) claimExport(ctx context.Context) (*model.DataExport, error) {
+	var exports []model.DataExport
+	now := time.Now()
+	err := s.db.WithContext(ctx).Raw(`
+		UPDATE data_exports SET status = ?, available_at = ?, attempts = attempts + 1,
+			started_at = COALESCE(started_at, ?), updated_at = ?
+		WHERE id IN (
+			SELECT id FROM data_exports
+			WHERE status IN ? AND available_at <= ?
+			ORDER BY available_at
+			LIMIT 1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING *
+	`, model.DataExportStatusProcessing, now.Add(dataExportLease), now, now,
+		[]string{model.DataExportStatusPending, model.DataExportStatusProcessing}, now).Scan(&exports).Error
+	if err != nil || len(exports) == 0 {
+		return nil, err
+	}
+	return &exports[0], nil
+}
+
+// processExport arşivi hazırlayıp depolamaya yazar ve kullanıcıyı bilgilendirir. Hata durumunda istek
+// yeniden denenmek üzere beklemeye alınır; deneme sınırında başarısız olarak işaretlenir.
+func (s *DataExportService) processExport(ctx context.Context, export *model.DataExport) error {
+	buildErr := s.buildExport(ctx, export)
+	if buildErr != nil {
+		updates := map[string]interface{}{"last_error": buildErr.Error()}
+		if export.Attempts >= MaxDataExportAttempts {
+			updates["status"] = model.DataExportStatusFailed
+		} else {
+			updates["status"] = model.DataExportStatusPending
+			updates["available_at"] = time.Now().Add(dataExportRetryDelay)
+		}
+		return s.db.Model(&model.DataExport{}).Where("id = ?", export.ID).Updates(updates).Error
+	}
+
+	// Bildirimde uzun süreli imzalı bağlantı yerine oturum gerektiren bağlantı uç noktası paylaşılır;
+	// e-posta iletilse veya sızsa bile arşiv kimlik doğrulaması olmadan indirilemez
+	_, err := s.notifications.Notify(export.UserID, Notification{
+		Category: model.NotificationCategorySecurity,
+		Template: "data_export_ready",
+		Data: map[string]interface{}{
+			"DownloadURL": s.signer.BaseURL() + DataExportLinkPath(export.ID),
+			"ExpiresAt":   export.ExpiresAt.Format("2006-01-02 15:04"),
+		},
+		Importance: model.ImportanceHigh,
+	})
+	if err != nil {
+		log.Printf("Dışa aktarma bildirimi gönderilemedi (dışa aktarma %d): %v", export.ID, err)
+	}
+	return nil
+}
+
+// buildExport arşivi geçici bir dosyada oluşturur, depolamaya yükler ve isteği hazır olarak işaretler
+func (s *DataExportService) buildExport(ctx context.Context, export *model.DataExport) error {
+	file, err := os.CreateTemp("", "data-export-*.zip")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+	defer file.Close()
+
+	if err := s.writeArchive(ctx, export.UserID, file); err != nil {
+		return err
+	}
+
+	size, err := file.Seek(0, io.SeekEnd)
+	if err != nil {
+		return err
+	}
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+
+	key, err := newStorageKey(fmt.Sprintf("%s/%d", dataExportStoragePrefix, export.UserID), ".zip")
+	if err != nil {
+		return err
+	}
+	hash := sha256.New()
+	if err := s.storage.Put(ctx, key, io.TeeReader(file, hash), size, dataExportContentType); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	expiresAt := now.Add(DataExportRetention)
+	fileName := fmt.Sprintf("kisisel-veriler-%d-%s.zip", export.UserID, now.Format("20060102"))
+	updates := map[string]interface{}{
+		"status":       model.DataExportStatusReady,
+		"storage_path": key,
+		"file_name":    fileName,
+		"size":         size,
+		"checksum":     hex.EncodeToString(hash.Sum(nil)),
+		"completed_at": now,
+		"expires_at":   expiresAt,
+		"last_error":   "",
+	}
+	if err := s.db.Model(&model.DataExport{}).Where("id = ?", export.ID).Updates(updates).Error; err != nil {
+		s.removeFile(ctx, key)
+		return err
+	}
+
+	export.Status = model.DataExportStatusReady
+	export.StoragePath = key
+	export.FileName = fileName
+	export.Size = size
+	export.CompletedAt = &now
+	export.ExpiresAt = &expiresAt
+	return nil
+}
+
+// loginHistory hesap ve cihaz kayıtlarından derlenen oturum açma geçmişi
+type loginHistory struct {
+	RegistrationDate   time.Time         `json:"registration_date"`
+	RegistrationIP     string            `json:"registration_ip"`
+	LastLogin          *time.Time        `json:"last_login"`
+	LastActivity       *time.Time        `json:"last_activity"`
+	LastPasswordChange *time.Time        `json:"last_password_change"`
+	FailedAttempts     int               `json:"failed_attempts"`
+	AccountLockedUntil *time.Time        `json:"account_locked_until"`
+	Devices            []loginDeviceInfo `json:"devices"`
+}
+
+// loginDeviceInfo bir cihazdan yapılan son oturum açma
+type loginDeviceInfo struct {
+	DeviceName    string    `json:"device_name"`
+	DeviceType    string    `json:"device_type"`
+	LastIP        string    `json:"last_ip"`
+	LastLoginDate time.Time `json:"last_login_date"`
+	GeoLocation   string    `json:"geo_location"`
+	UserAgent     string    `json:"user_agent"`
+}
+
+// writeArchive kullanıcıyla ilişkili tüm kayıtları JSON dosyaları, belgeleri ise özgün dosyaları olarak
+// ZIP arşivine yazar
+func (s *DataExportService) writeArchive(ctx context.Context, userID uint, w io.Writer) error {
+	db := s.db.WithContext(ctx)
+
+	var user model.User
+	if err := db.Preload("Roles").Preload("Staff").First(&user, userID).Error; err != nil {
+		return errors.New("kullanıcı bulunamadı")
+	}
+
+	var requests []model.UserRequest
+	if err := db.Where("user_id = ?", userID).Order("id").Find(&requests).Error; err != nil {
+		return err
+	}
+
+	// Kullanıcının taleplerine yazılan personel içi olmayan yorumlar ve kullanıcının yazdığı tüm yorumlar
+	var comments []model.RequestComment
+	err := db.Preload("Attachments").
+		Where("author_user_id = ? OR (is_internal = ? AND request_id IN (?))",
+			userID, false, db.Model(&model.UserRequest{}).Select("id").Where("user_id = ?", userID)).
+		Order("id").
+		Find(&comments).Error
+	if err != nil {
+		return err
+	}
+
+	var documents []model.UserDocument
+	if err := db.Where("user_id = ?", userID).Order("id").Find(&documents).Error; err != nil {
+		return err
+	}
+
+	var devices []model.UserDevice
+	if err := db.Where("user_id = ?", userID).Order("last_login_date DESC").Find(&devices).Error; err != nil {
+		return err
+	}
+
+	var storedPreferences []model.UserPreference
+	if err := db.Where("user_id = ?", userID).Order("preference_key").Find(&storedPreferences).Error; err != nil {
+		return err
+	}
+
+	var communications []model.UserCommunication
+	if err := db.Preload("Deliveries").Where("user_id = ?", userID).Order("id").Find(&communications).Error; err != nil {
+		return err
+	}
+
+	var staff *model.Staff
+	if user.Staff != nil {
+		staff = &model.Staff{}
+		// Yöneticinin personel kaydı başka bir kişinin verisi olduğundan arşive yalnızca manager_id girer
+		if err := db.Preload("Role").First(staff, user.Staff.ID).Error; err != nil {
+			return err
+		}
+	}
+
+	history := loginHistory{
+		RegistrationDate:   user.RegistrationDate,
+		RegistrationIP:     user.IpRegistration,
+		LastLogin:          user.LastLogin,
+		LastActivity:       user.LastActivity,
+		LastPasswordChange: user.LastPasswordChange,
+		FailedAttempts:     user.LoginAttempts,
+		AccountLockedUntil: user.AccountLockedUntil,
+		Devices:            make([]loginDeviceInfo, 0, len(devices)),
+	}
+	for _, device := range devices {
+		history.Devices = append(history.Devices, loginDeviceInfo{
+			DeviceName:    device.DeviceName,
+			DeviceType:    device.DeviceType,
+			LastIP:        device.LastIP,
+			LastLoginDate: device.LastLoginDate,
+			GeoLocation:   device.GeoLocation,
+			UserAgent:     device.UserAgent,
+		})
+	}
+
+	roles := user.Roles
+	user.Roles = nil
+	user.Staff = nil
+
+	archive := zip.NewWriter(w)
+	files := []struct {
+		name  string
+		value interface{}
+	}{
+		{"user.json", user},
+		{"notification_preferences.json", notificationSettings(&user)},
+		{"privacy_settings.json", privacySettings(&user)},
+		{"requests.json", requests},
+		{"request_comments.json", comments},
+		{"documents.json", documents},
+		{"devices.json", devices},
+		{"preferences.json", map[string]interface{}{
+			"stored":    storedPreferences,
+			"effective": buildPreferenceSet(storedPreferences).Values,
+		}},
+		{"communications.json", communications},
+		{"roles.json", roles},
+		{"staff.json", staff},
+		{"login_history.json", history},
+	}
+
+	manifest := map[string]interface{}{
+		"user_id":      userID,
+		"generated_at": time.Now(),
+	}
+	var names []string
+	for _, file := range files {
+		if err := writeArchiveJSON(archive, file.name, file.value); err != nil {
+			return err
+		}
+		names = append(names, file.name)
+	}
+
+	// Taramadan geçmemiş belgeler arşive eklenmez; kayıtları documents.json içinde yer alır
+	for _, document := range documents {
+		if !document.IsAvailable() {
+			continue
+		}
+		name := path.Join("documents", fmt.Sprintf("%d-%s", document.ID, sanitizeFileName(document.FileName)))
+		if err := s.copyToArchive(ctx, archive, name, document.DocumentPath); err != nil {
+			return fmt.Errorf("belge arşive eklenemedi (belge %d): %w", document.ID, err)
+		}
+		names = append(names, name)
+	}
+
+	manifest["files"] = names
+	if err := writeArchiveJSON(archive, "manifest.json", manifest); err != nil {
+		return err
+	}
+	return archive.Close()
+}
+
+// copyToArchive depolamadaki dosyayı arşive kopyalar
+func (s *DataExportService) copyToArchive(ctx context.Context, archive *zip.Writer, name, key string) error {
+	reader, err := s.storage.Open(ctx, key)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	entry, err := archive.Create(name)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(entry, reader)
+	return err
+}
+
+// writeArchiveJSON değeri girintili JSON olarak arşive yazar
+func writeArchiveJSON(archive *zip.Writer, name string, value interface{}) error {
+	entry, err := archive.Create(name)
+	if err != nil {
+		return err
+	}
+	encoder := json.NewEncoder(entry)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(value)
+}
+
+// PurgeExpiredExports süresi dolan arşivleri depolamadan siler ve isteklerini süresi dolmuş olarak işaretler
+func (s *DataExportService) PurgeExpiredExports(ctx context.Context) error {
+	var exports []model.DataExport
+	err := s.db.WithContext(ctx).
+		Where("status = ? AND expires_at <= ?", model.DataExportStatusReady, time.Now()).
+		Find(&exports).Error
+	if err != nil {
+		return err
+	}
+
+	for _, export := range exports {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := s.storage.Delete(ctx, export.StoragePath); err != nil && !errors.Is(err, storage.ErrNotFound) {
+			log.Printf("Dışa aktarma arşivi silinemedi (dışa aktarma %d): %v", export.ID, err)
+			continue
+		}
+		err := s.db.Model(&model.DataExport{}).Where("id = ?", export.ID).
+			Updates(map[string]interface{}{"status": model.DataExportStatusExpired, "storage_path": ""}).Error
+		if err != nil {
+			log.Printf("Dışa aktarma kaydı güncellenemedi (dışa aktarma %d): %v", export.ID, err)
+		}
+	}
+	return nil
+}
+
+// removeFile depolamadaki arşivi siler; hata yalnızca günlüğe kaydedilir
+func (s *DataExportService) removeFile(ctx context.Context, key string) {
+	if err := s.storage.Delete(ctx, key); err != nil && !errors.Is(err, storage.ErrNotFound) {
+		log.Printf("Dışa aktarma arşivi silinemedi (%s): %v", key, err)
+	}
+}
@@ -0,0 +1,198 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/UmutTKMN/go-backend/internal/app/model"
+	"github.com/UmutTKMN/go-backend/internal/pkg/database"
+	"gorm.io/gorm"
+)
+
+// DelegationScopes geçerli yetki devri kapsamları. Yalnızca onay yolunda yetki devri kontrol edilen
+// kapsamlar kabul edilir; talep ve belge işlemleri yöneticiye bağlı olmadığından devredilemez.
+var DelegationScopes = []string{
+	model.DelegationScopeAll,
+	model.DelegationScopeLeave,
+}
+
+// DelegationService onay yetkisi devri işlemleri için servis
+type DelegationService struct {
+	db *gorm.DB
+}
+
+// NewDelegationService yeni bir DelegationService örneği oluşturur
+func NewDelegationService() *DelegationService {
+	return &DelegationService{
+		db: database.DB,
+	}
+}
+
+// CreateDelegation yeni bir yetki devri oluşturur
+func (s *DelegationService) CreateDelegation(delegation *model.Delegation) error {
+	if delegation.Scope == "" {
+		delegation.Scope = model.DelegationScopeAll
+	}
+	if !isValidDelegationScope(delegation.Scope) {
+		return fmt.Errorf("geçersiz yetki devri kapsamı, beklenen: %s", strings.Join(DelegationScopes, ", "))
+	}
+
+	if delegation.DelegatorStaffID == delegation.DelegateStaffID {
+		return errors.New("personel yetkisini kendisine devredemez")
+	}
+
+	delegation.StartDate = truncateDay(delegation.StartDate)
+	delegation.EndDate = truncateDay(delegation.EndDate)
+	if delegation.EndDate.Before(delegation.StartDate) {
+		return errors.New("bitiş tarihi başlangıç tarihinden önce olamaz")
+	}
+	if delegation.EndDate.Before(truncateDay(time.Now())) {
+		return errors.New("geçmiş tarihli yetki devri oluşturulamaz")
+	}
+
+	return runInTransaction(s.db, func(tx *gorm.DB) error {
+		var delegator model.Staff
+		if err := tx.First(&delegator, delegation.DelegatorStaffID).Error; err != nil {
+			return errors.New("yetkiyi devreden personel bulunamadı")
+		}
+
+		var delegate model.Staff
+		if err := tx.First(&delegate, delegation.DelegateStaffID).Error; err != nil {
+			return errors.New("yetki devredilen personel bulunamadı")
+		}
+		if delegate.EmployeeStatus == model.EmployeeStatusTerminated {
+			return errors.New("işten ayrılmış personele yetki devredilemez")
+		}
+
+		// Karşılıklı devirler onay zincirinde döngü oluşturacağından engellenir
+		var reverse int64
+		if err := tx.Model(&model.Delegation{}).
+			Where("delegator_staff_id = ? AND delegate_staff_id = ? AND status = ?", delegation.DelegateStaffID, delegation.DelegatorStaffID, model.DelegationStatusActive).
+			Where("start_date <= ? AND end_date >= ?", delegation.EndDate, delegation.StartDate).
+			Count(&reverse).Error; err != nil {
+			return err
+		}
+		if reverse > 0 {
+			return errors.New("bu tarihlerde karşı yönde aktif bir yetki devri var")
+		}
+
+		delegation.Status = model.DelegationStatusActive
+		delegation.CancelledAt = nil
+		return tx.Create(delegation).Error
+	})
+}
+
+// GetDelegationByID ID'ye göre yetki devri getirir
+func (s *DelegationService) GetDelegationByID(id uint) (*model.Delegation, error) {
+	var delegation model.Delegation
+	result := s.db.Preload("Delegator.User").Preload("Delegate.User").First(&delegation, id)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, errors.New("yetki devri bulunamadı")
+		}
+		return nil, result.Error
+	}
+	return &delegation, nil
+}
+
+// GetDelegationsByStaff personelin verdiği ve aldığı yetki devirlerini getirir
+func (s *DelegationService) GetDelegationsByStaff(staffID uint) (given, received []model.Delegation, err error) {
+	err = s.db.Preload("Delegate.User").
+		Where("delegator_staff_id = ?", staffID).
+		Order("start_date DESC").
+		Find(&given).Error
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = s.db.Preload("Delegator.User").
+		Where("delegate_staff_id = ?", staffID).
+		Order("start_date DESC").
+		Find(&received).Error
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return given, received, nil
+}
+
+// CancelDelegation bir yetki devrini iptal eder.
+// force true ise (ör. admin) devreden personel kontrolü yapılmaz.
+func (s *DelegationService) CancelDelegation(id, staffID uint, force bool) error {
+	var delegation model.Delegation
+	if err := s.db.First(&delegation, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("yetki devri bulunamadı")
+		}
+		return err
+	}
+
+	if !force && delegation.DelegatorStaffID != staffID {
+		return errors.New("yalnızca yetkiyi devreden personel iptal edebilir")
+	}
+
+	if delegation.Status != model.DelegationStatusActive {
+		return errors.New("yetki devri zaten iptal edilmiş")
+	}
+
+	now := time.Now()
+	return s.db.Model(&delegation).Updates(map[string]interface{}{
+		"status":       model.DelegationStatusCancelled,
+		"cancelled_at": now,
+	}).Error
+}
+
+// GetActiveDelegatorIDs personelin verilen kapsamda şu anda adına onay verebildiği personelin ID'lerini getirir
+func (s *DelegationService) GetActiveDelegatorIDs(delegateStaffID uint, scope string, at time.Time) ([]uint, error) {
+	day := truncateDay(at)
+	var ids []uint
+	result := s.db.Model(&model.Delegation{}).
+		Where("delegate_staff_id = ? AND status = ?", delegateStaffID, model.DelegationStatusActive).
+		Where("scope IN ?", []string{model.DelegationScopeAll, scope}).
+		Where("start_date <= ? AND end_date >= ?", day, day).
+		Distinct().
+		Pluck("delegator_staff_id", &ids)
+	return ids, result.Error
+}
+
+// CanActFor actorStaffID'nin verilen kapsamda approverStaffID adına onay verip veremeyeceğini kontrol eder.
+// Personel kendi adına her zaman onay verebilir; başkası adına yalnızca aktif bir yetki devri varsa.
+// Devirler zincirlenmez: yalnızca doğrudan devredilen yetki geçerlidir.
+func (s *DelegationService) CanActFor(actorStaffID, approverStaffID uint, scope string, at time.Time) (bool, error) {
+	if actorStaffID == approverStaffID {
+		return true, nil
+	}
+
+	delegatorIDs, err := s.GetActiveDelegatorIDs(actorStaffID, scope, at)
+	if err != nil {
+		return false, err
+	}
+
+	for _, id := range delegatorIDs {
+		if id == approverStaffID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// EffectiveApproverIDs personelin verilen kapsamda onay verebildiği tüm yönetici ID'lerini
+// (kendisi ve yetki devreden personel) döndürür
+func (s *DelegationService) EffectiveApproverIDs(actorStaffID uint, scope string, at time.Time) ([]uint, error) {
+	delegatorIDs, err := s.GetActiveDelegatorIDs(actorStaffID, scope, at)
+	if err != nil {
+		return nil, err
+	}
+	return append([]uint{actorStaffID}, delegatorIDs...), nil
+}
+
+func isValidDelegationScope(scope string) bool {
+	for _, valid := range DelegationScopes {
+		if scope == valid {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,555 @@
+package services
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/UmutTKMN/go-backend/internal/app/model"
+	"github.com/UmutTKMN/go-backend/internal/pkg/database"
+	"github.com/UmutTKMN/go-backend/internal/pkg/listquery"
+	"github.com/UmutTKMN/go-backend/internal/pkg/scanner"
+	"github.com/UmutTKMN/go-backend/internal/pkg/signedurl"
+	"github.com/UmutTKMN/go-backend/internal/pkg/storage"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Belge yükleme sınırları
+const (
+	MaxDocumentSize     = 10 << 20 // 10 MB
+	DocumentLinkExpiry  = 5 * time.Minute
+	documentSniffLength = 512
+)
+
+// DocumentRescanDelay taraması yarım kalan veya başarısız olan belgelerin yeniden taranmadan önce beklediği süre
+const DocumentRescanDelay = 5 * time.Minute
+
+// documentQuarantinePrefix virüslü dosyaların taşındığı depolama öneki
+const documentQuarantinePrefix = "quarantine/"
+
+// Belge tarama hataları
+var (
+	ErrDocumentInfected    = errors.New("dosyada zararlı içerik tespit edildi")
+	ErrDocumentUnavailable = errors.New("belge güvenlik taramasından geçmediği için kullanılamaz")
+)
+
+// DocumentExpiryWarningDays son geçerlilik tarihine bu kadar gün kalan onaylı belgelerin sahipleri uyarılır
+const DocumentExpiryWarningDays = 30
+
+// DocumentQueueSpec belge inceleme kuyruğunda izin verilen sıralama alanları ve sayfa sınırları
+var DocumentQueueSpec = listquery.Spec{
+	SortFields: map[string]listquery.SortField{
+		"id":          {Column: "user_documents.id", Kind: listquery.KindInt},
+		"upload_date": {Column: "user_documents.upload_date", Kind: listquery.KindTime},
+	},
+	DefaultSort:  "upload_date",
+	IDColumn:     "user_documents.id",
+	DefaultLimit: 20,
+	MaxLimit:     100,
+}
+
+// DocumentQueueFilter belge inceleme kuyruğu filtreleri
+type DocumentQueueFilter struct {
+	Status         string
+	DocumentType   string
+	ScanStatus     string // Boşsa yalnızca taramadan geçmiş belgeler listelenir
+	UserID         uint
+	IncludeDeleted bool // Silinmiş belgeleri de listeler
+}
+
+// AllowedDocumentTypes yüklenebilecek belge MIME türleri ve dosya uzantıları
+var AllowedDocumentTypes = map[string]string{
+	"application/pdf": ".pdf",
+	"image/jpeg":      ".jpg",
+	"image/png":       ".png",
+}
+
+// DocumentService kullanıcı belgelerinin yüklenmesi ve indirilmesi için servis
+type DocumentService struct {
+	db            *gorm.DB
+	storage       storage.Storage
+	signer        *signedurl.Signer
+	scanner       scanner.Scanner
+	notifications *NotificationService
+}
+
+// NewDocumentService yeni bir DocumentService örneği oluşturur.
+// fileScanner nil ise belgeler taranmadan kullanıma açılır.
+func NewDocumentService(store storage.Storage, signer *signedurl.Signer, fileScanner scanner.Scanner) *DocumentService {
+	return &DocumentService{
+		db:            database.DB,
+		storage:       store,
+		signer:        signer,
+		scanner:       fileScanner,
+		notifications: NewNotificationService(),
+	}
+}
+
+// UploadDocument belgeyi doğrular, depolamaya yazar, kaydını oluşturur ve taramadan geçirir.
+// MIME türü istemcinin bildirdiği değere değil dosya içeriğine göre belirlenir.
+// Dosya virüslü çıkarsa kayıt karantina durumuyla saklanır ve ErrDocumentInfected döner.
+func (s *DocumentService) UploadDocument(ctx context.Context, document *model.UserDocument, upload FileUpload) error {
+	document.DocumentType = strings.TrimSpace(document.DocumentType)
+	if document.DocumentType == "" {
+		return errors.New("belge türü zorunludur")
+	}
+	if upload.Size <= 0 {
+		return errors.New("dosya boş olamaz")
+	}
+	if upload.Size > MaxDocumentSize {
+		return fmt.Errorf("dosya boyutu en fazla %d MB olabilir", MaxDocumentSize>>20)
+	}
+
+	reader := bufio.NewReaderSize(io.LimitReader(upload.Reader, MaxDocumentSize+1), documentSniffLength)
+	head, err := reader.Peek(documentSniffLength)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, bufio.ErrBufferFull) {
+		return err
+	}
+
+	contentType := strings.SplitN(http.DetectContentType(head), ";", 2)[0]
+	extension, ok := AllowedDocumentTypes[contentType]
+	if !ok {
+		return fmt.Errorf("desteklenmeyen dosya türü: %s", contentType)
+	}
+
+	key, err := newStorageKey(fmt.Sprintf("documents/%d", document.UserID), extension)
+	if err != nil {
+		return err
+	}
+
+	hash := sha256.New()
+	counter := &countingReader{reader: io.TeeReader(reader, hash)}
+	if err := s.storage.Put(ctx, key, counter, upload.Size, contentType); err != nil {
+		return err
+	}
+	if counter.count != upload.Size {
+		s.removeFile(ctx, key)
+		return errors.New("dosya boyutu bildirilen boyutla uyuşmuyor")
+	}
+
+	document.DocumentPath = key
+	document.FileName = sanitizeFileName(upload.FileName)
+	document.ContentType = contentType
+	document.Size = counter.count
+	document.Checksum = hex.EncodeToString(hash.Sum(nil))
+	document.UploadDate = time.Now()
+	document.VerificationStatus = model.DocumentStatusPending
+	document.VerifiedBy = 0
+	document.VerifiedAt = nil
+	document.RejectionReason = ""
+	document.ExpiryNotifiedAt = nil
+	document.ScanStatus = model.ScanStatusPending
+	document.ScanSignature = ""
+	document.ScannedAt = nil
+
+	if err := s.db.Create(document).Error; err != nil {
+		s.removeFile(ctx, key)
+		return err
+	}
+
+	// Tarama yapılamazsa belge beklemede kalır ve zamanlanmış iş tarafından yeniden denenir
+	if err := s.ScanDocument(ctx, document); err != nil {
+		log.Printf("Belge taranamadı (belge %d): %v", document.ID, err)
+	}
+	if document.ScanStatus == model.ScanStatusInfected {
+		return ErrDocumentInfected
+	}
+	return nil
+}
+
+// ScanDocument belgeyi tarayıcıdan geçirip sonucu kaydeder. Virüslü dosya karantinaya taşınır
+// ve yöneticiler bilgilendirilir. Tarayıcı yapılandırılmamışsa belge taranmadan kullanıma açılır.
+func (s *DocumentService) ScanDocument(ctx context.Context, document *model.UserDocument) error {
+	now := time.Now()
+	if s.scanner == nil {
+		return s.saveScanResult(document, model.ScanStatusSkipped, "", now)
+	}
+
+	reader, err := s.storage.Open(ctx, document.DocumentPath)
+	if err != nil {
+		return errors.Join(err, s.saveScanResult(document, model.ScanStatusFailed, "", now))
+	}
+	result, err := s.scanner.Scan(ctx, reader)
+	reader.Close()
+	if err != nil {
+		return errors.Join(err, s.saveScanResult(document, model.ScanStatusFailed, "", now))
+	}
+
+	if !result.Infected {
+		return s.saveScanResult(document, model.ScanStatusClean, "", now)
+	}
+
+	// Karantinaya alınamasa da belge virüslü olarak işaretlenir ve kullanıma kapatılır
+	if err := s.quarantine(ctx, document); err != nil {
+		log.Printf("Belge karantinaya alınamadı (belge %d): %v", document.ID, err)
+	}
+	if err := s.saveScanResult(document, model.ScanStatusInfected, result.Signature, now); err != nil {
+		return err
+	}
+
+	err = s.notifications.NotifyRoles(Notification{
+		Category:  model.NotificationCategorySecurity,
+		Template:  "document_infected",
+		Mandatory: true,
+		Data: map[string]interface{}{
+			"UserID":     document.UserID,
+			"DocumentID": document.ID,
+			"FileName":   document.FileName,
+			"Signature":  result.Signature,
+		},
+		Importance: model.ImportanceHigh,
+	}, "Admin", "Super Admin")
+	if err != nil {
+		log.Printf("Karantina bildirimi gönderilemedi (belge %d): %v", document.ID, err)
+	}
+	return nil
+}
+
+// RescanDocuments taraması başarısız olan veya yarım kalan belgeleri yeniden tarar
+func (s *DocumentService) RescanDocuments(ctx context.Context) error {
+	var documents []model.UserDocument
+	err := s.db.WithContext(ctx).
+		Where("scan_status IN ? AND updated_at < ?", []string{model.ScanStatusPending, model.ScanStatusFailed}, time.Now().Add(-DocumentRescanDelay)).
+		Order("id").
+		Find(&documents).Error
+	if err != nil {
+		return err
+	}
+
+	for i := range documents {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := s.ScanDocument(ctx, &documents[i]); err != nil {
+			log.Printf("Belge yeniden taranamadı (belge %d): %v", documents[i].ID, err)
+		}
+	}
+	return nil
+}
+
+// quarantine dosyayı karantina önekine taşır ve belgenin depolama anahtarını günceller
+func (s *DocumentService) quarantine(ctx context.Context, document *model.UserDocument) error {
+	if strings.HasPrefix(document.DocumentPath, documentQuarantinePrefix) {
+		return nil
+	}
+
+	reader, err := s.storage.Open(ctx, document.DocumentPath)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	key := documentQuarantinePrefix + document.DocumentPath
+	if err := s.storage.Put(ctx, key, reader, document.Size, document.ContentType); err != nil {
+		return err
+	}
+	if err := s.db.Model(&model.UserDocument{}).Where("id = ?", document.ID).Update("document_path", key).Error; err != nil {
+		s.removeFile(ctx, key)
+		return err
+	}
+
+	s.removeFile(ctx, document.DocumentPath)
+	document.DocumentPath = key
+	return nil
+}
+
+// saveScanResult tarama sonucunu belgeye yazar
+func (s *DocumentService) saveScanResult(document *model.UserDocument, status, signature string, scannedAt time.Time) error {
+	updates := map[string]interface{}{
+		"scan_status":    status,
+		"scan_signature": signature,
+		"scanned_at":     scannedAt,
+	}
+	if err := s.db.Model(&model.UserDocument{}).Where("id = ?", document.ID).Updates(updates).Error; err != nil {
+		return err
+	}
+
+	document.ScanStatus = status
+	document.ScanSignature = signature
+	document.ScannedAt = &scannedAt
+	return nil
+}
+
+// GetDocumentsByUser kullanıcının belgelerini en yeniden eskiye getirir
+func (s *DocumentService) GetDocumentsByUser(userID uint) ([]model.UserDocument, error) {
+	var documents []model.UserDocument
+	result := s.db.Where("user_id = ?", userID).Order("upload_date DESC").Find(&documents)
+	return documents, result.Error
+}
+
+// GetDocumentByID ID'ye göre belge getirir
+func (s *DocumentService) GetDocumentByID(id uint) (*model.UserDocument, error) {
+	var document model.UserDocument
+	result := s.db.First(&document, id)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, errors.New("belge bulunamadı")
+		}
+		return nil, result.Error
+	}
+	return &document, nil
+}
+
+// GetUserDocument kullanıcıya ait belgeyi getirir; başka kullanıcının belgesi bulunamadı olarak döner
+func (s *DocumentService) GetUserDocument(userID, id uint) (*model.UserDocument, error) {
+	document, err := s.GetDocumentByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if document.UserID != userID {
+		return nil, errors.New("belge bulunamadı")
+	}
+	return document, nil
+}
+
+// DeleteDocument kullanıcının belgesini geri yüklenebilir şekilde siler.
+// Dosya, belge saklama süresi sonunda kalıcı olarak silinene kadar depolamada tutulur.
+func (s *DocumentService) DeleteDocument(userID, id uint) error {
+	document, err := s.GetUserDocument(userID, id)
+	if err != nil {
+		return err
+	}
+
+	return s.db.Delete(&model.UserDocument{}, document.ID).Error
+}
+
+// RestoreDocument silinmiş bir belgeyi geri yükler
+func (s *DocumentService) RestoreDocument(id uint) (*model.UserDocument, error) {
+	var document model.UserDocument
+	if err := s.db.Unscoped().First(&document, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("belge bulunamadı")
+		}
+		return nil, err
+	}
+	if !document.DeletedAt.Valid {
+		return nil, errors.New("belge silinmemiş")
+	}
+
+	if err := s.db.Unscoped().Model(&document).Update("deleted_at", nil).Error; err != nil {
+		return nil, err
+	}
+	document.DeletedAt = gorm.DeletedAt{}
+	return &document, nil
+}
+
+// GetReviewQueue personel için filtrelenmiş belge inceleme kuyruğunu sayfalı olarak getirir
+func (s *DocumentService) GetReviewQueue(filter DocumentQueueFilter, query *listquery.Query) (*listquery.Page[model.UserDocument], error) {
+	db := s.db.Model(&model.UserDocument{}).Preload("User")
+
+	if filter.IncludeDeleted {
+		db = db.Unscoped()
+	}
+	if filter.Status != "" {
+		db = db.Where("user_documents.verification_status = ?", filter.Status)
+	}
+	if filter.DocumentType != "" {
+		db = db.Where("user_documents.document_type = ?", filter.DocumentType)
+	}
+	if filter.UserID > 0 {
+		db = db.Where("user_documents.user_id = ?", filter.UserID)
+	}
+	if filter.ScanStatus != "" {
+		db = db.Where("user_documents.scan_status = ?", filter.ScanStatus)
+	} else {
+		db = db.Where("user_documents.scan_status IN ?", []string{model.ScanStatusClean, model.ScanStatusSkipped})
+	}
+	if query.Search != "" {
+		db = db.Where("user_documents.file_name ILIKE ?", "%"+escapeLike(query.Search)+"%")
+	}
+
+	return listquery.Paginate(db, query, DocumentQueueSpec, func(document *model.UserDocument) (interface{}, uint) {
+		if query.SortKey == "upload_date" {
+			return document.UploadDate, document.ID
+		}
+		return document.ID, document.ID
+	})
+}
+
+// ReviewDocument bekleyen belgeyi onaylar veya reddeder ve belge sahibini bilgilendirir.
+// Ret için gerekçe zorunludur; süresi dolmuş belge onaylanamaz ve kimse kendi belgesini inceleyemez.
+func (s *DocumentService) ReviewDocument(id, staffID, reviewerUserID uint, approve bool, reason string) (*model.UserDocument, error) {
+	reason = strings.TrimSpace(reason)
+	if !approve && reason == "" {
+		return nil, errors.New("ret gerekçesi zorunludur")
+	}
+
+	var document model.UserDocument
+	err := runInTransaction(s.db, func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&document, id).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.New("belge bulunamadı")
+			}
+			return err
+		}
+
+		if document.VerificationStatus != model.DocumentStatusPending {
+			return errors.New("yalnızca inceleme bekleyen belgeler karara bağlanabilir")
+		}
+		if document.UserID == reviewerUserID {
+			return errors.New("kendi belgenizi inceleyemezsiniz")
+		}
+		if approve && !document.IsAvailable() {
+			return ErrDocumentUnavailable
+		}
+		if approve && document.ExpiryDate != nil && document.ExpiryDate.Before(truncateDay(time.Now())) {
+			return errors.New("süresi dolmuş belge onaylanamaz")
+		}
+
+		status := model.DocumentStatusRejected
+		if approve {
+			status = model.DocumentStatusApproved
+			reason = ""
+		}
+
+		now := time.Now()
+		updates := map[string]interface{}{
+			"verification_status": status,
+			"verified_by":         staffID,
+			"verified_at":         now,
+			"rejection_reason":    reason,
+		}
+		if err := tx.Model(&document).Updates(updates).Error; err != nil {
+			return err
+		}
+
+		document.VerificationStatus = status
+		document.VerifiedBy = staffID
+		document.VerifiedAt = &now
+		document.RejectionReason = reason
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	notification := Notification{
+		Category: model.NotificationCategorySecurity,
+		Template: "document_approved",
+		Data: map[string]interface{}{
+			"DocumentType": document.DocumentType,
+			"FileName":     document.FileName,
+			"Reason":       reason,
+		},
+	}
+	if !approve {
+		notification.Template = "document_rejected"
+		notification.Importance = model.ImportanceHigh
+	}
+	if _, err := s.notifications.Notify(document.UserID, notification); err != nil {
+		log.Printf("Belge inceleme bildirimi gönderilemedi (belge %d): %v", document.ID, err)
+	}
+
+	return &document, nil
+}
+
+// FlagExpiringDocuments son geçerlilik tarihi yaklaşan veya geçmiş onaylı belgeleri işaretler
+// ve sahiplerinden yenisini yüklemelerini ister. Her belge için yalnızca bir kez bildirim gönderilir.
+func (s *DocumentService) FlagExpiringDocuments(ctx context.Context) error {
+	today := truncateDay(time.Now())
+	threshold := today.AddDate(0, 0, DocumentExpiryWarningDays)
+
+	var documents []model.UserDocument
+	err := s.db.WithContext(ctx).
+		Where("verification_status = ? AND expiry_date IS NOT NULL AND expiry_date <= ?", model.DocumentStatusApproved, threshold).
+		Where("expiry_notified_at IS NULL").
+		Find(&documents).Error
+	if err != nil {
+		return err
+	}
+
+	for _, document := range documents {
+		result := s.db.Model(&model.UserDocument{}).
+			Where("id = ? AND expiry_notified_at IS NULL", document.ID).
+			Update("expiry_notified_at", time.Now())
+		if result.Error != nil {
+			log.Printf("Belge süresi işaretlenemedi (belge %d): %v", document.ID, result.Error)
+			continue
+		}
+		if result.RowsAffected == 0 {
+			continue
+		}
+
+		_, err := s.notifications.Notify(document.UserID, Notification{
+			Category: model.NotificationCategorySecurity,
+			Template: "document_expiring",
+			Data: map[string]interface{}{
+				"DocumentType": document.DocumentType,
+				"ExpiryDate":   document.ExpiryDate.Format("2006-01-02"),
+				"Expired":      document.ExpiryDate.Before(today),
+			},
+			Importance: model.ImportanceHigh,
+		})
+		if err != nil {
+			log.Printf("Belge süresi bildirimi gönderilemedi (belge %d): %v", document.ID, err)
+		}
+	}
+	return nil
+}
+
+// DownloadURL belge için süreli bir indirme bağlantısı üretir.
+// Depolama arka ucu kendi imzalı bağlantısını üretebiliyorsa (ör. S3) o kullanılır.
+func (s *DocumentService) DownloadURL(ctx context.Context, document *model.UserDocument) (string, time.Time, error) {
+	if !document.IsAvailable() {
+		return "", time.Time{}, ErrDocumentUnavailable
+	}
+
+	if signer, ok := s.storage.(storage.URLSigner); ok {
+		link, err := signer.SignedURL(ctx, document.DocumentPath, DocumentLinkExpiry, document.FileName)
+		return link, time.Now().Add(DocumentLinkExpiry), err
+	}
+
+	link, expiresAt := s.signer.URL(DocumentFilePath(document.ID), DocumentLinkExpiry)
+	return link, expiresAt, nil
+}
+
+// OpenDocument belgenin içeriğini okumak için açar
+func (s *DocumentService) OpenDocument(ctx context.Context, document *model.UserDocument) (io.ReadCloser, error) {
+	if !document.IsAvailable() {
+		return nil, ErrDocumentUnavailable
+	}
+	return s.storage.Open(ctx, document.DocumentPath)
+}
+
+// DocumentFilePath imzalı bağlantıyla belge indirmek için kullanılan uygulama yolunu döndürür
+func DocumentFilePath(id uint) string {
+	return fmt.Sprintf("/api/v1/files/documents/%d", id)
+}
+
+// removeFile depolamadaki dosyayı siler; hata yalnızca günlüğe kaydedilir
+func (s *DocumentService) removeFile(ctx context.Context, key string) {
+	if err := s.storage.Delete(ctx, key); err != nil {
+		log.Printf("Belge dosyası silinemedi (%s): %v", key, err)
+	}
+}
+
+// newStorageKey tahmin edilemeyen bir dosya adıyla depolama anahtarı üretir
+func newStorageKey(prefix, extension string) (string, error) {
+	random := make([]byte, 16)
+	if _, err := rand.Read(random); err != nil {
+		return "", err
+	}
+	return prefix + "/" + hex.EncodeToString(random) + extension, nil
+}
+
+// countingReader okunan bayt sayısını tutar
+type countingReader struct {
+	reader io.Reader
+	count  int64
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	r.count += int64(n)
+	return n, err
+}
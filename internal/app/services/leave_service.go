@@ -0,0 +1,475 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/UmutTKMN/go-backend/internal/app/model"
+	"github.com/UmutTKMN/go-backend/internal/pkg/database"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// LeaveService izin ve devamsızlık işlemleri için servis
+type LeaveService struct {
+	db *gorm.DB
+}
+
+// NewLeaveService yeni bir LeaveService örneği oluşturur
+func NewLeaveService() *LeaveService {
+	return &LeaveService{
+		db: database.DB,
+	}
+}
+
+// GetAllLeaveTypes tüm izin türlerini getirir
+func (s *LeaveService) GetAllLeaveTypes() ([]model.LeaveType, error) {
+	var leaveTypes []model.LeaveType
+	result := s.db.Order("name").Find(&leaveTypes)
+	return leaveTypes, result.Error
+}
+
+// CreateLeaveType yeni bir izin türü oluşturur
+func (s *LeaveService) CreateLeaveType(leaveType *model.LeaveType) error {
+	if leaveType.YearlyAllowance < 0 {
+		return errors.New("yıllık izin hakkı negatif olamaz")
+	}
+	return s.db.Create(leaveType).Error
+}
+
+// UpdateLeaveType bir izin türünü günceller
+func (s *LeaveService) UpdateLeaveType(leaveType *model.LeaveType) error {
+	if err := s.db.First(&model.LeaveType{}, leaveType.ID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("izin türü bulunamadı")
+		}
+		return err
+	}
+
+	if leaveType.YearlyAllowance < 0 {
+		return errors.New("yıllık izin hakkı negatif olamaz")
+	}
+
+	return s.db.Save(leaveType).Error
+}
+
+// DeleteLeaveType bir izin türünü siler
+func (s *LeaveService) DeleteLeaveType(id uint) error {
+	if err := s.db.First(&model.LeaveType{}, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("izin türü bulunamadı")
+		}
+		return err
+	}
+
+	// İzin türü kullanılıyor mu kontrol et
+	var count int64
+	if err := s.db.Model(&model.LeaveRequest{}).Where("leave_type_id = ?", id).Count(&count).Error; err != nil {
+		return err
+	}
+
+	if count > 0 {
+		return errors.New("bu izin türü için talepler bulunduğundan silinemez")
+	}
+
+	return s.db.Delete(&model.LeaveType{}, id).Error
+}
+
+// GetLeaveBalances personelin belirtilen yıldaki tüm izin bakiyelerini getirir.
+// Henüz oluşturulmamış bakiyeler izin türünün yıllık hakkıyla oluşturulur.
+func (s *LeaveService) GetLeaveBalances(staffID uint, year int) ([]model.LeaveBalance, error) {
+	var leaveTypes []model.LeaveType
+	if err := s.db.Find(&leaveTypes).Error; err != nil {
+		return nil, err
+	}
+
+	balances := make([]model.LeaveBalance, 0, len(leaveTypes))
+	for _, leaveType := range leaveTypes {
+		balance, err := s.ensureBalance(s.db, staffID, &leaveType, year)
+		if err != nil {
+			return nil, err
+		}
+		balance.LeaveType = &leaveType
+		balances = append(balances, *balance)
+	}
+
+	return balances, nil
+}
+
+// GetLeaveRequestByID ID'ye göre izin talebi getirir
+func (s *LeaveService) GetLeaveRequestByID(id uint) (*model.LeaveRequest, error) {
+	var request model.LeaveRequest
+	result := s.db.Preload("Staff").Preload("LeaveType").Preload("Approver").First(&request, id)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, errors.New("izin talebi bulunamadı")
+		}
+		return nil, result.Error
+	}
+	return &request, nil
+}
+
+// GetLeaveRequestsByStaff personelin izin taleplerini getirir
+func (s *LeaveService) GetLeaveRequestsByStaff(staffID uint) ([]model.LeaveRequest, error) {
+	var requests []model.LeaveRequest
+	result := s.db.Preload("LeaveType").Preload("Approver").
+		Where("staff_id = ?", staffID).
+		Order("start_date DESC").
+		Find(&requests)
+	return requests, result.Error
+}
+
+// GetPendingApprovals yöneticinin onayını bekleyen izin taleplerini getirir.
+// Yöneticiye yetki devredilmişse devredenin ekibinin talepleri de listelenir; personelin kendi talepleri listelenmez.
+func (s *LeaveService) GetPendingApprovals(managerStaffID uint) ([]model.LeaveRequest, error) {
+	approverIDs, err := s.delegations().EffectiveApproverIDs(managerStaffID, model.DelegationScopeLeave, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	var requests []model.LeaveRequest
+	result := s.db.Preload("Staff.User").Preload("LeaveType").
+		Joins("JOIN staffs ON staffs.staff_id = leave_requests.staff_id").
+		Where("staffs.manager_id IN ? AND leave_requests.status = ?", approverIDs, model.LeaveStatusPending).
+		Where("leave_requests.staff_id <> ?", managerStaffID).
+		Order("leave_requests.start_date").
+		Find(&requests)
+	return requests, result.Error
+}
+
+// CreateLeaveRequest yeni bir izin talebi oluşturur.
+// Onay gerektirmeyen izin türlerinde talep doğrudan onaylanır.
+func (s *LeaveService) CreateLeaveRequest(request *model.LeaveRequest) error {
+	start := truncateDay(request.StartDate)
+	end := truncateDay(request.EndDate)
+	if end.Before(start) {
+		return errors.New("bitiş tarihi başlangıç tarihinden önce olamaz")
+	}
+	if start.Year() != end.Year() {
+		return errors.New("izin talebi yıl sınırını aşamaz, lütfen iki ayrı talep oluşturun")
+	}
+
+	days := countWorkingDays(start, end)
+	if days == 0 {
+		return errors.New("seçilen aralıkta iş günü bulunmuyor")
+	}
+
+	var staff model.Staff
+	if err := s.db.First(&staff, request.StaffID).Error; err != nil {
+		return errors.New("personel bulunamadı")
+	}
+
+	var leaveType model.LeaveType
+	if err := s.db.First(&leaveType, request.LeaveTypeID).Error; err != nil {
+		return errors.New("izin türü bulunamadı")
+	}
+
+	// Çakışan talep var mı kontrol et
+	var overlapping int64
+	if err := s.db.Model(&model.LeaveRequest{}).
+		Where("staff_id = ? AND status IN ? AND start_date <= ? AND end_date >= ?",
+			request.StaffID, []string{model.LeaveStatusPending, model.LeaveStatusApproved}, end, start).
+		Count(&overlapping).Error; err != nil {
+		return err
+	}
+	if overlapping > 0 {
+		return errors.New("bu tarihlerle çakışan bir izin talebiniz zaten var")
+	}
+
+	balance, err := s.ensureBalance(s.db, request.StaffID, &leaveType, start.Year())
+	if err != nil {
+		return err
+	}
+	if leaveType.YearlyAllowance > 0 && balance.Remaining < days {
+		return errors.New("yetersiz izin bakiyesi")
+	}
+
+	request.StartDate = start
+	request.EndDate = end
+	request.Days = days
+	request.Status = model.LeaveStatusPending
+	request.ApproverStaffID = nil
+	request.DecidedAt = nil
+
+	return runInTransaction(s.db, func(tx *gorm.DB) error {
+		if err := tx.Create(request).Error; err != nil {
+			return err
+		}
+
+		if leaveType.RequiresApproval {
+			return nil
+		}
+
+		return s.approve(tx, request, nil, "")
+	})
+}
+
+// ApproveLeaveRequest bir izin talebini onaylar.
+// Talebi personelin yöneticisi veya yöneticinin yetki devrettiği personel onaylayabilir.
+func (s *LeaveService) ApproveLeaveRequest(requestID, approverStaffID uint, notes string) (*model.LeaveRequest, error) {
+	err := runInTransaction(s.db, func(tx *gorm.DB) error {
+		request, err := s.getDecidableRequest(tx, requestID, approverStaffID)
+		if err != nil {
+			return err
+		}
+		return s.approve(tx, request, &approverStaffID, notes)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetLeaveRequestByID(requestID)
+}
+
+// RejectLeaveRequest bir izin talebini reddeder
+func (s *LeaveService) RejectLeaveRequest(requestID, approverStaffID uint, notes string) (*model.LeaveRequest, error) {
+	err := runInTransaction(s.db, func(tx *gorm.DB) error {
+		request, err := s.getDecidableRequest(tx, requestID, approverStaffID)
+		if err != nil {
+			return err
+		}
+
+		return tx.Model(request).Updates(map[string]interface{}{
+			"status":            model.LeaveStatusRejected,
+			"approver_staff_id": approverStaffID,
+			"decided_at":        time.Now(),
+			"decision_notes":    notes,
+		}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetLeaveRequestByID(requestID)
+}
+
+// CancelLeaveRequest personelin kendi izin talebini iptal etmesini sağlar.
+// Onaylanmış ve henüz başlamamış izinlerde kullanılan gün bakiyeye iade edilir.
+func (s *LeaveService) CancelLeaveRequest(requestID, staffID uint) error {
+	var request model.LeaveRequest
+	if err := s.db.First(&request, requestID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("izin talebi bulunamadı")
+		}
+		return err
+	}
+
+	if request.StaffID != staffID {
+		return errors.New("yalnızca kendi izin talebinizi iptal edebilirsiniz")
+	}
+
+	switch request.Status {
+	case model.LeaveStatusPending:
+	case model.LeaveStatusApproved:
+		if !truncateDay(time.Now()).Before(request.StartDate) {
+			return errors.New("başlamış bir izin iptal edilemez")
+		}
+	default:
+		return errors.New("bu izin talebi iptal edilemez")
+	}
+
+	return runInTransaction(s.db, func(tx *gorm.DB) error {
+		if request.Status == model.LeaveStatusApproved {
+			if err := tx.Model(&model.LeaveBalance{}).
+				Where("staff_id = ? AND leave_type_id = ? AND year = ?", request.StaffID, request.LeaveTypeID, request.StartDate.Year()).
+				Update("used", gorm.Expr("used - ?", request.Days)).Error; err != nil {
+				return err
+			}
+		}
+
+		return tx.Model(&request).Update("status", model.LeaveStatusCancelled).Error
+	})
+}
+
+// GetDepartmentCalendar departmandaki personelin belirtilen aralıktaki izinlerini getirir
+func (s *LeaveService) GetDepartmentCalendar(department string, from, to time.Time) ([]model.LeaveRequest, error) {
+	var requests []model.LeaveRequest
+	result := s.db.Preload("Staff.User").Preload("LeaveType").
+		Joins("JOIN staffs ON staffs.staff_id = leave_requests.staff_id").
+		Where("staffs.department = ?", department).
+		Where("leave_requests.status IN ?", []string{model.LeaveStatusPending, model.LeaveStatusApproved}).
+		Where("leave_requests.start_date <= ? AND leave_requests.end_date >= ?", truncateDay(to), truncateDay(from)).
+		Order("leave_requests.start_date").
+		Find(&requests)
+	return requests, result.Error
+}
+
+// GetUnavailableStaffIDs belirtilen aralıkta onaylı izinde olan personelin ID'lerini getirir.
+// Vardiya planlaması personelin müsaitliğini bu metot üzerinden belirler.
+func (s *LeaveService) GetUnavailableStaffIDs(from, to time.Time) ([]uint, error) {
+	var staffIDs []uint
+	result := s.db.Model(&model.LeaveRequest{}).
+		Where("status = ? AND start_date <= ? AND end_date >= ?", model.LeaveStatusApproved, truncateDay(to), truncateDay(from)).
+		Distinct().
+		Pluck("staff_id", &staffIDs)
+	return staffIDs, result.Error
+}
+
+// IsStaffOnLeave personelin verilen anda onaylı izinde olup olmadığını kontrol eder
+func (s *LeaveService) IsStaffOnLeave(staffID uint, at time.Time) (bool, error) {
+	day := truncateDay(at)
+	var count int64
+	err := s.db.Model(&model.LeaveRequest{}).
+		Where("staff_id = ? AND status = ? AND start_date <= ? AND end_date >= ?", staffID, model.LeaveStatusApproved, day, day).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// SyncLeaveStatuses onaylı izin dönemindeki aktif personeli "on_leave", izni biten personeli tekrar
+// "active" durumuna getirir. Yalnızca bu eşitlemenin on_leave yaptığı kayıtlar geri alınır; elle veya içe
+// aktarma ile verilen durumlar korunur. Zamanlayıcı tarafından periyodik olarak çalıştırılır.
+func (s *LeaveService) SyncLeaveStatuses(ctx context.Context) error {
+	now := time.Now()
+	onLeave, err := s.GetUnavailableStaffIDs(now, now)
+	if err != nil {
+		return err
+	}
+
+	return runInTransaction(s.db.WithContext(ctx), func(tx *gorm.DB) error {
+		if len(onLeave) > 0 {
+			if err := markStaffOnLeave(tx, onLeave); err != nil {
+				return err
+			}
+		}
+
+		returning := tx.Model(&model.Staff{}).
+			Where("employee_status = ? AND on_leave_by_sync = ?", model.EmployeeStatusOnLeave, true)
+		if len(onLeave) > 0 {
+			returning = returning.Where("staff_id NOT IN ?", onLeave)
+		}
+		return returning.Updates(map[string]interface{}{
+			"employee_status":  model.EmployeeStatusActive,
+			"on_leave_by_sync": false,
+		}).Error
+	})
+}
+
+// markStaffOnLeave verilen personelden aktif olanları on_leave durumuna getirir ve durumun
+// izin eşitlemesi tarafından değiştirildiğini işaretler
+func markStaffOnLeave(tx *gorm.DB, staffIDs []uint) error {
+	return tx.Model(&model.Staff{}).
+		Where("staff_id IN ? AND employee_status = ?", staffIDs, model.EmployeeStatusActive).
+		Updates(map[string]interface{}{
+			"employee_status":  model.EmployeeStatusOnLeave,
+			"on_leave_by_sync": true,
+		}).Error
+}
+
+// getDecidableRequest onay veya ret için bekleyen talebi kilitleyerek getirir ve onaylayanın yetkisini doğrular.
+// Satır kilidi eşzamanlı onay ve retlerin aynı talebi iki kez karara bağlamasını engeller.
+func (s *LeaveService) getDecidableRequest(tx *gorm.DB, requestID, approverStaffID uint) (*model.LeaveRequest, error) {
+	var request model.LeaveRequest
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&request, requestID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("izin talebi bulunamadı")
+		}
+		return nil, err
+	}
+
+	var staff model.Staff
+	if err := tx.First(&staff, request.StaffID).Error; err != nil {
+		return nil, errors.New("personel bulunamadı")
+	}
+	request.Staff = &staff
+
+	if request.Status != model.LeaveStatusPending {
+		return nil, errors.New("yalnızca bekleyen izin talepleri karara bağlanabilir")
+	}
+
+	if request.StaffID == approverStaffID {
+		return nil, errors.New("kendi izin talebinizi karara bağlayamazsınız")
+	}
+	if request.Staff == nil || request.Staff.ManagerID == nil {
+		return nil, errors.New("bu izin talebini onaylama yetkiniz yok")
+	}
+
+	// Onaylayan yönetici değilse yöneticinin aktif yetki devri aranır
+	allowed, err := (&DelegationService{db: tx}).CanActFor(approverStaffID, *request.Staff.ManagerID, model.DelegationScopeLeave, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if !allowed {
+		return nil, errors.New("bu izin talebini onaylama yetkiniz yok")
+	}
+
+	return &request, nil
+}
+
+// approve talebi onaylar, bakiyeden düşer ve izin bugün başlıyorsa personel durumunu günceller
+func (s *LeaveService) approve(tx *gorm.DB, request *model.LeaveRequest, approverStaffID *uint, notes string) error {
+	var leaveType model.LeaveType
+	if err := tx.First(&leaveType, request.LeaveTypeID).Error; err != nil {
+		return errors.New("izin türü bulunamadı")
+	}
+
+	balance, err := s.ensureBalance(tx, request.StaffID, &leaveType, request.StartDate.Year())
+	if err != nil {
+		return err
+	}
+	if leaveType.YearlyAllowance > 0 && balance.Remaining < request.Days {
+		return errors.New("yetersiz izin bakiyesi")
+	}
+
+	if err := tx.Model(balance).Update("used", gorm.Expr("used + ?", request.Days)).Error; err != nil {
+		return err
+	}
+
+	now := time.Now()
+	if err := tx.Model(request).Updates(map[string]interface{}{
+		"status":            model.LeaveStatusApproved,
+		"approver_staff_id": approverStaffID,
+		"decided_at":        now,
+		"decision_notes":    notes,
+	}).Error; err != nil {
+		return err
+	}
+
+	if request.Covers(now) {
+		return markStaffOnLeave(tx, []uint{request.StaffID})
+	}
+
+	return nil
+}
+
+// delegations aynı veritabanı bağlantısını kullanan bir DelegationService döndürür
+func (s *LeaveService) delegations() *DelegationService {
+	return &DelegationService{db: s.db}
+}
+
+// ensureBalance personelin yıllık bakiyesini getirir, yoksa izin türünün hakkıyla oluşturur
+func (s *LeaveService) ensureBalance(tx *gorm.DB, staffID uint, leaveType *model.LeaveType, year int) (*model.LeaveBalance, error) {
+	balance := model.LeaveBalance{
+		StaffID:     staffID,
+		LeaveTypeID: leaveType.ID,
+		Year:        year,
+	}
+
+	err := tx.Where(&balance).
+		Attrs(model.LeaveBalance{Allowance: leaveType.YearlyAllowance}).
+		FirstOrCreate(&balance).Error
+	if err != nil {
+		return nil, err
+	}
+
+	balance.Remaining = balance.Allowance - balance.Used
+	return &balance, nil
+}
+
+// countWorkingDays iki tarih arasındaki (dahil) hafta içi gün sayısını hesaplar
+func countWorkingDays(start, end time.Time) float64 {
+	var days float64
+	for day := start; !day.After(end); day = day.AddDate(0, 0, 1) {
+		if day.Weekday() != time.Saturday && day.Weekday() != time.Sunday {
+			days++
+		}
+	}
+	return days
+}
+
+// truncateDay t'nin kendi saat dilimindeki takvim gününü UTC gece yarısı olarak döndürür.
+// YYYY-MM-DD olarak alınan tarihler UTC gece yarısıdır; time.Now() ise sunucunun yerel günü olarak
+// aynı biçime getirilir, böylece tarih karşılaştırmaları saat diliminden bağımsız olur.
+func truncateDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
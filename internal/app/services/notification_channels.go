@@ -0,0 +1,127 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/UmutTKMN/go-backend/internal/app/model"
+	"github.com/UmutTKMN/go-backend/internal/pkg/database"
+	"github.com/UmutTKMN/go-backend/internal/pkg/messaging"
+	"gorm.io/gorm"
+)
+
+// EmailChannel bildirimleri kullanıcının e-posta adresine gönderir
+type EmailChannel struct {
+	mailer messaging.Mailer
+}
+
+// NewEmailChannel yeni bir EmailChannel oluşturur
+func NewEmailChannel(mailer messaging.Mailer) *EmailChannel {
+	return &EmailChannel{mailer: mailer}
+}
+
+// Name kanal adını döndürür
+func (c *EmailChannel) Name() string {
+	return model.NotificationChannelEmail
+}
+
+// Deliver bildirimi e-posta olarak gönderir
+func (c *EmailChannel) Deliver(ctx context.Context, user *model.User, communication *model.UserCommunication) error {
+	return c.mailer.Send(ctx, messaging.Email{
+		To:      user.Email,
+		Subject: communication.Subject,
+		Text:    communication.Content,
+		HTML:    communication.ContentHTML,
+	})
+}
+
+// SMSChannel bildirimleri kullanıcının doğrulanmış telefon numarasına kısa mesaj olarak gönderir
+type SMSChannel struct {
+	sender messaging.SMSSender
+}
+
+// NewSMSChannel yeni bir SMSChannel oluşturur
+func NewSMSChannel(sender messaging.SMSSender) *SMSChannel {
+	return &SMSChannel{sender: sender}
+}
+
+// Name kanal adını döndürür
+func (c *SMSChannel) Name() string {
+	return model.NotificationChannelSMS
+}
+
+// Deliver bildirimi kısa mesaj olarak gönderir; doğrulanmamış numaralara gönderim yapılmaz
+func (c *SMSChannel) Deliver(ctx context.Context, user *model.User, communication *model.UserCommunication) error {
+	if user.PhoneNumber == "" || user.PhoneVerifiedAt == nil {
+		return messaging.Permanent(errors.New("doğrulanmış telefon numarası yok"))
+	}
+	return c.sender.SendSMS(ctx, user.PhoneNumber, fmt.Sprintf("%s: %s", communication.Subject, communication.Content))
+}
+
+// PushChannel bildirimleri kullanıcının kayıtlı cihazlarına (UserDevice.DeviceToken) gönderir
+type PushChannel struct {
+	db     *gorm.DB
+	sender messaging.PushSender
+}
+
+// NewPushChannel yeni bir PushChannel oluşturur
+func NewPushChannel(sender messaging.PushSender) *PushChannel {
+	return &PushChannel{db: database.DB, sender: sender}
+}
+
+// Name kanal adını döndürür
+func (c *PushChannel) Name() string {
+	return model.NotificationChannelPush
+}
+
+// Deliver bildirimi kullanıcının tüm cihazlarına gönderir. En az bir cihaza ulaşılırsa teslimat
+// başarılı sayılır; geçersiz jetonlar cihaz kaydından silinir.
+func (c *PushChannel) Deliver(ctx context.Context, user *model.User, communication *model.UserCommunication) error {
+	var devices []model.UserDevice
+	if err := c.db.Where("user_id = ? AND device_token <> ''", user.ID).Find(&devices).Error; err != nil {
+		return err
+	}
+	if len(devices) == 0 {
+		return messaging.Permanent(errors.New("push bildirimi alabilecek cihaz yok"))
+	}
+
+	message := messaging.PushMessage{
+		Title: communication.Subject,
+		Body:  communication.Content,
+		Data: map[string]string{
+			"communication_id": fmt.Sprint(communication.ID),
+			"category":         communication.CommunicationType,
+		},
+	}
+
+	var errs []error
+	delivered := 0
+	for _, device := range devices {
+		err := c.sender.Push(ctx, device.DeviceToken, message)
+		switch {
+		case err == nil:
+			delivered++
+		case errors.Is(err, messaging.ErrUnregistered):
+			if err := c.db.Model(&device).Update("device_token", "").Error; err != nil {
+				log.Printf("Geçersiz cihaz jetonu silinemedi (cihaz %d): %v", device.ID, err)
+			}
+			errs = append(errs, err)
+		default:
+			errs = append(errs, err)
+		}
+	}
+
+	if delivered > 0 {
+		return nil
+	}
+
+	// Geçici bir hata varsa yeniden denenir; tüm hatalar kalıcıysa teslimat başarısız olur
+	for _, err := range errs {
+		if !messaging.IsPermanent(err) {
+			return errors.Join(errs...)
+		}
+	}
+	return messaging.Permanent(errors.Join(errs...))
+}
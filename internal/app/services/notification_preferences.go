@@ -0,0 +1,261 @@
+package services
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/UmutTKMN/go-backend/internal/app/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// notificationCategoryDefaults kullanıcı tercih belirtmediğinde kategori ve kanal bazında varsayılanlar.
+// Pazarlama bildirimleri yalnızca açık rıza ile gönderilir.
+var notificationCategoryDefaults = map[string]map[string]bool{
+	model.NotificationCategorySecurity: {
+		model.NotificationChannelEmail: true,
+		model.NotificationChannelSMS:   false,
+		model.NotificationChannelPush:  true,
+	},
+	model.NotificationCategoryRequests: {
+		model.NotificationChannelEmail: true,
+		model.NotificationChannelSMS:   false,
+		model.NotificationChannelPush:  true,
+	},
+	model.NotificationCategoryMarketing: {
+		model.NotificationChannelEmail: false,
+		model.NotificationChannelSMS:   false,
+		model.NotificationChannelPush:  false,
+	},
+	model.NotificationCategoryAnnouncements: {
+		model.NotificationChannelEmail: true,
+		model.NotificationChannelSMS:   false,
+		model.NotificationChannelPush:  true,
+	},
+}
+
+// quietHoursChannels sessiz saatlerde ertelenen kanallar; e-posta kullanıcıyı rahatsız etmediğinden ertelenmez
+var quietHoursChannels = map[string]bool{
+	model.NotificationChannelSMS:  true,
+	model.NotificationChannelPush: true,
+}
+
+const quietHoursLayout = "15:04"
+
+// NotificationSettings kullanıcının varsayılanlarla birleştirilmiş bildirim tercihleri.
+// Timezone sessiz saatlerin yorumlandığı saat dilimidir ve profil üzerinden değiştirilir.
+type NotificationSettings struct {
+	model.NotificationPreferences
+	Timezone string `json:"timezone"`
+}
+
+// GetPreferences kullanıcının geçerli bildirim tercihlerini getirir
+func (s *NotificationService) GetPreferences(userID uint) (*NotificationSettings, error) {
+	var user model.User
+	if err := s.db.First(&user, userID).Error; err != nil {
+		return nil, errors.New("kullanıcı bulunamadı")
+	}
+	return notificationSettings(&user), nil
+}
+
+// UpdatePreferences verilen kategori/kanal değerlerini kayıtlı tercihlerle birleştirir; quiet_hours verilirse
+// sessiz saatleri değiştirir. SMS kanalı yalnızca doğrulanmış telefon numarası olan kullanıcılar için açılabilir.
+func (s *NotificationService) UpdatePreferences(userID uint, update model.NotificationPreferences) (*NotificationSettings, error) {
+	var user model.User
+	err := runInTransaction(s.db, func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&user, userID).Error; err != nil {
+			return errors.New("kullanıcı bulunamadı")
+		}
+
+		stored := storedNotificationPreferences(&user)
+		for category, channels := range update.Categories {
+			if !model.IsValidNotificationCategory(category) {
+				return fmt.Errorf("geçersiz bildirim kategorisi: %s", category)
+			}
+			for channel, enabled := range channels {
+				if !model.IsValidNotificationPreferenceChannel(channel) {
+					return fmt.Errorf("geçersiz bildirim kanalı: %s", channel)
+				}
+				if channel == model.NotificationChannelSMS && enabled && user.PhoneVerifiedAt == nil {
+					return errors.New("SMS bildirimleri için doğrulanmış telefon numarası gereklidir")
+				}
+				if stored.Categories[category] == nil {
+					stored.Categories[category] = map[string]bool{}
+				}
+				stored.Categories[category][channel] = enabled
+			}
+		}
+
+		if update.QuietHours != nil {
+			if err := validateQuietHours(update.QuietHours); err != nil {
+				return err
+			}
+			stored.QuietHours = update.QuietHours
+		}
+
+		data, err := json.Marshal(stored)
+		if err != nil {
+			return err
+		}
+		user.NotificationPreferences = sql.NullString{String: string(data), Valid: true}
+		return tx.Model(&user).Update("notification_preferences", user.NotificationPreferences).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return notificationSettings(&user), nil
+}
+
+// validateQuietHours sessiz saat aralığını doğrular
+func validateQuietHours(quietHours *model.QuietHours) error {
+	if !quietHours.Enabled && quietHours.Start == "" && quietHours.End == "" {
+		return nil
+	}
+
+	start, err := time.Parse(quietHoursLayout, quietHours.Start)
+	if err != nil {
+		return errors.New("sessiz saat başlangıcı SS:DD biçiminde olmalıdır")
+	}
+	end, err := time.Parse(quietHoursLayout, quietHours.End)
+	if err != nil {
+		return errors.New("sessiz saat bitişi SS:DD biçiminde olmalıdır")
+	}
+	if start.Equal(end) {
+		return errors.New("sessiz saat başlangıcı ve bitişi aynı olamaz")
+	}
+	return nil
+}
+
+// storedNotificationPreferences kullanıcının yalnızca açıkça belirttiği tercihleri döndürür.
+// Okunamayan veya eski biçimdeki değerler yok sayılır.
+func storedNotificationPreferences(user *model.User) model.NotificationPreferences {
+	var preferences model.NotificationPreferences
+	if user.NotificationPreferences.Valid && user.NotificationPreferences.String != "" {
+		if err := json.Unmarshal([]byte(user.NotificationPreferences.String), &preferences); err != nil {
+			log.Printf("Bildirim tercihleri okunamadı (kullanıcı %d): %v", user.ID, err)
+			preferences = model.NotificationPreferences{}
+		}
+	}
+	if preferences.Categories == nil {
+		preferences.Categories = map[string]map[string]bool{}
+	}
+	return preferences
+}
+
+// notificationSettings kayıtlı tercihleri tüm kategori ve kanallar için varsayılanlarla tamamlar
+func notificationSettings(user *model.User) *NotificationSettings {
+	stored := storedNotificationPreferences(user)
+
+	settings := &NotificationSettings{
+		NotificationPreferences: model.NotificationPreferences{
+			Categories: make(map[string]map[string]bool, len(model.NotificationCategories)),
+			QuietHours: &model.QuietHours{Start: "22:00", End: "08:00"},
+		},
+		Timezone: user.Timezone,
+	}
+	for _, category := range model.NotificationCategories {
+		channels := make(map[string]bool, len(model.NotificationPreferenceChannels))
+		for _, channel := range model.NotificationPreferenceChannels {
+			enabled, ok := stored.Categories[category][channel]
+			if !ok {
+				enabled = notificationCategoryDefaults[category][channel]
+			}
+			channels[channel] = enabled
+		}
+		settings.Categories[category] = channels
+	}
+	if stored.QuietHours != nil {
+		settings.QuietHours = stored.QuietHours
+	}
+	return settings
+}
+
+// planDeliveries bildirim için kullanıcının tercihlerine göre açık olan kayıtlı kanallara teslimat kayıtları hazırlar.
+// Sessiz saatlerde SMS ve push teslimatları sessiz saatlerin bitişine ertelenir.
+// Zorunlu güvenlik bildirimleri kapatılmış kanallara da (varsayılan olarak açıksa) gönderilir ve ertelenmez.
+func planDeliveries(user *model.User, notification Notification, now time.Time) []model.NotificationDelivery {
+	settings := notificationSettings(user)
+	mandatory := notification.Mandatory && notification.Category == model.NotificationCategorySecurity
+
+	var deferUntil *time.Time
+	if !mandatory {
+		if until, ok := quietHoursEnd(settings.QuietHours, user.Timezone, now); ok {
+			deferUntil = &until
+		}
+	}
+
+	channelsMu.RLock()
+	defer channelsMu.RUnlock()
+
+	var deliveries []model.NotificationDelivery
+	for name := range notificationChannels {
+		enabled, ok := settings.Categories[notification.Category][name]
+		if !ok {
+			enabled = notificationChannelDefaults[name]
+		}
+		if mandatory && notificationCategoryDefaults[model.NotificationCategorySecurity][name] {
+			enabled = true
+		}
+		if !enabled {
+			continue
+		}
+
+		delivery := model.NotificationDelivery{
+			UserID:        user.ID,
+			Channel:       name,
+			Status:        model.DeliveryStatusPending,
+			NextAttemptAt: now,
+		}
+		if deferUntil != nil && quietHoursChannels[name] {
+			delivery.NextAttemptAt = *deferUntil
+		}
+		deliveries = append(deliveries, delivery)
+	}
+	return deliveries
+}
+
+// quietHoursEnd an sessiz saatler içindeyse sessiz saatlerin bitiş zamanını döndürür.
+// Saat dilimi okunamazsa UTC kullanılır.
+func quietHoursEnd(quietHours *model.QuietHours, timezone string, now time.Time) (time.Time, bool) {
+	if quietHours == nil || !quietHours.Enabled {
+		return time.Time{}, false
+	}
+	start, err := time.Parse(quietHoursLayout, quietHours.Start)
+	if err != nil {
+		return time.Time{}, false
+	}
+	end, err := time.Parse(quietHoursLayout, quietHours.End)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	location, err := time.LoadLocation(timezone)
+	if err != nil {
+		location = time.UTC
+	}
+	local := now.In(location)
+
+	minute := local.Hour()*60 + local.Minute()
+	startMinute := start.Hour()*60 + start.Minute()
+	endMinute := end.Hour()*60 + end.Minute()
+
+	var inside bool
+	if startMinute < endMinute {
+		inside = minute >= startMinute && minute < endMinute
+	} else {
+		inside = minute >= startMinute || minute < endMinute
+	}
+	if !inside {
+		return time.Time{}, false
+	}
+
+	until := time.Date(local.Year(), local.Month(), local.Day(), end.Hour(), end.Minute(), 0, 0, location)
+	if !until.After(local) {
+		until = time.Date(local.Year(), local.Month(), local.Day()+1, end.Hour(), end.Minute(), 0, 0, location)
+	}
+	return until, true
+}
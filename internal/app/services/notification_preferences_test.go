@@ -0,0 +1,48 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/UmutTKMN/go-backend/internal/app/model"
+)
+
+func TestQuietHoursEnd(t *testing.T) {
+	istanbul, err := time.LoadLocation("Europe/Istanbul")
+	if err != nil {
+		t.Skipf("saat dilimi yüklenemedi: %v", err)
+	}
+	night := &model.QuietHours{Enabled: true, Start: "22:00", End: "08:00"}
+	lunch := &model.QuietHours{Enabled: true, Start: "12:00", End: "13:30"}
+
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2026, 4, day, hour, minute, 0, 0, istanbul)
+	}
+
+	tests := []struct {
+		name       string
+		quietHours *model.QuietHours
+		timezone   string
+		now        time.Time
+		want       time.Time
+		inside     bool
+	}{
+		{"gece yarısından önce", night, "Europe/Istanbul", at(20, 23, 0), at(21, 8, 0), true},
+		{"gece yarısından sonra", night, "Europe/Istanbul", at(21, 3, 0), at(21, 8, 0), true},
+		{"bitiş anında sessiz değil", night, "Europe/Istanbul", at(21, 8, 0), time.Time{}, false},
+		{"gündüz", night, "Europe/Istanbul", at(21, 15, 0), time.Time{}, false},
+		{"aynı gün içindeki aralık", lunch, "Europe/Istanbul", at(21, 12, 15), at(21, 13, 30), true},
+		// 19:00 UTC İstanbul'da 22:00'dir
+		{"saat dilimine göre", night, "Europe/Istanbul", time.Date(2026, 4, 20, 19, 0, 0, 0, time.UTC), at(21, 8, 0), true},
+		{"geçersiz saat dilimi UTC kullanır", night, "Mars/Olympus", time.Date(2026, 4, 20, 19, 0, 0, 0, time.UTC), time.Time{}, false},
+		{"kapalı", &model.QuietHours{Start: "22:00", End: "08:00"}, "Europe/Istanbul", at(20, 23, 0), time.Time{}, false},
+		{"tanımsız", nil, "Europe/Istanbul", at(20, 23, 0), time.Time{}, false},
+		{"geçersiz saat", &model.QuietHours{Enabled: true, Start: "25:00", End: "08:00"}, "Europe/Istanbul", at(20, 23, 0), time.Time{}, false},
+	}
+	for _, tt := range tests {
+		got, inside := quietHoursEnd(tt.quietHours, tt.timezone, tt.now)
+		if inside != tt.inside || !got.Equal(tt.want) {
+			t.Errorf("%s: quietHoursEnd = %s, %v; beklenen %s, %v", tt.name, got, inside, tt.want, tt.inside)
+		}
+	}
+}
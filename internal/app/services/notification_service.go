@@ -0,0 +1,510 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/UmutTKMN/go-backend/internal/app/model"
+	"github.com/UmutTKMN/go-backend/internal/pkg/database"
+	"github.com/UmutTKMN/go-backend/internal/pkg/listquery"
+	"github.com/UmutTKMN/go-backend/internal/pkg/messaging"
+	"gorm.io/gorm"
+)
+
+// Bildirim teslimat kuyruğu ayarları
+const (
+	MaxDeliveryAttempts         = 6
+	notificationDeliveryTimeout = 30 * time.Second
+	deliveryBatchSize           = 50
+	deliveryLease               = 5 * time.Minute // Alınan kaydın başka bir örnek tarafından alınmaması için süre
+	maxDeliveryBackoff          = time.Hour
+	notificationInsertBatchSize = 200
+)
+
+// Notification diğer alt sistemlerin kullanıcıya gönderdiği bildirimi tanımlar.
+// Template verilirse konu ve içerik alıcının dilinde şablondan üretilir; Subject ve Content yok sayılır.
+// Mandatory yalnızca güvenlik kategorisinde geçerlidir; kullanıcının kanal tercihleri ve sessiz saatleri atlanır.
+type Notification struct {
+	Category   string
+	Subject    string
+	Content    string
+	Template   string
+	Data       map[string]interface{}
+	Importance string
+	SenderID   uint
+	Mandatory  bool
+
+	// AnnouncementID bildirim bir toplu duyurudan üretildiyse duyurunun ID'si
+	AnnouncementID *uint
+}
+
+// NotificationChannel bildirimleri uygulama içi gelen kutusu dışına (e-posta, SMS, push) ileten kanal.
+// Yeniden denenmesi anlamsız hatalar messaging.Permanent ile işaretlenmelidir.
+type NotificationChannel interface {
+	Name() string
+	Deliver(ctx context.Context, user *model.User, communication *model.UserCommunication) error
+}
+
+var (
+	channelsMu           sync.RWMutex
+	notificationChannels = map[string]NotificationChannel{}
+)
+
+// RegisterNotificationChannel kanalı bildirim dağıtımına ekler; uygulama başlarken çağrılır
+func RegisterNotificationChannel(channel NotificationChannel) {
+	channelsMu.Lock()
+	defer channelsMu.Unlock()
+	notificationChannels[channel.Name()] = channel
+}
+
+// notificationChannelDefaults tanımlı bir kategoriye ait olmayan bildirimlerde kanalların açık olup olmadığı
+var notificationChannelDefaults = map[string]bool{
+	model.NotificationChannelEmail: true,
+	model.NotificationChannelSMS:   false,
+	model.NotificationChannelPush:  true,
+}
+
+// InboxSpec bildirim gelen kutusunda izin verilen sıralama alanları ve sayfa sınırları
+var InboxSpec = listquery.Spec{
+	SortFields: map[string]listquery.SortField{
+		"id":      {Column: "id", Kind: listquery.KindInt},
+		"sent_at": {Column: "sent_at", Kind: listquery.KindTime},
+	},
+	DefaultSort:  "sent_at",
+	DefaultDesc:  true,
+	IDColumn:     "id",
+	DefaultLimit: 20,
+	MaxLimit:     100,
+}
+
+// InboxFilter gelen kutusu filtreleri
+type InboxFilter struct {
+	Category   string
+	Importance string
+	UnreadOnly bool
+}
+
+// UnreadCounts okunmamış bildirim sayıları
+type UnreadCounts struct {
+	Total      int64            `json:"total"`
+	ByCategory map[string]int64 `json:"by_category"`
+}
+
+// NotificationService kullanıcı bildirimleri için servis
+type NotificationService struct {
+	db        *gorm.DB
+	templates *TemplateService
+}
+
+// NewNotificationService yeni bir NotificationService örneği oluşturur
+func NewNotificationService() *NotificationService {
+	return &NotificationService{
+		db:        database.DB,
+		templates: NewTemplateService(),
+	}
+}
+
+// Notify bildirimi kullanıcının gelen kutusuna UserCommunication kaydı olarak ekler ve bildirim tercihlerinde
+// kategori için açık olan diğer kanallara teslimat kuyruğuna kayıt oluşturur. Kanallara iletim ProcessDeliveries ile yapılır.
+func (s *NotificationService) Notify(userID uint, notification Notification) (*model.UserCommunication, error) {
+	if notification.Importance == "" {
+		notification.Importance = model.ImportanceNormal
+	}
+
+	now := time.Now()
+	var deliveries []model.NotificationDelivery
+	language := DefaultTemplateLanguage
+	var user model.User
+	if err := s.db.First(&user, userID).Error; err == nil {
+		deliveries = planDeliveries(&user, notification, now)
+		language = user.PreferredLanguage
+	} else {
+		log.Printf("Bildirim kanalları için kullanıcı bulunamadı (kullanıcı %d): %v", userID, err)
+	}
+
+	message, err := s.renderNotification(notification, language)
+	if err != nil {
+		return nil, err
+	}
+	communication := newCommunication(userID, notification, message, now)
+	if len(deliveries) > 0 {
+		communication.DeliveryStatus = model.DeliveryStatusPending
+	}
+
+	err = runInTransaction(s.db, func(tx *gorm.DB) error {
+		if err := tx.Create(communication).Error; err != nil {
+			return err
+		}
+
+		for i := range deliveries {
+			deliveries[i].CommunicationID = communication.ID
+			if err := tx.Create(&deliveries[i]).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	publishEvent(userID, EventNotificationCreated, communication)
+	return communication, nil
+}
+
+// notifyUsers bildirimi verilen kullanıcılara tx içinde toplu olarak ekler ve oluşturulan kayıtları döndürür.
+// Şablon her dil için bir kez işlenir; toplu duyurular gibi çok sayıda alıcılı gönderimlerde kullanılır.
+// Anlık olaylar işlem tamamlandıktan sonra çağıran tarafından publishNotifications ile yayınlanmalıdır.
+func (s *NotificationService) notifyUsers(tx *gorm.DB, users []model.User, notification Notification) ([]model.UserCommunication, error) {
+	if len(users) == 0 {
+		return nil, nil
+	}
+	if notification.Importance == "" {
+		notification.Importance = model.ImportanceNormal
+	}
+
+	now := time.Now()
+	messages := map[string]*RenderedMessage{}
+	communications := make([]model.UserCommunication, len(users))
+	plans := make([][]model.NotificationDelivery, len(users))
+	for i := range users {
+		language := users[i].PreferredLanguage
+		message, ok := messages[language]
+		if !ok {
+			var err error
+			if message, err = s.renderNotification(notification, language); err != nil {
+				return nil, err
+			}
+			messages[language] = message
+		}
+
+		communications[i] = *newCommunication(users[i].ID, notification, message, now)
+		plans[i] = planDeliveries(&users[i], notification, now)
+		if len(plans[i]) > 0 {
+			communications[i].DeliveryStatus = model.DeliveryStatusPending
+		}
+	}
+	if err := tx.CreateInBatches(communications, notificationInsertBatchSize).Error; err != nil {
+		return nil, err
+	}
+
+	var deliveries []model.NotificationDelivery
+	for i := range plans {
+		for _, delivery := range plans[i] {
+			delivery.CommunicationID = communications[i].ID
+			deliveries = append(deliveries, delivery)
+		}
+	}
+	if len(deliveries) > 0 {
+		if err := tx.CreateInBatches(deliveries, notificationInsertBatchSize).Error; err != nil {
+			return nil, err
+		}
+	}
+	return communications, nil
+}
+
+// publishNotifications oluşturulan bildirimleri alıcılarının bağlı istemcilerine yayınlar
+func publishNotifications(communications []model.UserCommunication) {
+	for i := range communications {
+		publishEvent(communications[i].UserID, EventNotificationCreated, &communications[i])
+	}
+}
+
+// renderNotification şablonlu bildirimi verilen dilde işler; şablonsuz bildirimlerde nil döner
+func (s *NotificationService) renderNotification(notification Notification, language string) (*RenderedMessage, error) {
+	if notification.Template == "" {
+		return nil, nil
+	}
+	return s.templates.Render(notification.Template, language, notification.Data)
+}
+
+// newCommunication bildirimden gelen kutusu kaydı oluşturur; message verilirse konu ve içerik ondan alınır
+func newCommunication(userID uint, notification Notification, message *RenderedMessage, now time.Time) *model.UserCommunication {
+	communication := &model.UserCommunication{
+		UserID:            userID,
+		CommunicationType: notification.Category,
+		SentAt:            now,
+		DeliveryStatus:    model.DeliveryStatusDelivered,
+		Subject:           notification.Subject,
+		Content:           notification.Content,
+		SenderID:          notification.SenderID,
+		Importance:        notification.Importance,
+		AnnouncementID:    notification.AnnouncementID,
+	}
+	if message != nil {
+		communication.Subject = message.Subject
+		communication.Content = message.Text
+		communication.ContentHTML = message.HTML
+	}
+	return communication
+}
+
+// ProcessDeliveries zamanı gelmiş teslimat kayıtlarını ilgili kanallara iletir. Başarısız teslimatlar
+// üstel bekleme ile yeniden denenir; kalıcı hatalarda veya deneme sınırında başarısız olarak işaretlenir.
+// Kayıtlar SKIP LOCKED ile alındığından birden fazla uygulama örneği aynı anda çalışabilir.
+func (s *NotificationService) ProcessDeliveries(ctx context.Context) error {
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		deliveries, err := s.claimDeliveries(ctx)
+		if err != nil {
+			return err
+		}
+		if len(deliveries) == 0 {
+			return nil
+		}
+
+		for i := range deliveries {
+			if err := s.attemptDelivery(ctx, &deliveries[i]); err != nil {
+				log.Printf("Teslimat kaydı güncellenemedi (teslimat %d): %v", deliveries[i].ID, err)
+			}
+		}
+	}
+}
+
+// claimDeliveries zamanı gelmiş teslimatları alır ve diğer örneklerin almaması için ileri bir zamana erteler
+func (s *NotificationService) claimDeliveries(ctx context.Context) ([]model.NotificationDelivery, error) {
+	var deliveries []model.NotificationDelivery
+	now := time.Now()
+	err := s.db.WithContext(ctx).Raw(`
+		UPDATE notification_deliveries SET next_attempt_at = ?, updated_at = ?
+		WHERE id IN (
+			SELECT id FROM notification_deliveries
+			WHERE status = ? AND next_attempt_at <= ?
+			ORDER BY next_attempt_at
+			LIMIT ?
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING *
+	`, now.Add(deliveryLease), now, model.DeliveryStatusPending, now, deliveryBatchSize).Scan(&deliveries).Error
+	return deliveries, err
+}
+
+// attemptDelivery teslimatı bir kez dener ve sonucu kaydeder
+func (s *NotificationService) attemptDelivery(ctx context.Context, delivery *model.NotificationDelivery) error {
+	deliverErr := s.deliver(ctx, delivery)
+
+	now := time.Now()
+	attempts := delivery.Attempts + 1
+	updates := map[string]interface{}{
+		"attempts":   attempts,
+		"last_error": "",
+	}
+
+	switch {
+	case deliverErr == nil:
+		updates["status"] = model.DeliveryStatusDelivered
+		updates["delivered_at"] = now
+	case messaging.IsPermanent(deliverErr) || attempts >= MaxDeliveryAttempts:
+		updates["status"] = model.DeliveryStatusFailed
+		updates["last_error"] = deliverErr.Error()
+	default:
+		updates["next_attempt_at"] = now.Add(deliveryBackoff(attempts))
+		updates["last_error"] = deliverErr.Error()
+	}
+
+	if err := s.db.Model(&model.NotificationDelivery{}).Where("id = ?", delivery.ID).Updates(updates).Error; err != nil {
+		return err
+	}
+	return s.refreshDeliveryStatus(delivery.CommunicationID)
+}
+
+// deliver teslimatın bildirimini ve kullanıcısını yükleyerek kanala iletir
+func (s *NotificationService) deliver(ctx context.Context, delivery *model.NotificationDelivery) error {
+	channelsMu.RLock()
+	channel, ok := notificationChannels[delivery.Channel]
+	channelsMu.RUnlock()
+	if !ok {
+		return messaging.Permanent(fmt.Errorf("%s kanalı yapılandırılmamış", delivery.Channel))
+	}
+
+	var communication model.UserCommunication
+	if err := s.db.Preload("User").First(&communication, delivery.CommunicationID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return messaging.Permanent(errors.New("bildirim bulunamadı"))
+		}
+		return err
+	}
+	if communication.User == nil {
+		return messaging.Permanent(errors.New("kullanıcı bulunamadı"))
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, notificationDeliveryTimeout)
+	defer cancel()
+	return channel.Deliver(ctx, communication.User, &communication)
+}
+
+// refreshDeliveryStatus bildirimin genel teslim durumunu kanal teslimatlarından hesaplar:
+// bekleyen teslimat varsa pending, tümü başarısızsa failed, aksi halde delivered
+func (s *NotificationService) refreshDeliveryStatus(communicationID uint) error {
+	var rows []struct {
+		Status string
+		Count  int64
+	}
+	err := s.db.Model(&model.NotificationDelivery{}).
+		Select("status, COUNT(*) AS count").
+		Where("communication_id = ?", communicationID).
+		Group("status").
+		Scan(&rows).Error
+	if err != nil {
+		return err
+	}
+
+	counts := map[string]int64{}
+	var total int64
+	for _, row := range rows {
+		counts[row.Status] = row.Count
+		total += row.Count
+	}
+
+	status := model.DeliveryStatusDelivered
+	switch {
+	case counts[model.DeliveryStatusPending] > 0:
+		status = model.DeliveryStatusPending
+	case total > 0 && counts[model.DeliveryStatusFailed] == total:
+		status = model.DeliveryStatusFailed
+	}
+
+	return s.db.Model(&model.UserCommunication{}).Where("id = ?", communicationID).Update("delivery_status", status).Error
+}
+
+// deliveryBackoff n. başarısız denemeden sonra beklenecek süreyi döndürür (1, 2, 4, ... dakika; en fazla 1 saat)
+func deliveryBackoff(attempts int) time.Duration {
+	backoff := time.Minute << (attempts - 1)
+	if backoff <= 0 || backoff > maxDeliveryBackoff {
+		return maxDeliveryBackoff
+	}
+	return backoff
+}
+
+// NotifyRoles bildirimi belirtilen rollerden birine sahip tüm kullanıcılara gönderir
+func (s *NotificationService) NotifyRoles(notification Notification, roleNames ...string) error {
+	var userIDs []uint
+	err := s.db.Raw(`
+		SELECT DISTINCT ur.user_id FROM user_roles ur
+		JOIN roles r ON ur.role_id = r.role_id
+		JOIN users u ON ur.user_id = u.id
+		WHERE r.role_name IN ? AND r.deleted_at IS NULL AND u.deleted_at IS NULL
+	`, roleNames).Scan(&userIDs).Error
+	if err != nil {
+		return err
+	}
+
+	for _, userID := range userIDs {
+		if _, err := s.Notify(userID, notification); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetInbox kullanıcının bildirimlerini filtreleyerek sayfalı olarak getirir
+func (s *NotificationService) GetInbox(userID uint, filter InboxFilter, query *listquery.Query) (*listquery.Page[model.UserCommunication], error) {
+	db := s.db.Model(&model.UserCommunication{}).Where("user_id = ?", userID)
+
+	if filter.Category != "" {
+		db = db.Where("communication_type = ?", filter.Category)
+	}
+	if filter.Importance != "" {
+		db = db.Where("importance = ?", filter.Importance)
+	}
+	if filter.UnreadOnly {
+		db = db.Where("read_at IS NULL")
+	}
+	if query.Search != "" {
+		pattern := "%" + escapeLike(query.Search) + "%"
+		db = db.Where("(subject ILIKE ? OR content ILIKE ?)", pattern, pattern)
+	}
+
+	return listquery.Paginate(db, query, InboxSpec, func(communication *model.UserCommunication) (interface{}, uint) {
+		if query.SortKey == "sent_at" {
+			return communication.SentAt, communication.ID
+		}
+		return communication.ID, communication.ID
+	})
+}
+
+// GetUnreadCounts kullanıcının okunmamış bildirim sayılarını kategori bazında getirir
+func (s *NotificationService) GetUnreadCounts(userID uint) (*UnreadCounts, error) {
+	var rows []struct {
+		CommunicationType string
+		Count             int64
+	}
+	err := s.db.Model(&model.UserCommunication{}).
+		Select("communication_type, COUNT(*) AS count").
+		Where("user_id = ? AND read_at IS NULL", userID).
+		Group("communication_type").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	counts := &UnreadCounts{ByCategory: make(map[string]int64, len(rows))}
+	for _, row := range rows {
+		counts.ByCategory[row.CommunicationType] = row.Count
+		counts.Total += row.Count
+	}
+	return counts, nil
+}
+
+// GetNotification kullanıcının bildirimini getirir; başka kullanıcının bildirimi bulunamadı olarak döner
+func (s *NotificationService) GetNotification(userID, id uint) (*model.UserCommunication, error) {
+	var communication model.UserCommunication
+	if err := s.db.Preload("Deliveries").Where("id = ? AND user_id = ?", id, userID).First(&communication).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("bildirim bulunamadı")
+		}
+		return nil, err
+	}
+	return &communication, nil
+}
+
+// MarkRead bildirimi okundu olarak işaretler; zaten okunmuşsa okunma zamanı değişmez
+func (s *NotificationService) MarkRead(userID, id uint) (*model.UserCommunication, error) {
+	communication, err := s.GetNotification(userID, id)
+	if err != nil {
+		return nil, err
+	}
+	if communication.ReadAt != nil {
+		return communication, nil
+	}
+
+	now := time.Now()
+	if err := s.db.Model(communication).Update("read_at", now).Error; err != nil {
+		return nil, err
+	}
+	communication.ReadAt = &now
+	return communication, nil
+}
+
+// MarkAllRead kullanıcının okunmamış bildirimlerini okundu olarak işaretler.
+// category boş değilse yalnızca o kategorideki bildirimler işaretlenir.
+func (s *NotificationService) MarkAllRead(userID uint, category string) (int64, error) {
+	db := s.db.Model(&model.UserCommunication{}).Where("user_id = ? AND read_at IS NULL", userID)
+	if category != "" {
+		db = db.Where("communication_type = ?", category)
+	}
+
+	result := db.Update("read_at", time.Now())
+	return result.RowsAffected, result.Error
+}
+
+// DeleteNotification kullanıcının bildirimini ve teslimat kayıtlarını siler
+func (s *NotificationService) DeleteNotification(userID, id uint) error {
+	return runInTransaction(s.db, func(tx *gorm.DB) error {
+		result := tx.Where("id = ? AND user_id = ?", id, userID).Delete(&model.UserCommunication{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errors.New("bildirim bulunamadı")
+		}
+		return tx.Where("communication_id = ?", id).Delete(&model.NotificationDelivery{}).Error
+	})
+}
@@ -0,0 +1,214 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/UmutTKMN/go-backend/internal/app/model"
+	"github.com/UmutTKMN/go-backend/internal/pkg/database"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrUnknownPreference tercih anahtarı PreferenceDefinitions içinde tanımlı değil
+var ErrUnknownPreference = errors.New("bilinmeyen tercih anahtarı")
+
+// PreferenceValue bir tercih anahtarının geçerli değeri ve tanımı
+type PreferenceValue struct {
+	Key        string                      `json:"key"`
+	Value      interface{}                 `json:"value"`
+	IsDefault  bool                        `json:"is_default"`
+	Definition *model.PreferenceDefinition `json:"definition"`
+}
+
+// PreferenceSet kullanıcının tüm tercihleri; Values kayıtlı olmayan anahtarlar için varsayılanları içerir
+type PreferenceSet struct {
+	Values     map[string]interface{}
+	Customized []string
+}
+
+// PreferenceService kullanıcı tercihleri için servis
+type PreferenceService struct {
+	db *gorm.DB
+}
+
+// NewPreferenceService yeni bir PreferenceService örneği oluşturur
+func NewPreferenceService() *PreferenceService {
+	return &PreferenceService{
+		db: database.DB,
+	}
+}
+
+// GetPreferences kullanıcının tüm tercihlerini varsayılanlarla birleştirerek getirir
+func (s *PreferenceService) GetPreferences(userID uint) (*PreferenceSet, error) {
+	stored, err := s.storedPreferences(s.db, userID)
+	if err != nil {
+		return nil, err
+	}
+	return buildPreferenceSet(stored), nil
+}
+
+// GetPreference tek bir tercihin geçerli değerini getirir
+func (s *PreferenceService) GetPreference(userID uint, key string) (*PreferenceValue, error) {
+	definition, ok := model.FindPreferenceDefinition(key)
+	if !ok {
+		return nil, ErrUnknownPreference
+	}
+
+	var preference model.UserPreference
+	err := s.db.Where("user_id = ? AND preference_key = ?", userID, key).First(&preference).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return &PreferenceValue{Key: key, Value: definition.Default, IsDefault: true, Definition: definition}, nil
+		}
+		return nil, err
+	}
+
+	value, valid := decodePreference(definition, preference.PreferenceValue)
+	return &PreferenceValue{Key: key, Value: value, IsDefault: !valid, Definition: definition}, nil
+}
+
+// SetPreference tercihi doğrulayıp kaydeder; kayıt varsa günceller
+func (s *PreferenceService) SetPreference(userID uint, key string, raw json.RawMessage) (*PreferenceValue, error) {
+	definition, ok := model.FindPreferenceDefinition(key)
+	if !ok {
+		return nil, ErrUnknownPreference
+	}
+
+	value, err := definition.Parse(raw)
+	if err != nil {
+		return nil, err
+	}
+	if err := upsertPreference(s.db, userID, key, value); err != nil {
+		return nil, err
+	}
+	return &PreferenceValue{Key: key, Value: value, Definition: definition}, nil
+}
+
+// ResetPreference kayıtlı tercihi siler; varsayılan değer kullanılır
+func (s *PreferenceService) ResetPreference(userID uint, key string) (*PreferenceValue, error) {
+	definition, ok := model.FindPreferenceDefinition(key)
+	if !ok {
+		return nil, ErrUnknownPreference
+	}
+
+	err := s.db.Where("user_id = ? AND preference_key = ?", userID, key).Delete(&model.UserPreference{}).Error
+	if err != nil {
+		return nil, err
+	}
+	return &PreferenceValue{Key: key, Value: definition.Default, IsDefault: true, Definition: definition}, nil
+}
+
+// SetPreferences birden fazla tercihi tek işlemde kaydeder. null değer verilen anahtarlar varsayılana döner.
+// Değerlerden biri geçersizse hiçbir değişiklik yapılmaz.
+func (s *PreferenceService) SetPreferences(userID uint, values map[string]json.RawMessage) (*PreferenceSet, error) {
+	if len(values) == 0 {
+		return nil, errors.New("güncellenecek tercih yok")
+	}
+
+	parsed := make(map[string]interface{}, len(values))
+	var resets []string
+	for key, raw := range values {
+		definition, ok := model.FindPreferenceDefinition(key)
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownPreference, key)
+		}
+		if string(raw) == "null" {
+			resets = append(resets, key)
+			continue
+		}
+
+		value, err := definition.Parse(raw)
+		if err != nil {
+			return nil, err
+		}
+		parsed[key] = value
+	}
+
+	var stored []model.UserPreference
+	err := runInTransaction(s.db, func(tx *gorm.DB) error {
+		if len(resets) > 0 {
+			err := tx.Where("user_id = ? AND preference_key IN ?", userID, resets).Delete(&model.UserPreference{}).Error
+			if err != nil {
+				return err
+			}
+		}
+		for key, value := range parsed {
+			if err := upsertPreference(tx, userID, key, value); err != nil {
+				return err
+			}
+		}
+
+		var err error
+		stored, err = s.storedPreferences(tx, userID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return buildPreferenceSet(stored), nil
+}
+
+// storedPreferences kullanıcının kayıtlı tercihlerini getirir
+func (s *PreferenceService) storedPreferences(db *gorm.DB, userID uint) ([]model.UserPreference, error) {
+	var preferences []model.UserPreference
+	err := db.Where("user_id = ?", userID).Find(&preferences).Error
+	return preferences, err
+}
+
+// upsertPreference (user_id, preference_key) benzersiz indeksi üzerinden tercihi ekler veya günceller
+func upsertPreference(db *gorm.DB, userID uint, key string, value interface{}) error {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	return db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}, {Name: "preference_key"}},
+		DoUpdates: clause.AssignmentColumns([]string{"preference_value", "updated_at"}),
+	}).Create(&model.UserPreference{
+		UserID:          userID,
+		PreferenceKey:   key,
+		PreferenceValue: string(encoded),
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}).Error
+}
+
+// buildPreferenceSet tüm tanımlı anahtarlar için kayıtlı değeri, yoksa varsayılanı döndürür.
+// Tanımı kaldırılmış anahtarlar ve artık geçersiz olan değerler yok sayılır.
+func buildPreferenceSet(stored []model.UserPreference) *PreferenceSet {
+	set := &PreferenceSet{
+		Values:     make(map[string]interface{}, len(model.PreferenceDefinitions)),
+		Customized: []string{},
+	}
+	for _, definition := range model.PreferenceDefinitions {
+		set.Values[definition.Key] = definition.Default
+	}
+
+	for _, preference := range stored {
+		definition, ok := model.FindPreferenceDefinition(preference.PreferenceKey)
+		if !ok {
+			continue
+		}
+		if value, valid := decodePreference(definition, preference.PreferenceValue); valid {
+			set.Values[definition.Key] = value
+			set.Customized = append(set.Customized, definition.Key)
+		}
+	}
+	sort.Strings(set.Customized)
+	return set
+}
+
+// decodePreference kayıtlı değeri tanıma göre çözümler; geçersizse varsayılanı ve false döndürür
+func decodePreference(definition *model.PreferenceDefinition, stored string) (interface{}, bool) {
+	value, err := definition.Parse(json.RawMessage(stored))
+	if err != nil {
+		return definition.Default, false
+	}
+	return value, true
+}
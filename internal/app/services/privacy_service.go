@@ -0,0 +1,350 @@
+package services
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/UmutTKMN/go-backend/internal/app/model"
+	"github.com/UmutTKMN/go-backend/internal/pkg/database"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// privacyStaffRoles staff düzeyindeki alanları görebilen roller
+var privacyStaffRoles = []string{"Staff", "Manager", "Admin", "Super Admin"}
+
+// privacyAdminRoles tüm alanları görebilen roller
+var privacyAdminRoles = []string{"Admin", "Super Admin"}
+
+// PrivacyViewer kullanıcı verisini görüntüleyen taraf; UserID sıfırsa istek anonimdir
+type PrivacyViewer struct {
+	UserID  uint
+	IsStaff bool
+	IsAdmin bool
+}
+
+// CanView görüntüleyenin verilen görünürlük düzeyindeki bir alanı görüp göremeyeceğini döndürür
+func (v PrivacyViewer) CanView(ownerID uint, visibility string) bool {
+	if v.IsAdmin || (v.UserID != 0 && v.UserID == ownerID) {
+		return true
+	}
+	switch visibility {
+	case model.VisibilityPublic:
+		return true
+	case model.VisibilityStaff:
+		return v.IsStaff
+	}
+	return false
+}
+
+// PrivacyService kullanıcı gizlilik ayarları ve yanıtlara uygulanması için servis
+type PrivacyService struct {
+	db *gorm.DB
+}
+
+// NewPrivacyService yeni bir PrivacyService örneği oluşturur
+func NewPrivacyService() *PrivacyService {
+	return &PrivacyService{
+		db: database.DB,
+	}
+}
+
+// Viewer kullanıcının rollerine göre görüntüleyen bilgisini oluşturur; userID sıfırsa anonim görüntüleyen döner
+func (s *PrivacyService) Viewer(userID uint) (PrivacyViewer, error) {
+	viewer := PrivacyViewer{UserID: userID}
+	if userID == 0 {
+		return viewer, nil
+	}
+
+	var roleNames []string
+	err := s.db.Table("roles").
+		Joins("JOIN user_roles ON user_roles.role_id = roles.role_id").
+		Where("user_roles.user_id = ? AND roles.deleted_at IS NULL", userID).
+		Pluck("roles.role_name", &roleNames).Error
+	if err != nil {
+		return viewer, err
+	}
+
+	for _, name := range roleNames {
+		for _, role := range privacyStaffRoles {
+			if name == role {
+				viewer.IsStaff = true
+			}
+		}
+		for _, role := range privacyAdminRoles {
+			if name == role {
+				viewer.IsAdmin = true
+			}
+		}
+	}
+	return viewer, nil
+}
+
+// GetSettings kullanıcının tüm alan grupları için geçerli gizlilik ayarlarını getirir
+func (s *PrivacyService) GetSettings(userID uint) (*model.PrivacySettings, error) {
+	var user model.User
+	if err := s.db.First(&user, userID).Error; err != nil {
+		return nil, errors.New("kullanıcı bulunamadı")
+	}
+	return privacySettings(&user), nil
+}
+
+// UpdateSettings verilen alan grubu görünürlüklerini kayıtlı ayarlarla birleştirir
+func (s *PrivacyService) UpdateSettings(userID uint, update model.PrivacySettings) (*model.PrivacySettings, error) {
+	if len(update.Fields) == 0 {
+		return nil, errors.New("güncellenecek gizlilik ayarı yok")
+	}
+	for group, visibility := range update.Fields {
+		if _, ok := model.PrivacyFieldGroups[group]; !ok {
+			return nil, fmt.Errorf("geçersiz gizlilik alanı: %s", group)
+		}
+		if !model.IsValidVisibility(visibility) {
+			return nil, fmt.Errorf("geçersiz görünürlük düzeyi: %s", visibility)
+		}
+	}
+
+	var user model.User
+	err := runInTransaction(s.db, func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&user, userID).Error; err != nil {
+			return errors.New("kullanıcı bulunamadı")
+		}
+
+		stored := storedPrivacySettings(&user)
+		for group, visibility := range update.Fields {
+			stored.Fields[group] = visibility
+		}
+
+		data, err := json.Marshal(stored)
+		if err != nil {
+			return err
+		}
+		user.PrivacySettings = sql.NullString{String: string(data), Valid: true}
+		return tx.Model(&user).Update("privacy_settings", user.PrivacySettings).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return privacySettings(&user), nil
+}
+
+// FilterUser kullanıcıyı görüntüleyenin görebileceği alanlarla sınırlanmış bir JSON nesnesine dönüştürür
+func FilterUser(user *model.User, viewer PrivacyViewer) (map[string]interface{}, error) {
+	data, err := toJSONMap(user)
+	if err != nil {
+		return nil, err
+	}
+
+	settings := storedPrivacySettings(user)
+	for field := range data {
+		if !viewer.CanView(user.ID, settings.FieldVisibility(field)) {
+			delete(data, field)
+		}
+	}
+	return data, nil
+}
+
+// FilterUsers kullanıcı listesine FilterUser uygular
+func FilterUsers(users []model.User, viewer PrivacyViewer) ([]map[string]interface{}, error) {
+	return filterList(users, viewer, FilterUser)
+}
+
+// FilterStaff personel kaydındaki kullanıcı bilgisini görüntüleyene göre sınırlar; personel alanları değişmez
+func FilterStaff(staff *model.Staff, viewer PrivacyViewer) (map[string]interface{}, error) {
+	data, err := toJSONMap(staff)
+	if err != nil {
+		return nil, err
+	}
+	if staff.User != nil {
+		user, err := FilterUser(staff.User, viewer)
+		if err != nil {
+			return nil, err
+		}
+		data["user"] = user
+	}
+	return data, nil
+}
+
+// FilterStaffList personel listesine FilterStaff uygular
+func FilterStaffList(staffList []model.Staff, viewer PrivacyViewer) ([]map[string]interface{}, error) {
+	return filterList(staffList, viewer, FilterStaff)
+}
+
+// FilterRequest talep sahibinin ve talebe atanan personelin kullanıcı bilgisini görüntüleyene göre sınırlar
+func FilterRequest(request *model.UserRequest, viewer PrivacyViewer) (map[string]interface{}, error) {
+	data, err := toJSONMap(request)
+	if err != nil {
+		return nil, err
+	}
+	if request.User != nil {
+		if data["user"], err = FilterUser(request.User, viewer); err != nil {
+			return nil, err
+		}
+	}
+	if request.HandledBy != nil {
+		if data["handled_by"], err = FilterStaff(request.HandledBy, viewer); err != nil {
+			return nil, err
+		}
+	}
+	return data, nil
+}
+
+// FilterRequests talep listesine FilterRequest uygular
+func FilterRequests(requests []model.UserRequest, viewer PrivacyViewer) ([]map[string]interface{}, error) {
+	return filterList(requests, viewer, FilterRequest)
+}
+
+// FilterLeaveRequest izin talebindeki personel ve onaylayan bilgisini görüntüleyene göre sınırlar
+func FilterLeaveRequest(request *model.LeaveRequest, viewer PrivacyViewer) (map[string]interface{}, error) {
+	data, err := toJSONMap(request)
+	if err != nil {
+		return nil, err
+	}
+	if request.Staff != nil {
+		if data["staff"], err = FilterStaff(request.Staff, viewer); err != nil {
+			return nil, err
+		}
+	}
+	if request.Approver != nil {
+		if data["approver"], err = FilterStaff(request.Approver, viewer); err != nil {
+			return nil, err
+		}
+	}
+	return data, nil
+}
+
+// FilterLeaveRequests izin talebi listesine FilterLeaveRequest uygular
+func FilterLeaveRequests(requests []model.LeaveRequest, viewer PrivacyViewer) ([]map[string]interface{}, error) {
+	return filterList(requests, viewer, FilterLeaveRequest)
+}
+
+// FilterDocument belge sahibinin kullanıcı bilgisini görüntüleyene göre sınırlar
+func FilterDocument(document *model.UserDocument, viewer PrivacyViewer) (map[string]interface{}, error) {
+	data, err := toJSONMap(document)
+	if err != nil {
+		return nil, err
+	}
+	if document.User != nil {
+		if data["user"], err = FilterUser(document.User, viewer); err != nil {
+			return nil, err
+		}
+	}
+	return data, nil
+}
+
+// FilterDocuments belge listesine FilterDocument uygular
+func FilterDocuments(documents []model.UserDocument, viewer PrivacyViewer) ([]map[string]interface{}, error) {
+	return filterList(documents, viewer, FilterDocument)
+}
+
+// FilterDelegation yetki devrindeki devreden ve devralan personelin kullanıcı bilgisini görüntüleyene göre sınırlar
+func FilterDelegation(delegation *model.Delegation, viewer PrivacyViewer) (map[string]interface{}, error) {
+	data, err := toJSONMap(delegation)
+	if err != nil {
+		return nil, err
+	}
+	if delegation.Delegator != nil {
+		if data["delegator"], err = FilterStaff(delegation.Delegator, viewer); err != nil {
+			return nil, err
+		}
+	}
+	if delegation.Delegate != nil {
+		if data["delegate"], err = FilterStaff(delegation.Delegate, viewer); err != nil {
+			return nil, err
+		}
+	}
+	return data, nil
+}
+
+// FilterDelegations yetki devri listesine FilterDelegation uygular
+func FilterDelegations(delegations []model.Delegation, viewer PrivacyViewer) ([]map[string]interface{}, error) {
+	return filterList(delegations, viewer, FilterDelegation)
+}
+
+// FilterComment yorum yazarının kullanıcı bilgisini görüntüleyene göre sınırlar
+func FilterComment(comment *model.RequestComment, viewer PrivacyViewer) (map[string]interface{}, error) {
+	data, err := toJSONMap(comment)
+	if err != nil {
+		return nil, err
+	}
+	if comment.Author != nil {
+		if data["author"], err = FilterUser(comment.Author, viewer); err != nil {
+			return nil, err
+		}
+	}
+	return data, nil
+}
+
+// FilterComments yorum listesine FilterComment uygular
+func FilterComments(comments []model.RequestComment, viewer PrivacyViewer) ([]map[string]interface{}, error) {
+	return filterList(comments, viewer, FilterComment)
+}
+
+// FilterSLAPolicy eskalasyon yapılacak personelin kullanıcı bilgisini görüntüleyene göre sınırlar
+func FilterSLAPolicy(policy *model.SLAPolicy, viewer PrivacyViewer) (map[string]interface{}, error) {
+	data, err := toJSONMap(policy)
+	if err != nil {
+		return nil, err
+	}
+	if policy.EscalateTo != nil {
+		if data["escalate_to"], err = FilterStaff(policy.EscalateTo, viewer); err != nil {
+			return nil, err
+		}
+	}
+	return data, nil
+}
+
+// filterList listedeki her öğeye verilen gizlilik filtresini uygular
+func filterList[T any](items []T, viewer PrivacyViewer, filter func(*T, PrivacyViewer) (map[string]interface{}, error)) ([]map[string]interface{}, error) {
+	result := make([]map[string]interface{}, 0, len(items))
+	for i := range items {
+		data, err := filter(&items[i], viewer)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, data)
+	}
+	return result, nil
+}
+
+// toJSONMap değeri JSON etiketleriyle anahtarlanmış bir nesneye dönüştürür
+func toJSONMap(value interface{}) (map[string]interface{}, error) {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	var data map[string]interface{}
+	if err := json.Unmarshal(encoded, &data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// storedPrivacySettings kullanıcının açıkça belirttiği gizlilik ayarlarını döndürür.
+// Okunamayan değerler yok sayılır; varsayılanlar uygulanır.
+func storedPrivacySettings(user *model.User) model.PrivacySettings {
+	var settings model.PrivacySettings
+	if user.PrivacySettings.Valid && user.PrivacySettings.String != "" {
+		if err := json.Unmarshal([]byte(user.PrivacySettings.String), &settings); err != nil {
+			log.Printf("Gizlilik ayarları okunamadı (kullanıcı %d): %v", user.ID, err)
+			settings = model.PrivacySettings{}
+		}
+	}
+	if settings.Fields == nil {
+		settings.Fields = map[string]string{}
+	}
+	return settings
+}
+
+// privacySettings kayıtlı ayarları tüm alan grupları için varsayılanlarla tamamlar
+func privacySettings(user *model.User) *model.PrivacySettings {
+	stored := storedPrivacySettings(user)
+
+	settings := &model.PrivacySettings{Fields: make(map[string]string, len(model.PrivacyFieldGroups))}
+	for group := range model.PrivacyFieldGroups {
+		settings.Fields[group] = stored.Visibility(group)
+	}
+	return settings
+}
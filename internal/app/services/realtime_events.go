@@ -0,0 +1,77 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/UmutTKMN/go-backend/internal/app/model"
+	"github.com/UmutTKMN/go-backend/internal/pkg/realtime"
+)
+
+// Bağlı istemcilere iletilen anlık olay türleri
+const (
+	EventNotificationCreated  = "notification.created"
+	EventRequestStatusChanged = "request.status_changed"
+	EventForcedLogout         = "session.logout"
+)
+
+// Zorunlu çıkış nedenleri
+const (
+	LogoutReasonAccountDeactivated = "account_deactivated"
+	LogoutReasonAccountDeleted     = "account_deleted"
+	LogoutReasonDeletionRequested  = "deletion_requested"
+)
+
+const realtimePublishTimeout = 5 * time.Second
+
+var (
+	realtimeMu  sync.RWMutex
+	realtimeHub *realtime.Hub
+)
+
+// SetRealtimeHub servislerin anlık olayları yayınlayacağı hub'ı ayarlar; uygulama başlarken çağrılır.
+// Hub ayarlanmazsa olaylar yayınlanmaz.
+func SetRealtimeHub(hub *realtime.Hub) {
+	realtimeMu.Lock()
+	defer realtimeMu.Unlock()
+	realtimeHub = hub
+}
+
+// publishEvent olayı kullanıcıya bağlı istemcilerine yayınlar. Yayın hataları işlemi etkilemez, yalnızca günlüğe yazılır.
+func publishEvent(userID uint, eventType string, data interface{}) {
+	realtimeMu.RLock()
+	hub := realtimeHub
+	realtimeMu.RUnlock()
+	if hub == nil || userID == 0 {
+		return
+	}
+
+	payload, err := json.Marshal(data)
+	if err != nil {
+		log.Printf("Anlık olay oluşturulamadı (%s, kullanıcı %d): %v", eventType, userID, err)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), realtimePublishTimeout)
+	defer cancel()
+	if err := hub.Publish(ctx, realtime.Event{Type: eventType, UserID: userID, Data: payload}); err != nil {
+		log.Printf("Anlık olay yayınlanamadı (%s, kullanıcı %d): %v", eventType, userID, err)
+	}
+}
+
+// publishRequestStatus talep durumundaki değişikliği talep sahibine yayınlar
+func publishRequestStatus(request *model.UserRequest) {
+	publishEvent(request.UserID, EventRequestStatusChanged, map[string]interface{}{
+		"request_id":   request.ID,
+		"request_type": request.RequestType,
+		"status":       request.Status,
+	})
+}
+
+// publishForcedLogout kullanıcının açık oturumlarının kapatılması gerektiğini yayınlar
+func publishForcedLogout(userID uint, reason string) {
+	publishEvent(userID, EventForcedLogout, map[string]interface{}{"reason": reason})
+}
@@ -0,0 +1,279 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/UmutTKMN/go-backend/internal/app/model"
+	"github.com/UmutTKMN/go-backend/internal/pkg/database"
+	"github.com/UmutTKMN/go-backend/internal/pkg/storage"
+	"gorm.io/gorm"
+)
+
+// Yorum eki sınırları
+const (
+	MaxCommentAttachments    = 5
+	MaxCommentAttachmentSize = 10 << 20 // 10 MB
+)
+
+// FileUpload yüklenen bir dosyanın içeriğini ve üst bilgilerini taşır
+type FileUpload struct {
+	FileName    string
+	ContentType string
+	Size        int64
+	Reader      io.Reader
+}
+
+// RequestCommentService talep yorumları ve iç notlar için servis
+type RequestCommentService struct {
+	db            *gorm.DB
+	storage       storage.Storage
+	notifications *NotificationService
+}
+
+// NewRequestCommentService yeni bir RequestCommentService örneği oluşturur
+func NewRequestCommentService(store storage.Storage) *RequestCommentService {
+	return &RequestCommentService{
+		db:            database.DB,
+		storage:       store,
+		notifications: NewNotificationService(),
+	}
+}
+
+// GetComments talebin yorumlarını eskiden yeniye getirir.
+// includeInternal false ise yalnızca talep sahibinin görebileceği yorumlar döner.
+func (s *RequestCommentService) GetComments(requestID uint, includeInternal bool) ([]model.RequestComment, error) {
+	var comments []model.RequestComment
+	db := s.db.Preload("Author").Preload("Attachments").
+		Where("request_id = ?", requestID)
+	if !includeInternal {
+		db = db.Where("is_internal = ?", false)
+	}
+
+	result := db.Order("created_at").Find(&comments)
+	return comments, result.Error
+}
+
+// GetCommentByID ID'ye göre yorum getirir
+func (s *RequestCommentService) GetCommentByID(id uint) (*model.RequestComment, error) {
+	var comment model.RequestComment
+	result := s.db.Preload("Author").Preload("Attachments").Preload("Request").First(&comment, id)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, errors.New("yorum bulunamadı")
+		}
+		return nil, result.Error
+	}
+	return &comment, nil
+}
+
+// AddComment talebe yeni bir yorum ve ekleri ekler, ardından talep sahibini ve atanan personeli bilgilendirir
+func (s *RequestCommentService) AddComment(ctx context.Context, comment *model.RequestComment, uploads []FileUpload) error {
+	comment.Body = strings.TrimSpace(comment.Body)
+	if comment.Body == "" {
+		return errors.New("yorum metni boş olamaz")
+	}
+	if len(uploads) > MaxCommentAttachments {
+		return fmt.Errorf("bir yoruma en fazla %d dosya eklenebilir", MaxCommentAttachments)
+	}
+	for _, upload := range uploads {
+		if upload.Size > MaxCommentAttachmentSize {
+			return fmt.Errorf("%s dosyası izin verilen boyutu aşıyor", upload.FileName)
+		}
+	}
+
+	var request model.UserRequest
+	if err := s.db.First(&request, comment.RequestID).Error; err != nil {
+		return errors.New("talep bulunamadı")
+	}
+	if request.Status == model.RequestStatusClosed {
+		return errors.New("kapatılmış talebe yorum eklenemez")
+	}
+
+	var storedKeys []string
+	err := runInTransaction(s.db, func(tx *gorm.DB) error {
+		comment.EditedAt = nil
+		if err := tx.Omit("Attachments").Create(comment).Error; err != nil {
+			return err
+		}
+
+		// Talep sahibi dışından gelen ilk herkese açık yanıt SLA ilk yanıt hedefini karşılar
+		if !comment.IsInternal && comment.AuthorUserID != request.UserID && request.FirstRespondedAt == nil {
+			if err := tx.Model(&model.UserRequest{}).
+				Where("id = ? AND first_responded_at IS NULL", request.ID).
+				Update("first_responded_at", comment.CreatedAt).Error; err != nil {
+				return err
+			}
+		}
+
+		for i, upload := range uploads {
+			fileName := sanitizeFileName(upload.FileName)
+			key := fmt.Sprintf("requests/%d/comments/%d/%d-%s", comment.RequestID, comment.ID, i+1, fileName)
+			if err := s.storage.Put(ctx, key, upload.Reader, upload.Size, upload.ContentType); err != nil {
+				return err
+			}
+			storedKeys = append(storedKeys, key)
+
+			attachment := model.RequestCommentAttachment{
+				CommentID:   comment.ID,
+				FileName:    fileName,
+				ContentType: upload.ContentType,
+				Size:        upload.Size,
+				StorageKey:  key,
+			}
+			if err := tx.Create(&attachment).Error; err != nil {
+				return err
+			}
+			comment.Attachments = append(comment.Attachments, attachment)
+		}
+
+		return nil
+	})
+	if err != nil {
+		// Veritabanı işlemi geri alındığından depolanan dosyaları da temizle
+		for _, key := range storedKeys {
+			if deleteErr := s.storage.Delete(ctx, key); deleteErr != nil {
+				log.Printf("Yorum eki temizlenemedi (%s): %v", key, deleteErr)
+			}
+		}
+		return err
+	}
+
+	s.notifyParticipants(&request, comment)
+	return nil
+}
+
+// EditComment yorumu günceller ve önceki metni düzenleme geçmişine kaydeder.
+// Yalnızca yorumun yazarı düzenleyebilir.
+func (s *RequestCommentService) EditComment(commentID, userID uint, body string) (*model.RequestComment, error) {
+	body = strings.TrimSpace(body)
+	if body == "" {
+		return nil, errors.New("yorum metni boş olamaz")
+	}
+
+	err := runInTransaction(s.db, func(tx *gorm.DB) error {
+		var comment model.RequestComment
+		if err := tx.First(&comment, commentID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.New("yorum bulunamadı")
+			}
+			return err
+		}
+
+		if comment.AuthorUserID != userID {
+			return errors.New("yalnızca kendi yorumunuzu düzenleyebilirsiniz")
+		}
+		if comment.Body == body {
+			return nil
+		}
+
+		revision := model.RequestCommentRevision{
+			CommentID:      comment.ID,
+			Body:           comment.Body,
+			EditedByUserID: userID,
+		}
+		if err := tx.Create(&revision).Error; err != nil {
+			return err
+		}
+
+		return tx.Model(&comment).Updates(map[string]interface{}{
+			"body":      body,
+			"edited_at": time.Now(),
+		}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetCommentByID(commentID)
+}
+
+// GetCommentHistory yorumun düzenleme geçmişini eskiden yeniye getirir
+func (s *RequestCommentService) GetCommentHistory(commentID uint) ([]model.RequestCommentRevision, error) {
+	var revisions []model.RequestCommentRevision
+	result := s.db.Where("comment_id = ?", commentID).Order("created_at").Find(&revisions)
+	return revisions, result.Error
+}
+
+// OpenAttachment yorum ekini ve içeriğini okumak için açar
+func (s *RequestCommentService) OpenAttachment(ctx context.Context, commentID, attachmentID uint) (*model.RequestCommentAttachment, io.ReadCloser, error) {
+	var attachment model.RequestCommentAttachment
+	if err := s.db.Where("id = ? AND comment_id = ?", attachmentID, commentID).First(&attachment).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, errors.New("ek bulunamadı")
+		}
+		return nil, nil, err
+	}
+
+	reader, err := s.storage.Open(ctx, attachment.StorageKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &attachment, reader, nil
+}
+
+// notifyParticipants yorum yazarı dışındaki talep sahibini ve atanan personeli bilgilendirir.
+// İç notlar talep sahibine bildirilmez. Bildirim hataları yorumu geri almaz, yalnızca günlüğe kaydedilir.
+func (s *RequestCommentService) notifyParticipants(request *model.UserRequest, comment *model.RequestComment) {
+	if !comment.IsInternal && request.UserID != comment.AuthorUserID {
+		_, err := s.notifications.Notify(request.UserID, Notification{
+			Category: model.NotificationCategoryRequests,
+			Template: "comment_reply",
+			Data:     map[string]interface{}{"RequestID": request.ID, "Body": comment.Body},
+			SenderID: comment.AuthorUserID,
+		})
+		if err != nil {
+			log.Printf("Talep sahibine yorum bildirimi gönderilemedi (talep %d): %v", request.ID, err)
+		}
+	}
+
+	if request.HandledByStaffID == nil {
+		return
+	}
+
+	var assignee model.Staff
+	if err := s.db.First(&assignee, *request.HandledByStaffID).Error; err != nil {
+		log.Printf("Talebe atanan personel bulunamadı (talep %d): %v", request.ID, err)
+		return
+	}
+	if assignee.UserID == comment.AuthorUserID {
+		return
+	}
+
+	_, err := s.notifications.Notify(assignee.UserID, Notification{
+		Category: model.NotificationCategoryRequests,
+		Template: "comment_assignee",
+		Data:     map[string]interface{}{"RequestID": request.ID, "Body": comment.Body},
+		SenderID: comment.AuthorUserID,
+	})
+	if err != nil {
+		log.Printf("Atanan personele yorum bildirimi gönderilemedi (talep %d): %v", request.ID, err)
+	}
+}
+
+var unsafeFileNameChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// sanitizeFileName dosya adından dizin bilgisini ve güvenli olmayan karakterleri temizler
+func sanitizeFileName(name string) string {
+	name = filepath.Base(strings.ReplaceAll(name, "\\", "/"))
+	name = unsafeFileNameChars.ReplaceAllString(name, "_")
+	name = strings.Trim(name, "._")
+	if name == "" {
+		return "dosya"
+	}
+	if len(name) > 100 {
+		extension := filepath.Ext(name)
+		if len(extension) > 10 {
+			extension = ""
+		}
+		name = name[:100-len(extension)] + extension
+	}
+	return name
+}
@@ -0,0 +1,637 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/UmutTKMN/go-backend/internal/app/model"
+	"github.com/UmutTKMN/go-backend/internal/pkg/database"
+	"github.com/UmutTKMN/go-backend/internal/pkg/listquery"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// RequestQueueSpec talep kuyruklarında izin verilen sıralama alanları ve sayfa sınırları
+var RequestQueueSpec = listquery.Spec{
+	SortFields: map[string]listquery.SortField{
+		"id":           {Column: "user_requests.id", Kind: listquery.KindInt},
+		"submitted_at": {Column: "user_requests.submitted_at", Kind: listquery.KindTime},
+		"updated_at":   {Column: "user_requests.updated_at", Kind: listquery.KindTime},
+	},
+	DefaultSort:  "submitted_at",
+	IDColumn:     "user_requests.id",
+	DefaultLimit: 20,
+	MaxLimit:     100,
+}
+
+// requestSupervisorRoles kendilerine atanmamış talepleri kapatabilen roller
+var requestSupervisorRoles = []string{"Manager", "Admin", "Super Admin"}
+
+// RequestQueueFilter talep kuyruğu filtreleri
+type RequestQueueFilter struct {
+	Status         string
+	RequestType    string
+	Priority       string
+	AssignedTo     uint
+	Unassigned     bool
+	SLAStatus      string
+	IncludeDeleted bool // Silinmiş talepleri de listeler
+}
+
+// SLA durum filtreleri için koşullar. Sonuçlanmış talepler de hedefi aşarak
+// sonuçlandıysa ihlal sayılır; risk altındaki talepler ihlal edilmemiş açık hedeflerdir.
+const (
+	slaBreachedCondition = "(COALESCE(user_requests.first_response_due_at < COALESCE(user_requests.first_responded_at, @now), FALSE) OR " +
+		"COALESCE(user_requests.resolution_due_at < COALESCE(user_requests.completed_at, @now), FALSE))"
+	slaAtRiskCondition = "((user_requests.first_responded_at IS NULL AND user_requests.submitted_at + " +
+		"(user_requests.first_response_due_at - user_requests.submitted_at) * @ratio < @now) OR " +
+		"(user_requests.completed_at IS NULL AND user_requests.submitted_at + " +
+		"(user_requests.resolution_due_at - user_requests.submitted_at) * @ratio < @now))"
+)
+
+// RequestStatusUpdate talep durum değişikliği için girdiler
+type RequestStatusUpdate struct {
+	Status           string
+	DecisionNotes    *string
+	FollowUpRequired *bool
+	FollowUpDate     *time.Time
+}
+
+// FollowUpUpdate talebin takip ayarları için girdiler.
+// Reopen true ise takip tarihi geldiğinde çözülmüş talep yeniden işleme alınır.
+type FollowUpUpdate struct {
+	Required bool
+	Date     *time.Time
+	Reopen   bool
+}
+
+// FollowUps personelin takip bekleyen talepleri
+type FollowUps struct {
+	Overdue  []model.UserRequest `json:"overdue"`
+	Upcoming []model.UserRequest `json:"upcoming"`
+}
+
+// RequestService kullanıcı talepleri (destek kayıtları) için servis
+type RequestService struct {
+	db            *gorm.DB
+	notifications *NotificationService
+}
+
+// NewRequestService yeni bir RequestService örneği oluşturur
+func NewRequestService() *RequestService {
+	return &RequestService{
+		db:            database.DB,
+		notifications: NewNotificationService(),
+	}
+}
+
+// SubmitRequest kullanıcı adına yeni bir talep oluşturur
+func (s *RequestService) SubmitRequest(request *model.UserRequest) error {
+	if request.RequestType == "" {
+		return errors.New("talep türü zorunludur")
+	}
+
+	if request.Priority == "" {
+		request.Priority = model.RequestPriorityNormal
+	}
+	if !model.IsValidRequestPriority(request.Priority) {
+		return errors.New("geçersiz talep önceliği")
+	}
+
+	now := time.Now()
+	request.Status = model.RequestStatusSubmitted
+	request.SubmittedAt = now
+	request.ProcessingStartedAt = nil
+	request.CompletedAt = nil
+	request.HandledByStaffID = nil
+	request.DecisionNotes = ""
+	request.FirstRespondedAt = nil
+	request.FirstResponseEscalatedAt = nil
+	request.ResolutionEscalatedAt = nil
+
+	if err := s.sla().ApplyPolicy(request); err != nil {
+		return err
+	}
+
+	if err := s.db.Create(request).Error; err != nil {
+		return err
+	}
+	setSLAStatus(request)
+
+	// Yönlendirme hatası talebin alınmasını engellemez; zamanlayıcı atamayı tekrar dener
+	staffID, err := s.routing().RouteRequest(request.ID)
+	if err != nil {
+		log.Printf("Talep yönlendirilemedi (talep %d): %v", request.ID, err)
+	}
+	request.HandledByStaffID = staffID
+	return nil
+}
+
+// GetRequestByID ID'ye göre talep getirir
+func (s *RequestService) GetRequestByID(id uint) (*model.UserRequest, error) {
+	var request model.UserRequest
+	result := s.db.Preload("User").Preload("HandledBy.User").First(&request, id)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, errors.New("talep bulunamadı")
+		}
+		return nil, result.Error
+	}
+	setSLAStatus(&request)
+	return &request, nil
+}
+
+// GetRequestsByUser kullanıcının taleplerini en yeniden eskiye getirir
+func (s *RequestService) GetRequestsByUser(userID uint) ([]model.UserRequest, error) {
+	var requests []model.UserRequest
+	result := s.db.Preload("HandledBy.User").
+		Where("user_id = ?", userID).
+		Order("submitted_at DESC").
+		Find(&requests)
+	for i := range requests {
+		setSLAStatus(&requests[i])
+	}
+	return requests, result.Error
+}
+
+// GetQueue personel için filtrelenmiş talep kuyruğunu sayfalı olarak getirir
+func (s *RequestService) GetQueue(filter RequestQueueFilter, query *listquery.Query) (*listquery.Page[model.UserRequest], error) {
+	db := s.db.Model(&model.UserRequest{}).Preload("User").Preload("HandledBy.User")
+
+	if filter.IncludeDeleted {
+		db = db.Unscoped()
+	}
+	if filter.Status != "" {
+		db = db.Where("user_requests.status = ?", filter.Status)
+	}
+	if filter.RequestType != "" {
+		db = db.Where("user_requests.request_type = ?", filter.RequestType)
+	}
+	if filter.Priority != "" {
+		db = db.Where("user_requests.priority = ?", filter.Priority)
+	}
+	if filter.AssignedTo > 0 {
+		db = db.Where("user_requests.handled_by_staff_id = ?", filter.AssignedTo)
+	}
+	if filter.Unassigned {
+		db = db.Where("user_requests.handled_by_staff_id IS NULL")
+	}
+	if query.Search != "" {
+		db = db.Where("user_requests.request_details ILIKE ?", "%"+escapeLike(query.Search)+"%")
+	}
+
+	slaParams := map[string]interface{}{"now": time.Now(), "ratio": model.SLAAtRiskRatio}
+	switch filter.SLAStatus {
+	case "":
+	case model.SLAStatusBreached:
+		db = db.Where(slaBreachedCondition, slaParams)
+	case model.SLAStatusAtRisk:
+		db = db.Where(slaAtRiskCondition+" AND NOT "+slaBreachedCondition, slaParams)
+	default:
+		return nil, fmt.Errorf("geçersiz SLA durumu filtresi: %s", filter.SLAStatus)
+	}
+
+	page, err := listquery.Paginate(db, query, RequestQueueSpec, func(request *model.UserRequest) (interface{}, uint) {
+		switch query.SortKey {
+		case "submitted_at":
+			return request.SubmittedAt, request.ID
+		case "updated_at":
+			return request.UpdatedAt, request.ID
+		default:
+			return request.ID, request.ID
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range page.Items {
+		setSLAStatus(&page.Items[i])
+	}
+	return page, nil
+}
+
+// GetQueueCounts her durumdaki talep sayısını döndürür.
+// staffID verilirse yalnızca o personele atanmış talepler sayılır.
+func (s *RequestService) GetQueueCounts(staffID uint) (map[string]int64, error) {
+	var rows []struct {
+		Status string
+		Count  int64
+	}
+
+	db := s.db.Model(&model.UserRequest{}).Select("status, COUNT(*) AS count").Group("status")
+	if staffID > 0 {
+		db = db.Where("handled_by_staff_id = ?", staffID)
+	}
+	if err := db.Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	counts := make(map[string]int64, len(model.RequestStatuses))
+	for _, status := range model.RequestStatuses {
+		counts[status] = 0
+	}
+	for _, row := range rows {
+		counts[row.Status] = row.Count
+	}
+	return counts, nil
+}
+
+// ClaimRequest atanmamış bir talebi personelin üzerine alır
+func (s *RequestService) ClaimRequest(requestID, staffID uint) (*model.UserRequest, error) {
+	err := runInTransaction(s.db, func(tx *gorm.DB) error {
+		request, err := lockRequest(tx, requestID)
+		if err != nil {
+			return err
+		}
+
+		if isRequestFinal(request.Status) {
+			return errors.New("kapanmış veya sonuçlanmış bir talep üstlenilemez")
+		}
+		if request.HandledByStaffID != nil {
+			if *request.HandledByStaffID == staffID {
+				return errors.New("talep zaten size atanmış")
+			}
+			return errors.New("talep başka bir personele atanmış")
+		}
+
+		return tx.Model(request).Update("handled_by_staff_id", staffID).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetRequestByID(requestID)
+}
+
+// AssignRequest talebi belirtilen personele atar veya staffID 0 ise atamayı kaldırır.
+// İşten ayrılmış veya onaylı izindeki personele atama yapılamaz.
+func (s *RequestService) AssignRequest(requestID, staffID uint) (*model.UserRequest, error) {
+	err := runInTransaction(s.db, func(tx *gorm.DB) error {
+		request, err := lockRequest(tx, requestID)
+		if err != nil {
+			return err
+		}
+
+		if isRequestFinal(request.Status) {
+			return errors.New("kapanmış veya sonuçlanmış bir talep atanamaz")
+		}
+
+		if staffID == 0 {
+			return tx.Model(request).Update("handled_by_staff_id", nil).Error
+		}
+
+		var staff model.Staff
+		if err := tx.First(&staff, staffID).Error; err != nil {
+			return errors.New("personel bulunamadı")
+		}
+		if staff.EmployeeStatus == model.EmployeeStatusTerminated {
+			return errors.New("işten ayrılmış personele talep atanamaz")
+		}
+		onLeave, err := (&LeaveService{db: tx}).IsStaffOnLeave(staffID, time.Now())
+		if err != nil {
+			return err
+		}
+		if onLeave {
+			return errors.New("izindeki personele talep atanamaz")
+		}
+
+		return tx.Model(request).Update("handled_by_staff_id", staffID).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetRequestByID(requestID)
+}
+
+// UpdateStatus talebin durumunu durum makinesine göre değiştirir.
+// in_progress geçişinde ProcessingStartedAt, resolved/rejected geçişinde CompletedAt damgalanır.
+// Talebi işleme alan personel atanmamışsa talep ona atanır. Talebi yalnızca atanan personel
+// veya Manager/Admin rolündeki personel kapatabilir.
+func (s *RequestService) UpdateStatus(requestID, staffID uint, update RequestStatusUpdate) (*model.UserRequest, error) {
+	if !model.IsValidRequestStatus(update.Status) {
+		return nil, errors.New("geçersiz talep durumu")
+	}
+
+	err := runInTransaction(s.db, func(tx *gorm.DB) error {
+		request, err := lockRequest(tx, requestID)
+		if err != nil {
+			return err
+		}
+
+		if !model.CanTransitionRequest(request.Status, update.Status) {
+			return errors.New("talep " + request.Status + " durumundan " + update.Status + " durumuna geçemez")
+		}
+
+		if request.HandledByStaffID == nil || *request.HandledByStaffID != staffID {
+			if update.Status == model.RequestStatusClosed {
+				// Talep sahibi kendi talebini CloseRequest ile kapatır; diğer personel için yönetici rolü gerekir
+				supervisor, err := staffHasAnyRole(tx, staffID, requestSupervisorRoles)
+				if err != nil {
+					return err
+				}
+				if !supervisor {
+					return errors.New("başka bir personele atanmış talebi yalnızca yöneticiler kapatabilir")
+				}
+			} else if request.HandledByStaffID != nil {
+				return errors.New("talep başka bir personele atanmış")
+			}
+		}
+
+		now := time.Now()
+		updates := map[string]interface{}{"status": update.Status}
+		switch update.Status {
+		case model.RequestStatusInProgress:
+			if request.ProcessingStartedAt == nil {
+				updates["processing_started_at"] = now
+			}
+			if request.FirstRespondedAt == nil {
+				updates["first_responded_at"] = now
+			}
+			if request.HandledByStaffID == nil {
+				updates["handled_by_staff_id"] = staffID
+			}
+		case model.RequestStatusResolved, model.RequestStatusRejected:
+			updates["completed_at"] = now
+		}
+
+		if update.DecisionNotes != nil {
+			updates["decision_notes"] = *update.DecisionNotes
+		}
+		if update.FollowUpRequired != nil {
+			updates["follow_up_required"] = *update.FollowUpRequired
+		}
+		if update.FollowUpDate != nil {
+			updates["follow_up_date"] = truncateDay(*update.FollowUpDate)
+			updates["follow_up_notified_at"] = nil
+		}
+
+		return tx.Model(request).Updates(updates).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	request, err := s.GetRequestByID(requestID)
+	if err != nil {
+		return nil, err
+	}
+
+	publishRequestStatus(request)
+	return request, nil
+}
+
+// CloseRequest talep sahibinin sonuçlanmış talebini kapatmasını sağlar
+func (s *RequestService) CloseRequest(requestID, userID uint) (*model.UserRequest, error) {
+	err := runInTransaction(s.db, func(tx *gorm.DB) error {
+		request, err := lockRequest(tx, requestID)
+		if err != nil {
+			return err
+		}
+
+		if request.UserID != userID {
+			return errors.New("yalnızca kendi talebinizi kapatabilirsiniz")
+		}
+		if !model.CanTransitionRequest(request.Status, model.RequestStatusClosed) {
+			return errors.New("yalnızca sonuçlanmış talepler kapatılabilir")
+		}
+
+		return tx.Model(request).Update("status", model.RequestStatusClosed).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	request, err := s.GetRequestByID(requestID)
+	if err != nil {
+		return nil, err
+	}
+
+	publishRequestStatus(request)
+	return request, nil
+}
+
+// DeleteRequest talebi geri yüklenebilir şekilde siler; yorumlar, revizyonlar ve ekler kalıcı silmeye kadar korunur
+func (s *RequestService) DeleteRequest(requestID uint) error {
+	result := s.db.Delete(&model.UserRequest{}, requestID)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("talep bulunamadı")
+	}
+	return nil
+}
+
+// RestoreRequest silinmiş bir talebi geri yükler
+func (s *RequestService) RestoreRequest(requestID uint) (*model.UserRequest, error) {
+	var request model.UserRequest
+	if err := s.db.Unscoped().First(&request, requestID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("talep bulunamadı")
+		}
+		return nil, err
+	}
+	if !request.DeletedAt.Valid {
+		return nil, errors.New("talep silinmemiş")
+	}
+
+	if err := s.db.Unscoped().Model(&request).Update("deleted_at", nil).Error; err != nil {
+		return nil, err
+	}
+	return s.GetRequestByID(requestID)
+}
+
+// SetFollowUp talebin takip ayarlarını günceller.
+// Talep başka bir personele atanmışsa yalnızca o personel değiştirebilir.
+func (s *RequestService) SetFollowUp(requestID, staffID uint, update FollowUpUpdate) (*model.UserRequest, error) {
+	if update.Required && update.Date == nil {
+		return nil, errors.New("takip tarihi zorunludur")
+	}
+
+	err := runInTransaction(s.db, func(tx *gorm.DB) error {
+		request, err := lockRequest(tx, requestID)
+		if err != nil {
+			return err
+		}
+
+		if request.Status == model.RequestStatusClosed {
+			return errors.New("kapatılmış talep için takip ayarlanamaz")
+		}
+		if request.HandledByStaffID != nil && *request.HandledByStaffID != staffID {
+			return errors.New("talep başka bir personele atanmış")
+		}
+
+		updates := map[string]interface{}{
+			"follow_up_required":    update.Required,
+			"follow_up_reopen":      update.Reopen,
+			"follow_up_notified_at": nil,
+		}
+		if update.Date != nil {
+			updates["follow_up_date"] = truncateDay(*update.Date)
+		} else {
+			updates["follow_up_date"] = nil
+		}
+
+		return tx.Model(request).Updates(updates).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetRequestByID(requestID)
+}
+
+// GetFollowUps personele atanmış takip bekleyen talepleri getirir.
+// Tarihi geçmiş takiplerin tamamı, yaklaşan takiplerden ise önümüzdeki days gün içindekiler döner.
+func (s *RequestService) GetFollowUps(staffID uint, days int) (*FollowUps, error) {
+	today := truncateDay(time.Now())
+	horizon := today.AddDate(0, 0, days+1)
+
+	var requests []model.UserRequest
+	result := s.db.Preload("User").
+		Where("handled_by_staff_id = ? AND follow_up_required = ? AND follow_up_date IS NOT NULL", staffID, true).
+		Where("status <> ? AND follow_up_date < ?", model.RequestStatusClosed, horizon).
+		Order("follow_up_date, id").
+		Find(&requests)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	followUps := &FollowUps{
+		Overdue:  []model.UserRequest{},
+		Upcoming: []model.UserRequest{},
+	}
+	for i := range requests {
+		setSLAStatus(&requests[i])
+		if requests[i].FollowUpDate.Before(today) {
+			followUps.Overdue = append(followUps.Overdue, requests[i])
+		} else {
+			followUps.Upcoming = append(followUps.Upcoming, requests[i])
+		}
+	}
+	return followUps, nil
+}
+
+// ProcessDueFollowUps takip tarihi gelmiş talepler için işleyen personele bildirim gönderir.
+// FollowUpReopen işaretli çözülmüş talepler yeniden işleme alınır. Her takip yalnızca bir kez işlenir.
+func (s *RequestService) ProcessDueFollowUps(ctx context.Context) error {
+	tomorrow := truncateDay(time.Now()).AddDate(0, 0, 1)
+
+	var ids []uint
+	err := s.db.WithContext(ctx).Model(&model.UserRequest{}).
+		Where("follow_up_required = ? AND follow_up_date < ? AND follow_up_notified_at IS NULL", true, tomorrow).
+		Where("status <> ?", model.RequestStatusClosed).
+		Pluck("id", &ids).Error
+	if err != nil {
+		return err
+	}
+
+	for _, id := range ids {
+		if err := s.processFollowUp(id); err != nil {
+			log.Printf("Talep takibi işlenemedi (talep %d): %v", id, err)
+		}
+	}
+	return nil
+}
+
+// processFollowUp tek bir talebin takibini işler
+func (s *RequestService) processFollowUp(requestID uint) error {
+	var request *model.UserRequest
+	reopened := false
+
+	err := runInTransaction(s.db, func(tx *gorm.DB) error {
+		var err error
+		request, err = lockRequest(tx, requestID)
+		if err != nil {
+			return err
+		}
+
+		// İşlem sırasında başka bir çalışma takibi işlemiş olabilir
+		if request.FollowUpNotifiedAt != nil || !request.FollowUpRequired {
+			request = nil
+			return nil
+		}
+
+		updates := map[string]interface{}{"follow_up_notified_at": time.Now()}
+		if request.FollowUpReopen && request.Status == model.RequestStatusResolved {
+			updates["status"] = model.RequestStatusInProgress
+			updates["completed_at"] = nil
+			reopened = true
+		}
+
+		return tx.Model(request).Updates(updates).Error
+	})
+	if err != nil || request == nil {
+		return err
+	}
+
+	if reopened {
+		request.Status = model.RequestStatusInProgress
+		publishRequestStatus(request)
+	}
+
+	if request.HandledByStaffID == nil {
+		log.Printf("Takip tarihi gelen talep kimseye atanmamış (talep %d)", request.ID)
+		return nil
+	}
+
+	var staff model.Staff
+	if err := s.db.First(&staff, *request.HandledByStaffID).Error; err != nil {
+		return err
+	}
+
+	_, err = s.notifications.Notify(staff.UserID, Notification{
+		Category: model.NotificationCategoryRequests,
+		Template: "request_follow_up",
+		Data: map[string]interface{}{
+			"RequestID":   request.ID,
+			"RequestType": request.RequestType,
+			"Reopened":    reopened,
+		},
+	})
+	return err
+}
+
+// routing aynı bağlantıyı kullanan bir yönlendirme servisi döndürür
+func (s *RequestService) routing() *RoutingService {
+	return &RoutingService{db: s.db, notifications: s.notifications}
+}
+
+// sla aynı bağlantıyı kullanan bir SLA servisi döndürür
+func (s *RequestService) sla() *SLAService {
+	return &SLAService{db: s.db}
+}
+
+// lockRequest talebi işlem sonuna kadar satır kilidiyle getirir
+func lockRequest(tx *gorm.DB, requestID uint) (*model.UserRequest, error) {
+	var request model.UserRequest
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&request, requestID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("talep bulunamadı")
+		}
+		return nil, err
+	}
+	return &request, nil
+}
+
+// staffHasAnyRole personelin kullanıcısının verilen rollerden birine sahip olup olmadığını döndürür
+func staffHasAnyRole(tx *gorm.DB, staffID uint, roleNames []string) (bool, error) {
+	var count int64
+	err := tx.Table("staffs").
+		Joins("JOIN user_roles ON user_roles.user_id = staffs.user_id").
+		Joins("JOIN roles ON roles.role_id = user_roles.role_id").
+		Where("staffs.staff_id = ? AND roles.role_name IN ?", staffID, roleNames).
+		Where("staffs.deleted_at IS NULL AND roles.deleted_at IS NULL").
+		Count(&count).Error
+	return count > 0, err
+}
+
+// isRequestFinal talebin artık işlem görmeyecek bir durumda olup olmadığını döndürür
+func isRequestFinal(status string) bool {
+	return status == model.RequestStatusResolved || status == model.RequestStatusRejected || status == model.RequestStatusClosed
+}
@@ -0,0 +1,196 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/UmutTKMN/go-backend/internal/app/model"
+	"github.com/UmutTKMN/go-backend/internal/pkg/database"
+	"github.com/UmutTKMN/go-backend/internal/pkg/storage"
+	"gorm.io/gorm"
+)
+
+// DefaultSoftDeleteRetention silinen kayıtların kalıcı olarak silinmeden önce saklandığı varsayılan süre
+const DefaultSoftDeleteRetention = 90 * 24 * time.Hour
+
+// RetentionService geri yüklenebilir şekilde silinmiş kayıtları saklama süresi dolduğunda kalıcı olarak siler.
+// Kullanıcı satırları geçmiş kayıtlarla bağlantı korunsun diye silinmez, kişisel verileri anonimleştirilir.
+type RetentionService struct {
+	db        *gorm.DB
+	storage   storage.Storage
+	retention time.Duration
+	deletions *AccountDeletionService
+}
+
+// NewRetentionService yeni bir RetentionService örneği oluşturur.
+// retention sıfır veya negatifse DefaultSoftDeleteRetention kullanılır.
+func NewRetentionService(store storage.Storage, retention time.Duration, deletions *AccountDeletionService) *RetentionService {
+	if retention <= 0 {
+		retention = DefaultSoftDeleteRetention
+	}
+	return &RetentionService{
+		db:        database.DB,
+		storage:   store,
+		retention: retention,
+		deletions: deletions,
+	}
+}
+
+// PurgeDeleted saklama süresi dolan silinmiş belgeleri, talepleri, personel kayıtlarını ve rolleri kalıcı olarak
+// siler, silinmiş kullanıcıları anonimleştirir. Başka kayıtlarca hala kullanılan satırlar atlanır ve günlüğe yazılır.
+func (s *RetentionService) PurgeDeleted(ctx context.Context) error {
+	cutoff := time.Now().Add(-s.retention)
+
+	for _, purge := range []func(context.Context, time.Time) error{
+		s.purgeDocuments,
+		s.purgeRequests,
+		s.purgeStaff,
+		s.purgeRoles,
+		s.purgeUsers,
+	} {
+		if err := purge(ctx, cutoff); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// purgeDocuments silinmiş belgeleri ve dosyalarını kalıcı olarak siler
+func (s *RetentionService) purgeDocuments(ctx context.Context, cutoff time.Time) error {
+	var documents []model.UserDocument
+	if err := s.expired(ctx, cutoff).Find(&documents).Error; err != nil {
+		return err
+	}
+
+	for _, document := range documents {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := s.db.WithContext(ctx).Unscoped().Delete(&model.UserDocument{}, document.ID).Error; err != nil {
+			log.Printf("Silinmiş belge kalıcı olarak silinemedi (belge %d): %v", document.ID, err)
+			continue
+		}
+		s.removeFile(ctx, document.DocumentPath)
+	}
+	return nil
+}
+
+// purgeRequests silinmiş talepleri yorumları, yorum revizyonları ve ekleriyle birlikte kalıcı olarak siler
+func (s *RetentionService) purgeRequests(ctx context.Context, cutoff time.Time) error {
+	var ids []uint
+	if err := s.expired(ctx, cutoff).Model(&model.UserRequest{}).Pluck("id", &ids).Error; err != nil {
+		return err
+	}
+
+	for _, id := range ids {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		var files []string
+		err := runInTransaction(s.db.WithContext(ctx), func(tx *gorm.DB) error {
+			const ofRequest = "comment_id IN (SELECT id FROM request_comments WHERE request_id = ?)"
+			if err := tx.Model(&model.RequestCommentAttachment{}).Where(ofRequest, id).Pluck("storage_key", &files).Error; err != nil {
+				return err
+			}
+
+			for _, record := range []interface{}{&model.RequestCommentAttachment{}, &model.RequestCommentRevision{}} {
+				if err := tx.Where(ofRequest, id).Delete(record).Error; err != nil {
+					return err
+				}
+			}
+			if err := tx.Where("request_id = ?", id).Delete(&model.RequestComment{}).Error; err != nil {
+				return err
+			}
+			return tx.Unscoped().Delete(&model.UserRequest{}, id).Error
+		})
+		if err != nil {
+			log.Printf("Silinmiş talep kalıcı olarak silinemedi (talep %d): %v", id, err)
+			continue
+		}
+
+		for _, key := range files {
+			s.removeFile(ctx, key)
+		}
+	}
+	return nil
+}
+
+// purgeStaff silinmiş personel kayıtlarını kalıcı olarak siler. İzin, vekalet veya talep geçmişinde
+// kullanılan kayıtlar yabancı anahtar nedeniyle silinemez ve atlanır.
+func (s *RetentionService) purgeStaff(ctx context.Context, cutoff time.Time) error {
+	var ids []uint
+	if err := s.expired(ctx, cutoff).Model(&model.Staff{}).Pluck("staff_id", &ids).Error; err != nil {
+		return err
+	}
+
+	for _, id := range ids {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := s.db.WithContext(ctx).Unscoped().Delete(&model.Staff{}, id).Error; err != nil {
+			log.Printf("Silinmiş personel kaydı kalıcı olarak silinemedi (personel %d): %v", id, err)
+		}
+	}
+	return nil
+}
+
+// purgeRoles silinmiş rolleri kullanıcı atamalarıyla birlikte kalıcı olarak siler.
+// Personel kayıtlarında veya yönlendirme kurallarında kullanılan roller atlanır.
+func (s *RetentionService) purgeRoles(ctx context.Context, cutoff time.Time) error {
+	var ids []uint
+	if err := s.expired(ctx, cutoff).Model(&model.Role{}).Pluck("role_id", &ids).Error; err != nil {
+		return err
+	}
+
+	for _, id := range ids {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		err := runInTransaction(s.db.WithContext(ctx), func(tx *gorm.DB) error {
+			if err := tx.Exec("DELETE FROM user_roles WHERE role_id = ?", id).Error; err != nil {
+				return err
+			}
+			return tx.Unscoped().Delete(&model.Role{}, id).Error
+		})
+		if err != nil {
+			log.Printf("Silinmiş rol kalıcı olarak silinemedi (rol %d): %v", id, err)
+		}
+	}
+	return nil
+}
+
+// purgeUsers silinmiş ve henüz anonimleştirilmemiş kullanıcıların kişisel verilerini temizler
+func (s *RetentionService) purgeUsers(ctx context.Context, cutoff time.Time) error {
+	var ids []uint
+	err := s.expired(ctx, cutoff).Model(&model.User{}).
+		Where("anonymized_at IS NULL").
+		Pluck("id", &ids).Error
+	if err != nil {
+		return err
+	}
+
+	for _, id := range ids {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := s.deletions.Anonymize(ctx, id, DeletedBefore(cutoff)); err != nil {
+			log.Printf("Silinmiş kullanıcı anonimleştirilemedi (kullanıcı %d): %v", id, err)
+		}
+	}
+	return nil
+}
+
+// expired saklama süresi dolmuş silinmiş kayıtları seçen sorguyu döndürür
+func (s *RetentionService) expired(ctx context.Context, cutoff time.Time) *gorm.DB {
+	return s.db.WithContext(ctx).Unscoped().Where("deleted_at IS NOT NULL AND deleted_at <= ?", cutoff)
+}
+
+// removeFile depolamadaki dosyayı siler; hata yalnızca günlüğe kaydedilir
+func (s *RetentionService) removeFile(ctx context.Context, key string) {
+	if err := s.storage.Delete(ctx, key); err != nil && !errors.Is(err, storage.ErrNotFound) {
+		log.Printf("Silinmiş kaydın dosyası kaldırılamadı (%s): %v", key, err)
+	}
+}
@@ -6,6 +6,13 @@ import (
 	"github.com/UmutTKMN/go-backend/internal/app/model"
 	"github.com/UmutTKMN/go-backend/internal/pkg/database"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Rol adı benzersizlik hataları; rol adı silinmiş roller dahil benzersizdir
+var (
+	ErrRoleNameTaken   = errors.New("bu rol adı zaten kullanılıyor")
+	ErrRoleNameDeleted = errors.New("bu adla silinmiş bir rol var; yeni rol oluşturmak yerine silinmiş rolü geri yükleyin")
 )
 
 // RoleService rol işlemleri için servis
@@ -20,10 +27,14 @@ func NewRoleService() *RoleService {
 	}
 }
 
-// GetAllRoles tüm rolleri getirir
-func (s *RoleService) GetAllRoles() ([]model.Role, error) {
+// GetAllRoles tüm rolleri getirir; includeDeleted true ise silinmiş roller de listelenir
+func (s *RoleService) GetAllRoles(includeDeleted bool) ([]model.Role, error) {
 	var roles []model.Role
-	result := s.db.Find(&roles)
+	db := s.db
+	if includeDeleted {
+		db = db.Unscoped()
+	}
+	result := db.Find(&roles)
 	return roles, result.Error
 }
 
@@ -42,79 +53,134 @@ func (s *RoleService) GetRoleByID(id uint) (*model.Role, error) {
 
 // CreateRole yeni bir rol oluşturur
 func (s *RoleService) CreateRole(role *model.Role) error {
-	return s.db.Create(role).Error
+	return runInTransaction(s.db, func(tx *gorm.DB) error {
+		if err := ensureRoleNameAvailable(tx, 0, role.RoleName); err != nil {
+			return err
+		}
+		return tx.Create(role).Error
+	})
 }
 
 // UpdateRole bir rolü günceller
 func (s *RoleService) UpdateRole(role *model.Role) error {
-	result := s.db.First(&model.Role{}, role.ID)
-	if result.Error != nil {
-		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
-			return errors.New("rol bulunamadı")
+	return runInTransaction(s.db, func(tx *gorm.DB) error {
+		if err := tx.First(&model.Role{}, role.ID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.New("rol bulunamadı")
+			}
+			return err
 		}
-		return result.Error
-	}
+		if err := ensureRoleNameAvailable(tx, role.ID, role.RoleName); err != nil {
+			return err
+		}
+
+		return tx.Save(role).Error
+	})
+}
 
-	return s.db.Save(role).Error
+// ensureRoleNameAvailable rol adının başka bir rolde kullanılmadığını doğrular. Ad veritabanında
+// silinmiş roller için de benzersiz olduğundan silinmiş rolün adı ayrı bir hatayla bildirilir.
+func ensureRoleNameAvailable(tx *gorm.DB, excludeID uint, name string) error {
+	var existing model.Role
+	err := tx.Unscoped().Where("role_name = ? AND role_id <> ?", name, excludeID).First(&existing).Error
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return nil
+	case err != nil:
+		return err
+	case existing.DeletedAt.Valid:
+		return ErrRoleNameDeleted
+	default:
+		return ErrRoleNameTaken
+	}
 }
 
-// DeleteRole bir rolü siler
+// DeleteRole bir rolü geri yüklenebilir şekilde siler.
+// Kullanım kontrolü ve silme aynı işlem içinde yapılır, böylece arada atanan rol silinmez.
+// Kullanıcı-rol atamaları korunur ancak rol sorgularında silinmiş roller dikkate alınmaz; rol geri
+// yüklendiğinde atamalar da geri gelir.
 func (s *RoleService) DeleteRole(id uint) error {
-	// Sistem rollerini silmeyi engelle
+	return runInTransaction(s.db, func(tx *gorm.DB) error {
+		// Sistem rollerini silmeyi engelle
+		var role model.Role
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&role, id).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.New("rol bulunamadı")
+			}
+			return err
+		}
+
+		if role.IsSystemRole {
+			return errors.New("sistem rolleri silinemez")
+		}
+
+		// Rol kullanılıyor mu kontrol et
+		var count int64
+		if err := tx.Model(&model.Staff{}).Where("role_id = ?", id).Count(&count).Error; err != nil {
+			return err
+		}
+
+		if count > 0 {
+			return errors.New("bu rol hala personel tarafından kullanılıyor ve silinemez")
+		}
+
+		return tx.Delete(&model.Role{}, id).Error
+	})
+}
+
+// RestoreRole silinmiş bir rolü geri yükler
+func (s *RoleService) RestoreRole(id uint) (*model.Role, error) {
 	var role model.Role
-	if err := s.db.First(&role, id).Error; err != nil {
+	if err := s.db.Unscoped().First(&role, id).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return errors.New("rol bulunamadı")
+			return nil, errors.New("rol bulunamadı")
 		}
-		return err
+		return nil, err
 	}
-
-	if role.IsSystemRole {
-		return errors.New("sistem rolleri silinemez")
+	if !role.DeletedAt.Valid {
+		return nil, errors.New("rol silinmemiş")
 	}
 
-	// Rol kullanılıyor mu kontrol et
-	var count int64
-	if err := s.db.Model(&model.Staff{}).Where("role_id = ?", id).Count(&count).Error; err != nil {
-		return err
-	}
-
-	if count > 0 {
-		return errors.New("bu rol hala personel tarafından kullanılıyor ve silinemez")
+	if err := s.db.Unscoped().Model(&role).Update("deleted_at", nil).Error; err != nil {
+		return nil, err
 	}
-
-	return s.db.Delete(&model.Role{}, id).Error
+	role.DeletedAt = gorm.DeletedAt{}
+	return &role, nil
 }
 
 // AssignRoleToUser kullanıcıya bir rol atar
 func (s *RoleService) AssignRoleToUser(userID, roleID uint) error {
-	var user model.User
-	if err := s.db.First(&user, userID).Error; err != nil {
-		return errors.New("kullanıcı bulunamadı")
-	}
+	return runInTransaction(s.db, func(tx *gorm.DB) error {
+		var user model.User
+		if err := tx.First(&user, userID).Error; err != nil {
+			return errors.New("kullanıcı bulunamadı")
+		}
 
-	var role model.Role
-	if err := s.db.First(&role, roleID).Error; err != nil {
-		return errors.New("rol bulunamadı")
-	}
+		var role model.Role
+		if err := tx.First(&role, roleID).Error; err != nil {
+			return errors.New("rol bulunamadı")
+		}
 
-	// Many-to-many ilişki
-	return s.db.Model(&user).Association("Roles").Append(&role)
+		// Many-to-many ilişki
+		return tx.Model(&user).Association("Roles").Append(&role)
+	})
 }
 
 // RemoveRoleFromUser kullanıcıdan bir rolü kaldırır
 func (s *RoleService) RemoveRoleFromUser(userID, roleID uint) error {
-	var user model.User
-	if err := s.db.First(&user, userID).Error; err != nil {
-		return errors.New("kullanıcı bulunamadı")
-	}
+	return runInTransaction(s.db, func(tx *gorm.DB) error {
+		var user model.User
+		if err := tx.First(&user, userID).Error; err != nil {
+			return errors.New("kullanıcı bulunamadı")
+		}
 
-	var role model.Role
-	if err := s.db.First(&role, roleID).Error; err != nil {
-		return errors.New("rol bulunamadı")
-	}
+		var role model.Role
+		if err := tx.First(&role, roleID).Error; err != nil {
+			return errors.New("rol bulunamadı")
+		}
 
-	return s.db.Model(&user).Association("Roles").Delete(&role)
+		return tx.Model(&user).Association("Roles").Delete(&role)
+	})
 }
 
 // GetUserRoles kullanıcının rollerini getirir
@@ -139,7 +205,7 @@ func (s *RoleService) HasRole(userID uint, roleName string) (bool, error) {
 		SELECT COUNT(*) FROM users u 
 		JOIN user_roles ur ON u.id = ur.user_id 
 		JOIN roles r ON ur.role_id = r.role_id 
-		WHERE u.id = ? AND r.role_name = ?
+		WHERE u.id = ? AND r.role_name = ? AND u.deleted_at IS NULL AND r.deleted_at IS NULL
 	`, userID, roleName).Count(&count).Error
 
 	return count > 0, err
@@ -172,6 +238,8 @@ func (s *RoleService) GetAllUserRoleDetails() ([]UserRoleDetail, error) {
 			user_roles ur ON u.id = ur.user_id
 		JOIN 
 			roles r ON ur.role_id = r.role_id
+		WHERE 
+			u.deleted_at IS NULL AND r.deleted_at IS NULL
 		ORDER BY 
 			u.id, r.permission_level DESC
 	`).Scan(&details).Error
@@ -197,7 +265,7 @@ func (s *RoleService) GetUserRoleDetailsByUser(userID uint) ([]UserRoleDetail, e
 		JOIN 
 			roles r ON ur.role_id = r.role_id
 		WHERE 
-			u.id = ?
+			u.id = ? AND u.deleted_at IS NULL AND r.deleted_at IS NULL
 		ORDER BY 
 			r.permission_level DESC
 	`, userID).Scan(&details).Error
@@ -223,7 +291,7 @@ func (s *RoleService) GetUserRoleDetailsByRole(roleID uint) ([]UserRoleDetail, e
 		JOIN 
 			roles r ON ur.role_id = r.role_id
 		WHERE 
-			r.role_id = ?
+			r.role_id = ? AND u.deleted_at IS NULL AND r.deleted_at IS NULL
 		ORDER BY 
 			u.id
 	`, roleID).Scan(&details).Error
@@ -0,0 +1,35 @@
+package services
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/UmutTKMN/go-backend/internal/app/model"
+)
+
+func TestCreateRoleRejectsNamesOfDeletedRoles(t *testing.T) {
+	db := openTestDB(t)
+	user := createTestUser(t, db, "role_name_reuse")
+	service := &RoleService{db: db}
+
+	role := createTestRole(t, db, "role_name_reuse", user.ID)
+	if err := service.CreateRole(&model.Role{RoleName: role.RoleName, CreatedBy: user.ID}); !errors.Is(err, ErrRoleNameTaken) {
+		t.Errorf("aktif rol adı için beklenen hata %v, alınan %v", ErrRoleNameTaken, err)
+	}
+
+	if err := service.DeleteRole(role.ID); err != nil {
+		t.Fatalf("rol silinemedi: %v", err)
+	}
+	if err := service.CreateRole(&model.Role{RoleName: role.RoleName, CreatedBy: user.ID}); !errors.Is(err, ErrRoleNameDeleted) {
+		t.Errorf("silinmiş rol adı için beklenen hata %v, alınan %v", ErrRoleNameDeleted, err)
+	}
+
+	// Rol kendi adıyla güncellenebilir
+	if _, err := service.RestoreRole(role.ID); err != nil {
+		t.Fatalf("rol geri yüklenemedi: %v", err)
+	}
+	role.Description = "güncellendi"
+	if err := service.UpdateRole(role); err != nil {
+		t.Errorf("rol güncellenemedi: %v", err)
+	}
+}
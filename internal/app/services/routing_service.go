@@ -0,0 +1,369 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/UmutTKMN/go-backend/internal/app/model"
+	"github.com/UmutTKMN/go-backend/internal/pkg/database"
+	"github.com/UmutTKMN/go-backend/internal/pkg/shift"
+	"gorm.io/gorm"
+)
+
+// openRequestStatuses personelin iş yükünde sayılan talep durumları
+var openRequestStatuses = []string{model.RequestStatusSubmitted, model.RequestStatusInProgress}
+
+// RoutingService talep yönlendirme kuralları ve otomatik atama için servis
+type RoutingService struct {
+	db            *gorm.DB
+	notifications *NotificationService
+}
+
+// NewRoutingService yeni bir RoutingService örneği oluşturur
+func NewRoutingService() *RoutingService {
+	return &RoutingService{
+		db:            database.DB,
+		notifications: NewNotificationService(),
+	}
+}
+
+// GetAllRules tüm yönlendirme kurallarını uygulanma sırasına göre getirir
+func (s *RoutingService) GetAllRules() ([]model.RoutingRule, error) {
+	var rules []model.RoutingRule
+	result := s.db.Preload("Role").Order("sort_order, id").Find(&rules)
+	return rules, result.Error
+}
+
+// CreateRule yeni bir yönlendirme kuralı oluşturur
+func (s *RoutingService) CreateRule(rule *model.RoutingRule) error {
+	if err := s.validateRule(rule); err != nil {
+		return err
+	}
+	return s.db.Omit("Role").Create(rule).Error
+}
+
+// UpdateRule bir yönlendirme kuralını günceller
+func (s *RoutingService) UpdateRule(rule *model.RoutingRule) error {
+	if err := s.db.First(&model.RoutingRule{}, rule.ID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("yönlendirme kuralı bulunamadı")
+		}
+		return err
+	}
+
+	if err := s.validateRule(rule); err != nil {
+		return err
+	}
+
+	return s.db.Omit("Role").Save(rule).Error
+}
+
+// DeleteRule bir yönlendirme kuralını siler; kuralla atanmış talepler atanmış olarak kalır
+func (s *RoutingService) DeleteRule(id uint) error {
+	return runInTransaction(s.db, func(tx *gorm.DB) error {
+		if err := tx.First(&model.RoutingRule{}, id).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.New("yönlendirme kuralı bulunamadı")
+			}
+			return err
+		}
+
+		if err := tx.Model(&model.UserRequest{}).Where("routing_rule_id = ?", id).
+			Update("routing_rule_id", nil).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&model.RoutingRule{}, id).Error
+	})
+}
+
+// RouteRequest atanmamış talebi eşleşen kurala göre en az açık talebi olan müsait personele atar.
+// Eşleşen kural veya müsait personel yoksa talep atanmamış kalır ve nil döner.
+func (s *RoutingService) RouteRequest(requestID uint) (*uint, error) {
+	var assigned *model.Staff
+	err := runInTransaction(s.db, func(tx *gorm.DB) error {
+		request, err := lockRequest(tx, requestID)
+		if err != nil {
+			return err
+		}
+		if request.HandledByStaffID != nil || isRequestFinal(request.Status) {
+			return nil
+		}
+
+		rule, err := s.matchRule(tx, request)
+		if err != nil || rule == nil {
+			return err
+		}
+
+		assigned, err = s.pickStaff(tx, rule, nil, time.Now())
+		if err != nil || assigned == nil {
+			return err
+		}
+
+		return tx.Model(request).Updates(map[string]interface{}{
+			"handled_by_staff_id": assigned.ID,
+			"routing_rule_id":     rule.ID,
+		}).Error
+	})
+	if err != nil || assigned == nil {
+		return nil, err
+	}
+
+	s.notifyAssignee(assigned, requestID)
+	return &assigned.ID, nil
+}
+
+// RouteRequests zamanlayıcı tarafından çalıştırılır. Önce izinde veya işten ayrılmış personelin
+// açık taleplerini başka personele aktarır, ardından atanmamış yeni talepleri yönlendirir.
+func (s *RoutingService) RouteRequests(ctx context.Context) error {
+	if err := s.reassignFromUnavailable(ctx); err != nil {
+		return err
+	}
+
+	var ids []uint
+	err := s.db.WithContext(ctx).Model(&model.UserRequest{}).
+		Where("status = ? AND handled_by_staff_id IS NULL", model.RequestStatusSubmitted).
+		Order("submitted_at").
+		Pluck("id", &ids).Error
+	if err != nil {
+		return err
+	}
+
+	for _, id := range ids {
+		if _, err := s.RouteRequest(id); err != nil {
+			log.Printf("Talep yönlendirilemedi (talep %d): %v", id, err)
+		}
+	}
+	return nil
+}
+
+// reassignFromUnavailable izinde, işten ayrılmış veya silinmiş personele atanmış açık talepleri
+// aynı kurala göre başka bir müsait personele aktarır; uygun personel yoksa atamayı kaldırır
+func (s *RoutingService) reassignFromUnavailable(ctx context.Context) error {
+	now := time.Now()
+	onLeave, err := s.leave().GetUnavailableStaffIDs(now, now)
+	if err != nil {
+		return err
+	}
+
+	db := s.db.WithContext(ctx).Model(&model.UserRequest{}).
+		Joins("JOIN staffs ON staffs.staff_id = user_requests.handled_by_staff_id").
+		Where("user_requests.status IN ?", openRequestStatuses)
+	unavailable := []string{model.EmployeeStatusOnLeave, model.EmployeeStatusTerminated}
+	if len(onLeave) > 0 {
+		db = db.Where("(staffs.employee_status IN ? OR staffs.staff_id IN ? OR staffs.deleted_at IS NOT NULL)", unavailable, onLeave)
+	} else {
+		db = db.Where("(staffs.employee_status IN ? OR staffs.deleted_at IS NOT NULL)", unavailable)
+	}
+
+	var ids []uint
+	if err := db.Pluck("user_requests.id", &ids).Error; err != nil {
+		return err
+	}
+
+	for _, id := range ids {
+		if err := s.reassign(id, now); err != nil {
+			log.Printf("Talep yeniden atanamadı (talep %d): %v", id, err)
+		}
+	}
+	return nil
+}
+
+// reassign tek bir talebi mevcut personel dışındaki müsait personele aktarır
+func (s *RoutingService) reassign(requestID uint, at time.Time) error {
+	var assigned *model.Staff
+	err := runInTransaction(s.db, func(tx *gorm.DB) error {
+		request, err := lockRequest(tx, requestID)
+		if err != nil {
+			return err
+		}
+		if request.HandledByStaffID == nil || isRequestFinal(request.Status) {
+			return nil
+		}
+
+		var rule *model.RoutingRule
+		if request.RoutingRuleID != nil {
+			var stored model.RoutingRule
+			if err := tx.Where("id = ? AND is_active = ?", *request.RoutingRuleID, true).Limit(1).Find(&stored).Error; err != nil {
+				return err
+			}
+			if stored.ID != 0 {
+				rule = &stored
+			}
+		}
+		if rule == nil {
+			if rule, err = s.matchRule(tx, request); err != nil {
+				return err
+			}
+		}
+
+		updates := map[string]interface{}{"handled_by_staff_id": nil}
+		if rule != nil {
+			assigned, err = s.pickStaff(tx, rule, []uint{*request.HandledByStaffID}, at)
+			if err != nil {
+				return err
+			}
+			if assigned != nil {
+				updates["handled_by_staff_id"] = assigned.ID
+				updates["routing_rule_id"] = rule.ID
+			}
+		}
+
+		return tx.Model(request).Updates(updates).Error
+	})
+	if err != nil {
+		return err
+	}
+
+	if assigned != nil {
+		s.notifyAssignee(assigned, requestID)
+	}
+	return nil
+}
+
+// matchRule talebe uyan ilk aktif kuralı bulur; uyan kural yoksa nil döner
+func (s *RoutingService) matchRule(tx *gorm.DB, request *model.UserRequest) (*model.RoutingRule, error) {
+	var user model.User
+	if err := tx.First(&user, request.UserID).Error; err != nil {
+		return nil, errors.New("talep sahibi bulunamadı")
+	}
+
+	var rules []model.RoutingRule
+	if err := tx.Where("is_active = ?", true).Order("sort_order, id").Find(&rules).Error; err != nil {
+		return nil, err
+	}
+
+	for i := range rules {
+		if rules[i].Matches(request, &user) {
+			return &rules[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// staffLocation personelin kullanıcı hesabındaki saat dilimini döndürür; tanımsız veya geçersizse UTC kullanılır
+func staffLocation(staff *model.Staff) *time.Location {
+	if staff.User == nil || staff.User.Timezone == "" {
+		return time.UTC
+	}
+	location, err := time.LoadLocation(staff.User.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return location
+}
+
+// pickStaff kuralın hedef grubundaki aktif, izinde olmayan ve kendi saat dilimine göre vardiyada olan
+// personelden en az açık talebi olanı seçer. Eşitlikte personel ID'si küçük olan seçilir.
+func (s *RoutingService) pickStaff(tx *gorm.DB, rule *model.RoutingRule, exclude []uint, at time.Time) (*model.Staff, error) {
+	db := tx.Where("employee_status = ?", model.EmployeeStatusActive)
+	if rule.Department != "" {
+		db = db.Where("department = ?", rule.Department)
+	}
+	if rule.RoleID != nil {
+		db = db.Where("role_id = ?", *rule.RoleID)
+	}
+	if len(exclude) > 0 {
+		db = db.Where("staff_id NOT IN ?", exclude)
+	}
+
+	var candidates []model.Staff
+	if err := db.Preload("User").Order("staff_id").Find(&candidates).Error; err != nil {
+		return nil, err
+	}
+
+	onLeave, err := (&LeaveService{db: tx}).GetUnavailableStaffIDs(at, at)
+	if err != nil {
+		return nil, err
+	}
+	unavailable := make(map[uint]bool, len(onLeave))
+	for _, id := range onLeave {
+		unavailable[id] = true
+	}
+
+	eligible := make([]model.Staff, 0, len(candidates))
+	ids := make([]uint, 0, len(candidates))
+	for _, candidate := range candidates {
+		if unavailable[candidate.ID] {
+			continue
+		}
+		schedule, err := shift.Parse(candidate.ShiftPattern)
+		if err != nil {
+			log.Printf("Personelin vardiya düzeni çözümlenemedi (personel %d): %v", candidate.ID, err)
+			continue
+		}
+		if !schedule.IsOnShift(at.In(staffLocation(&candidate))) {
+			continue
+		}
+		eligible = append(eligible, candidate)
+		ids = append(ids, candidate.ID)
+	}
+	if len(eligible) == 0 {
+		return nil, nil
+	}
+
+	var loads []struct {
+		HandledByStaffID uint
+		Count            int64
+	}
+	if err := tx.Model(&model.UserRequest{}).
+		Select("handled_by_staff_id, COUNT(*) AS count").
+		Where("status IN ? AND handled_by_staff_id IN ?", openRequestStatuses, ids).
+		Group("handled_by_staff_id").
+		Scan(&loads).Error; err != nil {
+		return nil, err
+	}
+	openCounts := make(map[uint]int64, len(loads))
+	for _, load := range loads {
+		openCounts[load.HandledByStaffID] = load.Count
+	}
+
+	best := &eligible[0]
+	for i := 1; i < len(eligible); i++ {
+		if openCounts[eligible[i].ID] < openCounts[best.ID] {
+			best = &eligible[i]
+		}
+	}
+	return best, nil
+}
+
+// notifyAssignee personele yeni atanan talebi bildirir; hata yalnızca günlüğe kaydedilir
+func (s *RoutingService) notifyAssignee(staff *model.Staff, requestID uint) {
+	if s.notifications == nil {
+		return
+	}
+
+	_, err := s.notifications.Notify(staff.UserID, Notification{
+		Category: model.NotificationCategoryRequests,
+		Template: "request_assigned",
+		Data:     map[string]interface{}{"RequestID": requestID},
+	})
+	if err != nil {
+		log.Printf("Atama bildirimi gönderilemedi (talep %d): %v", requestID, err)
+	}
+}
+
+// validateRule yönlendirme kuralı alanlarını doğrular
+func (s *RoutingService) validateRule(rule *model.RoutingRule) error {
+	if rule.Name == "" {
+		return errors.New("kural adı zorunludur")
+	}
+	if rule.Department == "" && rule.RoleID == nil {
+		return errors.New("kural için departman veya rol belirtilmelidir")
+	}
+	if rule.RequestPriority != "" && !model.IsValidRequestPriority(rule.RequestPriority) {
+		return errors.New("geçersiz talep önceliği")
+	}
+	if rule.RoleID != nil {
+		if err := s.db.First(&model.Role{}, *rule.RoleID).Error; err != nil {
+			return errors.New("rol bulunamadı")
+		}
+	}
+	return nil
+}
+
+// leave aynı bağlantıyı kullanan bir izin servisi döndürür
+func (s *RoutingService) leave() *LeaveService {
+	return &LeaveService{db: s.db}
+}
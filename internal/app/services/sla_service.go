@@ -0,0 +1,419 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/UmutTKMN/go-backend/internal/app/model"
+	"github.com/UmutTKMN/go-backend/internal/pkg/businesshours"
+	"github.com/UmutTKMN/go-backend/internal/pkg/database"
+	"gorm.io/gorm"
+)
+
+// SLAEscalationActions geçerli SLA yükseltme eylemleri
+var SLAEscalationActions = []string{
+	model.SLAEscalationNotify,
+	model.SLAEscalationReassign,
+}
+
+// SLAService SLA politikaları, mesai takvimleri ve ihlal yükseltmeleri için servis
+type SLAService struct {
+	db            *gorm.DB
+	notifications *NotificationService
+}
+
+// NewSLAService yeni bir SLAService örneği oluşturur
+func NewSLAService() *SLAService {
+	return &SLAService{
+		db:            database.DB,
+		notifications: NewNotificationService(),
+	}
+}
+
+// GetAllCalendars tüm mesai takvimlerini tatilleriyle birlikte getirir
+func (s *SLAService) GetAllCalendars() ([]model.BusinessCalendar, error) {
+	var calendars []model.BusinessCalendar
+	result := s.db.Preload("Holidays", func(db *gorm.DB) *gorm.DB {
+		return db.Order("date")
+	}).Order("name").Find(&calendars)
+	return calendars, result.Error
+}
+
+// CreateCalendar yeni bir mesai takvimi oluşturur
+func (s *SLAService) CreateCalendar(calendar *model.BusinessCalendar) error {
+	if err := validateCalendar(calendar); err != nil {
+		return err
+	}
+	return s.db.Omit("Holidays").Create(calendar).Error
+}
+
+// UpdateCalendar bir mesai takvimini günceller.
+// Mevcut taleplerin hedef tarihleri yeniden hesaplanmaz.
+func (s *SLAService) UpdateCalendar(calendar *model.BusinessCalendar) error {
+	if err := s.db.First(&model.BusinessCalendar{}, calendar.ID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("mesai takvimi bulunamadı")
+		}
+		return err
+	}
+
+	if err := validateCalendar(calendar); err != nil {
+		return err
+	}
+
+	return s.db.Omit("Holidays").Save(calendar).Error
+}
+
+// DeleteCalendar bir mesai takvimini ve tatillerini siler
+func (s *SLAService) DeleteCalendar(id uint) error {
+	return runInTransaction(s.db, func(tx *gorm.DB) error {
+		if err := tx.First(&model.BusinessCalendar{}, id).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.New("mesai takvimi bulunamadı")
+			}
+			return err
+		}
+
+		// Takvim kullanılıyor mu kontrol et
+		var count int64
+		if err := tx.Model(&model.SLAPolicy{}).Where("calendar_id = ?", id).Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			return errors.New("bu takvimi kullanan SLA politikaları bulunduğundan silinemez")
+		}
+
+		if err := tx.Where("calendar_id = ?", id).Delete(&model.BusinessHoliday{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&model.BusinessCalendar{}, id).Error
+	})
+}
+
+// AddHoliday mesai takvimine tatil günü ekler
+func (s *SLAService) AddHoliday(holiday *model.BusinessHoliday) error {
+	if err := s.db.First(&model.BusinessCalendar{}, holiday.CalendarID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("mesai takvimi bulunamadı")
+		}
+		return err
+	}
+
+	holiday.Date = truncateDay(holiday.Date)
+
+	var count int64
+	if err := s.db.Model(&model.BusinessHoliday{}).
+		Where("calendar_id = ? AND date = ?", holiday.CalendarID, holiday.Date).
+		Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return errors.New("bu tarih takvimde zaten tatil olarak tanımlı")
+	}
+
+	return s.db.Create(holiday).Error
+}
+
+// RemoveHoliday mesai takviminden tatil gününü kaldırır
+func (s *SLAService) RemoveHoliday(calendarID, holidayID uint) error {
+	result := s.db.Where("id = ? AND calendar_id = ?", holidayID, calendarID).Delete(&model.BusinessHoliday{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("tatil günü bulunamadı")
+	}
+	return nil
+}
+
+// GetAllPolicies tüm SLA politikalarını getirir
+func (s *SLAService) GetAllPolicies() ([]model.SLAPolicy, error) {
+	var policies []model.SLAPolicy
+	result := s.db.Preload("Calendar").Order("request_type, priority").Find(&policies)
+	return policies, result.Error
+}
+
+// GetPolicyByID ID'ye göre SLA politikası getirir
+func (s *SLAService) GetPolicyByID(id uint) (*model.SLAPolicy, error) {
+	var policy model.SLAPolicy
+	result := s.db.Preload("Calendar").Preload("EscalateTo.User").First(&policy, id)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, errors.New("SLA politikası bulunamadı")
+		}
+		return nil, result.Error
+	}
+	return &policy, nil
+}
+
+// CreatePolicy yeni bir SLA politikası oluşturur
+func (s *SLAService) CreatePolicy(policy *model.SLAPolicy) error {
+	if err := s.validatePolicy(policy); err != nil {
+		return err
+	}
+	return s.db.Omit("Calendar", "EscalateTo").Create(policy).Error
+}
+
+// UpdatePolicy bir SLA politikasını günceller.
+// Değişiklikler yalnızca sonraki taleplere uygulanır.
+func (s *SLAService) UpdatePolicy(policy *model.SLAPolicy) error {
+	if err := s.db.First(&model.SLAPolicy{}, policy.ID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("SLA politikası bulunamadı")
+		}
+		return err
+	}
+
+	if err := s.validatePolicy(policy); err != nil {
+		return err
+	}
+
+	return s.db.Omit("Calendar", "EscalateTo").Save(policy).Error
+}
+
+// DeletePolicy bir SLA politikasını siler; politikaya bağlı taleplerin hedef tarihleri korunur
+func (s *SLAService) DeletePolicy(id uint) error {
+	return runInTransaction(s.db, func(tx *gorm.DB) error {
+		if err := tx.First(&model.SLAPolicy{}, id).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.New("SLA politikası bulunamadı")
+			}
+			return err
+		}
+
+		if err := tx.Model(&model.UserRequest{}).Where("sla_policy_id = ?", id).
+			Update("sla_policy_id", nil).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&model.SLAPolicy{}, id).Error
+	})
+}
+
+// ApplyPolicy talebin türü ve önceliğine uyan politikayı bulur ve hedef tarihleri hesaplar.
+// Türe özel politika, tüm türler için tanımlanmış politikadan önceliklidir.
+func (s *SLAService) ApplyPolicy(request *model.UserRequest) error {
+	request.SLAPolicyID = nil
+	request.FirstResponseDueAt = nil
+	request.ResolutionDueAt = nil
+
+	var policy model.SLAPolicy
+	result := s.db.Where("is_active = ? AND priority = ?", true, request.Priority).
+		Where("request_type IN ?", []string{request.RequestType, ""}).
+		Order("request_type DESC").
+		Limit(1).
+		Find(&policy)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil
+	}
+
+	calendar, err := s.loadCalendar(policy.CalendarID)
+	if err != nil {
+		return err
+	}
+
+	request.SLAPolicyID = &policy.ID
+	if policy.FirstResponseMinutes > 0 {
+		due := calendar.Add(request.SubmittedAt, time.Duration(policy.FirstResponseMinutes)*time.Minute)
+		request.FirstResponseDueAt = &due
+	}
+	if policy.ResolutionMinutes > 0 {
+		due := calendar.Add(request.SubmittedAt, time.Duration(policy.ResolutionMinutes)*time.Minute)
+		request.ResolutionDueAt = &due
+	}
+	return nil
+}
+
+// EscalateBreaches hedefi aşılmış ve henüz yükseltilmemiş açık talepleri yükseltir.
+// Talebi işleyen personelin yöneticisi, yoksa politikada tanımlı personel bilgilendirilir;
+// politika "reassign" ise talep ayrıca bu kişiye atanır. Her hedef yalnızca bir kez yükseltilir.
+func (s *SLAService) EscalateBreaches(ctx context.Context) error {
+	now := time.Now()
+
+	var requests []model.UserRequest
+	err := s.db.WithContext(ctx).Preload("SLAPolicy").Preload("HandledBy").
+		Where("status IN ? AND sla_policy_id IS NOT NULL", []string{model.RequestStatusSubmitted, model.RequestStatusInProgress}).
+		Where("(first_responded_at IS NULL AND first_response_due_at < ? AND first_response_escalated_at IS NULL) OR "+
+			"(completed_at IS NULL AND resolution_due_at < ? AND resolution_escalated_at IS NULL)", now, now).
+		Find(&requests).Error
+	if err != nil {
+		return err
+	}
+
+	for i := range requests {
+		if err := s.escalate(&requests[i], now); err != nil {
+			log.Printf("SLA ihlali yükseltilemedi (talep %d): %v", requests[i].ID, err)
+		}
+	}
+	return nil
+}
+
+// escalate tek bir talebin aşılan hedeflerini yükseltir
+func (s *SLAService) escalate(request *model.UserRequest, now time.Time) error {
+	updates := map[string]interface{}{}
+	var breached string // Şablonda kullanılan hedef anahtarı
+	if request.FirstRespondedAt == nil && request.FirstResponseDueAt != nil &&
+		request.FirstResponseDueAt.Before(now) && request.FirstResponseEscalatedAt == nil {
+		updates["first_response_escalated_at"] = now
+		breached = "first_response"
+	}
+	if request.CompletedAt == nil && request.ResolutionDueAt != nil &&
+		request.ResolutionDueAt.Before(now) && request.ResolutionEscalatedAt == nil {
+		updates["resolution_escalated_at"] = now
+		breached = "resolution"
+	}
+	if len(updates) == 0 {
+		return nil
+	}
+
+	var targetID *uint
+	if request.HandledBy != nil && request.HandledBy.ManagerID != nil {
+		targetID = request.HandledBy.ManagerID
+	} else if request.SLAPolicy != nil {
+		targetID = request.SLAPolicy.EscalateToStaffID
+	}
+
+	var target model.Staff
+	if targetID != nil {
+		if err := s.db.First(&target, *targetID).Error; err != nil {
+			return fmt.Errorf("yükseltme yapılacak personel bulunamadı: %w", err)
+		}
+
+		reassign := request.SLAPolicy != nil && request.SLAPolicy.EscalationAction == model.SLAEscalationReassign &&
+			target.EmployeeStatus != model.EmployeeStatusTerminated &&
+			(request.HandledByStaffID == nil || *request.HandledByStaffID != target.ID)
+		if reassign {
+			updates["handled_by_staff_id"] = target.ID
+		}
+	}
+
+	// Yükseltme damgası bildirimden önce yazılır; böylece bir sonraki çalışmada tekrar bildirim gönderilmez
+	if err := s.db.Model(request).Updates(updates).Error; err != nil {
+		return err
+	}
+
+	if targetID == nil {
+		log.Printf("SLA ihlali için yükseltilecek personel tanımlı değil (talep %d)", request.ID)
+		return nil
+	}
+
+	_, err := s.notifications.Notify(target.UserID, Notification{
+		Category: model.NotificationCategoryRequests,
+		Template: "sla_breach",
+		Data: map[string]interface{}{
+			"RequestID":   request.ID,
+			"RequestType": request.RequestType,
+			"Target":      breached,
+		},
+		Importance: model.ImportanceHigh,
+	})
+	return err
+}
+
+// loadCalendar politikanın mesai takvimini yükler; takvim yoksa nil (7/24) döner
+func (s *SLAService) loadCalendar(calendarID *uint) (*businesshours.Calendar, error) {
+	if calendarID == nil {
+		return nil, nil
+	}
+
+	var calendar model.BusinessCalendar
+	if err := s.db.Preload("Holidays").First(&calendar, *calendarID).Error; err != nil {
+		return nil, errors.New("mesai takvimi bulunamadı")
+	}
+
+	holidays := make([]time.Time, 0, len(calendar.Holidays))
+	for _, holiday := range calendar.Holidays {
+		holidays = append(holidays, holiday.Date)
+	}
+	return businesshours.New(calendar.Timezone, calendar.DayStart, calendar.DayEnd, calendar.Workdays, holidays)
+}
+
+// validatePolicy SLA politikası alanlarını doğrular
+func (s *SLAService) validatePolicy(policy *model.SLAPolicy) error {
+	if policy.Name == "" {
+		return errors.New("politika adı zorunludur")
+	}
+	if !model.IsValidRequestPriority(policy.Priority) {
+		return errors.New("geçersiz talep önceliği")
+	}
+	if policy.FirstResponseMinutes < 0 || policy.ResolutionMinutes < 0 {
+		return errors.New("hedef süreler negatif olamaz")
+	}
+	if policy.FirstResponseMinutes == 0 && policy.ResolutionMinutes == 0 {
+		return errors.New("en az bir hedef süre tanımlanmalıdır")
+	}
+
+	if policy.EscalationAction == "" {
+		policy.EscalationAction = model.SLAEscalationNotify
+	}
+	if !isValidSLAEscalationAction(policy.EscalationAction) {
+		return errors.New("geçersiz yükseltme eylemi")
+	}
+
+	if policy.CalendarID != nil {
+		if err := s.db.First(&model.BusinessCalendar{}, *policy.CalendarID).Error; err != nil {
+			return errors.New("mesai takvimi bulunamadı")
+		}
+	}
+	if policy.EscalateToStaffID != nil {
+		if err := s.db.First(&model.Staff{}, *policy.EscalateToStaffID).Error; err != nil {
+			return errors.New("yükseltme yapılacak personel bulunamadı")
+		}
+	}
+
+	var count int64
+	if err := s.db.Model(&model.SLAPolicy{}).
+		Where("request_type = ? AND priority = ? AND id <> ?", policy.RequestType, policy.Priority, policy.ID).
+		Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return errors.New("bu talep türü ve öncelik için zaten bir SLA politikası var")
+	}
+
+	return nil
+}
+
+// validateCalendar mesai takvimi alanlarını doğrular
+func validateCalendar(calendar *model.BusinessCalendar) error {
+	if calendar.Name == "" {
+		return errors.New("takvim adı zorunludur")
+	}
+	if calendar.Timezone == "" {
+		calendar.Timezone = "UTC"
+	}
+	if calendar.DayStart == "" {
+		calendar.DayStart = "09:00"
+	}
+	if calendar.DayEnd == "" {
+		calendar.DayEnd = "18:00"
+	}
+	if calendar.Workdays == "" {
+		calendar.Workdays = "1,2,3,4,5"
+	}
+
+	_, err := businesshours.New(calendar.Timezone, calendar.DayStart, calendar.DayEnd, calendar.Workdays, nil)
+	return err
+}
+
+// setSLAStatus taleplerin hesaplanan SLA durumunu doldurur
+func setSLAStatus(requests ...*model.UserRequest) {
+	now := time.Now()
+	for _, request := range requests {
+		request.SLAStatus = request.ComputeSLAStatus(now)
+	}
+}
+
+func isValidSLAEscalationAction(action string) bool {
+	for _, valid := range SLAEscalationActions {
+		if action == valid {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,71 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/UmutTKMN/go-backend/internal/app/model"
+)
+
+func TestApplyPolicySkipsWeekendsAndHolidays(t *testing.T) {
+	db := openTestDB(t)
+	service := &SLAService{db: db}
+
+	istanbul, err := time.LoadLocation("Europe/Istanbul")
+	if err != nil {
+		t.Skipf("saat dilimi yüklenemedi: %v", err)
+	}
+
+	calendar := &model.BusinessCalendar{Name: "sla_test_calendar", Timezone: "Europe/Istanbul"}
+	if err := service.CreateCalendar(calendar); err != nil {
+		t.Fatalf("takvim oluşturulamadı: %v", err)
+	}
+	// 23 Nisan 2026 Perşembe tatil; 25-26 Nisan hafta sonu
+	holiday := &model.BusinessHoliday{CalendarID: calendar.ID, Date: time.Date(2026, 4, 23, 0, 0, 0, 0, time.UTC)}
+	if err := service.AddHoliday(holiday); err != nil {
+		t.Fatalf("tatil eklenemedi: %v", err)
+	}
+
+	policy := &model.SLAPolicy{
+		Name:                 "sla_test_policy",
+		RequestType:          "sla_test_type",
+		Priority:             model.RequestPriorityHigh,
+		FirstResponseMinutes: 4 * 60,
+		ResolutionMinutes:    16 * 60,
+		CalendarID:           &calendar.ID,
+	}
+	if err := service.CreatePolicy(policy); err != nil {
+		t.Fatalf("politika oluşturulamadı: %v", err)
+	}
+
+	at := func(day, hour int) time.Time {
+		return time.Date(2026, 4, day, hour, 0, 0, 0, istanbul)
+	}
+
+	tests := []struct {
+		name          string
+		submittedAt   time.Time
+		firstResponse time.Time
+		resolution    time.Time
+	}{
+		{"tatil öncesi akşam", at(22, 16), at(24, 11), at(27, 14)},
+		{"cuma öğleden sonra", at(24, 15), at(27, 10), at(28, 13)},
+		{"hafta sonu", at(25, 10), at(27, 13), at(28, 16)},
+	}
+	for _, tt := range tests {
+		request := &model.UserRequest{
+			RequestType: "sla_test_type",
+			Priority:    model.RequestPriorityHigh,
+			SubmittedAt: tt.submittedAt,
+		}
+		if err := service.ApplyPolicy(request); err != nil {
+			t.Fatalf("%s: politika uygulanamadı: %v", tt.name, err)
+		}
+		if request.FirstResponseDueAt == nil || !request.FirstResponseDueAt.Equal(tt.firstResponse) {
+			t.Errorf("%s: ilk yanıt hedefi %v, beklenen %s", tt.name, request.FirstResponseDueAt, tt.firstResponse)
+		}
+		if request.ResolutionDueAt == nil || !request.ResolutionDueAt.Equal(tt.resolution) {
+			t.Errorf("%s: çözüm hedefi %v, beklenen %s", tt.name, request.ResolutionDueAt, tt.resolution)
+		}
+	}
+}
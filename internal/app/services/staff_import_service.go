@@ -0,0 +1,422 @@
+package services
+
+import (
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/UmutTKMN/go-backend/internal/app/model"
+	"github.com/UmutTKMN/go-backend/internal/pkg/database"
+	"github.com/UmutTKMN/go-backend/internal/pkg/shift"
+	"gorm.io/gorm"
+)
+
+// StaffExportColumns dışa aktarılan personel sütunları
+var StaffExportColumns = []string{
+	"staff_id",
+	"user_id",
+	"user_email",
+	"display_name",
+	"role_name",
+	"department",
+	"position",
+	"hire_date",
+	"manager_email",
+	"employee_status",
+	"access_level",
+	"shift_pattern",
+	"start_date",
+	"end_date",
+	"emergency_contact",
+	"notes",
+}
+
+// ImportRowError içe aktarma sırasında bir satırda bulunan doğrulama hatası
+type ImportRowError struct {
+	Row     int    `json:"row"`
+	Column  string `json:"column,omitempty"`
+	Message string `json:"message"`
+}
+
+// StaffImportResult içe aktarma işleminin sonucu
+type StaffImportResult struct {
+	DryRun    bool             `json:"dry_run"`
+	TotalRows int              `json:"total_rows"`
+	ValidRows int              `json:"valid_rows"`
+	Created   int              `json:"created"`
+	Errors    []ImportRowError `json:"errors"`
+}
+
+// stagedStaff doğrulanmış ve kaydedilmeyi bekleyen personel satırı
+type stagedStaff struct {
+	staff        model.Staff
+	user         model.User
+	role         model.Role
+	managerEmail string
+}
+
+// StaffImportService toplu personel içe/dışa aktarma işlemleri için servis
+type StaffImportService struct {
+	db *gorm.DB
+}
+
+// NewStaffImportService yeni bir StaffImportService örneği oluşturur
+func NewStaffImportService() *StaffImportService {
+	return &StaffImportService{
+		db: database.DB,
+	}
+}
+
+// ImportStaff başlık satırı ile birlikte verilen satırları doğrular ve personel kayıtlarını oluşturur.
+// Tanınan sütunlar: user_email, role_name (zorunlu), department, position, hire_date, manager_email,
+// employee_status, access_level, shift_pattern, emergency_contact, notes.
+// dryRun true ise yalnızca doğrulama yapılır. Herhangi bir satırda hata varsa hiçbir kayıt oluşturulmaz.
+func (s *StaffImportService) ImportStaff(rows [][]string, dryRun bool) (*StaffImportResult, error) {
+	result := &StaffImportResult{DryRun: dryRun, Errors: []ImportRowError{}}
+	if len(rows) == 0 {
+		return nil, errors.New("dosya boş")
+	}
+
+	header := make(map[string]int)
+	for i, name := range rows[0] {
+		header[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, required := range []string{"user_email", "role_name"} {
+		if _, ok := header[required]; !ok {
+			return nil, errors.New("zorunlu sütun eksik: " + required)
+		}
+	}
+
+	staged, rowErrors, err := s.validateRows(header, rows[1:])
+	if err != nil {
+		return nil, err
+	}
+
+	result.TotalRows = len(rows) - 1
+	result.ValidRows = len(staged)
+	result.Errors = rowErrors
+	if dryRun || len(rowErrors) > 0 {
+		return result, nil
+	}
+
+	err = runInTransaction(s.db, func(tx *gorm.DB) error {
+		staffByEmail := make(map[string]*model.Staff, len(staged))
+		for i := range staged {
+			item := &staged[i]
+			if err := tx.Model(&item.user).Association("Roles").Append(&item.role); err != nil {
+				return err
+			}
+			if err := tx.Create(&item.staff).Error; err != nil {
+				return err
+			}
+			staffByEmail[strings.ToLower(item.user.Email)] = &item.staff
+		}
+
+		// Yöneticiler aynı dosyada tanımlanmış olabileceğinden tüm kayıtlar oluşturulduktan sonra bağlanır
+		for i := range staged {
+			item := &staged[i]
+			if item.managerEmail == "" {
+				continue
+			}
+
+			managerID, err := s.resolveManagerID(tx, item.managerEmail, staffByEmail)
+			if err != nil {
+				return err
+			}
+			if err := tx.Model(&item.staff).Update("manager_id", managerID).Error; err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result.Created = len(staged)
+	return result, nil
+}
+
+// ExportStaff tüm personel kayıtlarını partiler halinde okuyup her satır için fn'i çağırır
+func (s *StaffImportService) ExportStaff(fn func(values []string) error) error {
+	var batch []model.Staff
+	result := s.db.Preload("User").Preload("Role").Preload("Manager.User").
+		Order("staff_id").
+		FindInBatches(&batch, 500, func(tx *gorm.DB, _ int) error {
+			for _, staff := range batch {
+				if err := fn(staffExportRow(&staff)); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	return result.Error
+}
+
+// validateRows satırları doğrular; geçerli satırları ve satır bazlı hataları döndürür
+func (s *StaffImportService) validateRows(header map[string]int, rows [][]string) ([]stagedStaff, []ImportRowError, error) {
+	var staged []stagedStaff
+	rowErrors := []ImportRowError{}
+	seenUsers := make(map[string]int)
+	importedEmails := make(map[string]bool)
+	// Dosya içindeki yönetici ilişkileri döngü kontrolü için personel e-postasından yönetici e-postasına tutulur
+	fileManagers := make(map[string]string)
+
+	for _, row := range rows {
+		if !isBlankRow(row) {
+			importedEmails[strings.ToLower(cell(row, header, "user_email"))] = true
+		}
+	}
+
+	for i, row := range rows {
+		// Başlık satırı 1. satır olduğundan veri satırları 2'den başlar
+		rowNumber := i + 2
+		if isBlankRow(row) {
+			continue
+		}
+
+		addError := func(column, message string) {
+			rowErrors = append(rowErrors, ImportRowError{Row: rowNumber, Column: column, Message: message})
+		}
+		errorCount := len(rowErrors)
+
+		var item stagedStaff
+
+		email := strings.ToLower(cell(row, header, "user_email"))
+		switch {
+		case email == "":
+			addError("user_email", "kullanıcı e-postası zorunludur")
+		case seenUsers[email] > 0:
+			addError("user_email", "bu kullanıcı dosyada "+strconv.Itoa(seenUsers[email])+". satırda zaten var")
+		default:
+			seenUsers[email] = rowNumber
+			if err := s.db.Where("LOWER(email) = ?", email).First(&item.user).Error; err != nil {
+				if !errors.Is(err, gorm.ErrRecordNotFound) {
+					return nil, nil, err
+				}
+				addError("user_email", "kullanıcı bulunamadı")
+			} else {
+				var count int64
+				if err := s.db.Unscoped().Model(&model.Staff{}).Where("user_id = ?", item.user.ID).Count(&count).Error; err != nil {
+					return nil, nil, err
+				}
+				if count > 0 {
+					addError("user_email", "kullanıcının zaten personel kaydı var")
+				}
+			}
+		}
+
+		roleName := cell(row, header, "role_name")
+		if roleName == "" {
+			addError("role_name", "rol adı zorunludur")
+		} else if err := s.db.Where("role_name = ?", roleName).First(&item.role).Error; err != nil {
+			if !errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, nil, err
+			}
+			addError("role_name", "rol bulunamadı")
+		}
+
+		if managerEmail := strings.ToLower(cell(row, header, "manager_email")); managerEmail != "" {
+			switch {
+			case managerEmail == email:
+				addError("manager_email", "personel kendisini yönetici olarak atayamaz")
+			case importedEmails[managerEmail]:
+				item.managerEmail = managerEmail
+				if seenUsers[email] == rowNumber {
+					fileManagers[email] = managerEmail
+				}
+			default:
+				var count int64
+				if err := s.db.Model(&model.Staff{}).
+					Joins("JOIN users ON users.id = staffs.user_id").
+					Where("LOWER(users.email) = ?", managerEmail).
+					Count(&count).Error; err != nil {
+					return nil, nil, err
+				}
+				if count == 0 {
+					addError("manager_email", "yönetici bulunamadı")
+				}
+				item.managerEmail = managerEmail
+			}
+		}
+
+		item.staff = model.Staff{
+			UserID:           item.user.ID,
+			RoleID:           item.role.ID,
+			Department:       cell(row, header, "department"),
+			Position:         cell(row, header, "position"),
+			EmployeeStatus:   model.EmployeeStatusActive,
+			AccessLevel:      1,
+			ShiftPattern:     cell(row, header, "shift_pattern"),
+			EmergencyContact: cell(row, header, "emergency_contact"),
+			Notes:            cell(row, header, "notes"),
+			StartDate:        time.Now(),
+		}
+
+		if value := cell(row, header, "hire_date"); value != "" {
+			hireDate, err := time.Parse("2006-01-02", value)
+			if err != nil {
+				addError("hire_date", "geçersiz tarih, beklenen format: YYYY-MM-DD")
+			}
+			item.staff.HireDate = hireDate
+		}
+
+		if value := cell(row, header, "employee_status"); value != "" {
+			switch value {
+			case model.EmployeeStatusActive, model.EmployeeStatusOnLeave, model.EmployeeStatusTerminated:
+				item.staff.EmployeeStatus = value
+			default:
+				addError("employee_status", "geçersiz çalışma durumu")
+			}
+		}
+
+		if _, err := shift.Parse(item.staff.ShiftPattern); err != nil {
+			addError("shift_pattern", err.Error())
+		}
+
+		if value := cell(row, header, "access_level"); value != "" {
+			level, err := strconv.Atoi(value)
+			if err != nil || level < 0 {
+				addError("access_level", "erişim seviyesi pozitif bir tam sayı olmalıdır")
+			}
+			item.staff.AccessLevel = level
+		}
+
+		if len(rowErrors) == errorCount {
+			staged = append(staged, item)
+		}
+	}
+
+	// Personel kendisini dolaylı olarak yönetemez (A→B→A); döngüdeki her satır hatalı sayılır
+	cycles := findManagerCycles(fileManagers)
+	if len(cycles) > 0 {
+		valid := staged[:0]
+		for _, item := range staged {
+			if !cycles[strings.ToLower(item.user.Email)] {
+				valid = append(valid, item)
+			}
+		}
+		staged = valid
+
+		for email := range cycles {
+			rowErrors = append(rowErrors, ImportRowError{
+				Row:     seenUsers[email],
+				Column:  "manager_email",
+				Message: "yönetici ilişkisi döngü oluşturuyor",
+			})
+		}
+		sort.SliceStable(rowErrors, func(i, j int) bool { return rowErrors[i].Row < rowErrors[j].Row })
+	}
+
+	return staged, rowErrors, nil
+}
+
+// findManagerCycles personel → yönetici eşlemesinde bir döngünün parçası olan personeli döndürür
+func findManagerCycles(managers map[string]string) map[string]bool {
+	cycles := make(map[string]bool)
+	done := make(map[string]bool)
+
+	for start := range managers {
+		// Yol üzerindeki her personelin yoldaki sırası; yola geri dönülmesi döngü demektir
+		position := make(map[string]int)
+		var path []string
+		for current := start; current != ""; current = managers[current] {
+			if done[current] {
+				break
+			}
+			if index, ok := position[current]; ok {
+				for _, email := range path[index:] {
+					cycles[email] = true
+				}
+				break
+			}
+			position[current] = len(path)
+			path = append(path, current)
+		}
+		for _, email := range path {
+			done[email] = true
+		}
+	}
+	return cycles
+}
+
+// resolveManagerID yönetici e-postasını önce içe aktarılan kayıtlarda, ardından veritabanında arar
+func (s *StaffImportService) resolveManagerID(tx *gorm.DB, email string, imported map[string]*model.Staff) (uint, error) {
+	if staff, ok := imported[email]; ok {
+		return staff.ID, nil
+	}
+
+	var manager model.Staff
+	err := tx.Joins("JOIN users ON users.id = staffs.user_id").
+		Where("LOWER(users.email) = ?", email).
+		First(&manager).Error
+	if err != nil {
+		return 0, errors.New("yönetici bulunamadı: " + email)
+	}
+	return manager.ID, nil
+}
+
+func staffExportRow(staff *model.Staff) []string {
+	var userEmail, displayName, roleName, managerEmail, endDate string
+	if staff.User != nil {
+		userEmail = staff.User.Email
+		displayName = staff.User.DisplayName
+	}
+	if staff.Role != nil {
+		roleName = staff.Role.RoleName
+	}
+	if staff.Manager != nil && staff.Manager.User != nil {
+		managerEmail = staff.Manager.User.Email
+	}
+	if staff.EndDate != nil {
+		endDate = formatDate(*staff.EndDate)
+	}
+
+	return []string{
+		strconv.FormatUint(uint64(staff.ID), 10),
+		strconv.FormatUint(uint64(staff.UserID), 10),
+		userEmail,
+		displayName,
+		roleName,
+		staff.Department,
+		staff.Position,
+		formatDate(staff.HireDate),
+		managerEmail,
+		staff.EmployeeStatus,
+		strconv.Itoa(staff.AccessLevel),
+		staff.ShiftPattern,
+		formatDate(staff.StartDate),
+		endDate,
+		staff.EmergencyContact,
+		staff.Notes,
+	}
+}
+
+// cell satırdaki sütun değerini boşlukları temizleyerek döndürür
+func cell(row []string, header map[string]int, column string) string {
+	index, ok := header[column]
+	if !ok || index >= len(row) {
+		return ""
+	}
+	return strings.TrimSpace(row[index])
+}
+
+func isBlankRow(row []string) bool {
+	for _, value := range row {
+		if strings.TrimSpace(value) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// formatDate sıfır olmayan tarihleri YYYY-MM-DD formatında döndürür
+func formatDate(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format("2006-01-02")
+}
@@ -0,0 +1,32 @@
+package services
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFindManagerCycles(t *testing.T) {
+	tests := []struct {
+		name     string
+		managers map[string]string
+		want     map[string]bool
+	}{
+		{"döngü yok", map[string]string{"a": "b", "b": "c", "d": "c"}, map[string]bool{}},
+		{"iki kişilik döngü", map[string]string{"a": "b", "b": "a"}, map[string]bool{"a": true, "b": true}},
+		{
+			"döngüye bağlanan zincir",
+			map[string]string{"a": "b", "b": "c", "c": "b", "d": "a"},
+			map[string]bool{"b": true, "c": true},
+		},
+		{
+			"üç kişilik döngü ve ayrı ağaç",
+			map[string]string{"a": "b", "b": "c", "c": "a", "x": "y"},
+			map[string]bool{"a": true, "b": true, "c": true},
+		},
+	}
+	for _, tt := range tests {
+		if got := findManagerCycles(tt.managers); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: findManagerCycles = %v, beklenen %v", tt.name, got, tt.want)
+		}
+	}
+}
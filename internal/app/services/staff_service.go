@@ -2,11 +2,15 @@ package services
 
 import (
 	"errors"
+	"strings"
 	"time"
 
 	"github.com/UmutTKMN/go-backend/internal/app/model"
 	"github.com/UmutTKMN/go-backend/internal/pkg/database"
+	"github.com/UmutTKMN/go-backend/internal/pkg/listquery"
+	"github.com/UmutTKMN/go-backend/internal/pkg/shift"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // StaffService personel işlemleri için servis
@@ -30,6 +34,116 @@ func (s *StaffService) GetAllStaff() ([]model.Staff, error) {
 	return staffList, result.Error
 }
 
+// StaffListSpec personel listesinde izin verilen sıralama alanları ve sayfa sınırları
+var StaffListSpec = listquery.Spec{
+	SortFields: map[string]listquery.SortField{
+		"id":           {Column: "staffs.staff_id", Kind: listquery.KindInt},
+		"name":         {Column: "users.display_name", Kind: listquery.KindString},
+		"email":        {Column: "users.email", Kind: listquery.KindString},
+		"department":   {Column: "staffs.department", Kind: listquery.KindString},
+		"position":     {Column: "staffs.position", Kind: listquery.KindString},
+		"hire_date":    {Column: "staffs.hire_date", Kind: listquery.KindTime},
+		"access_level": {Column: "staffs.access_level", Kind: listquery.KindInt},
+		"created_at":   {Column: "staffs.created_at", Kind: listquery.KindTime},
+	},
+	DefaultSort:  "id",
+	IDColumn:     "staffs.staff_id",
+	DefaultLimit: 20,
+	MaxLimit:     100,
+}
+
+// StaffFilter personel aramasında kullanılan filtreler; boş alanlar uygulanmaz
+type StaffFilter struct {
+	Department     string
+	RoleID         uint
+	Status         string
+	ManagerID      uint
+	HiredFrom      *time.Time
+	HiredTo        *time.Time
+	MinAccessLevel *int
+	MaxAccessLevel *int
+	IncludeDeleted bool // Silinmiş personel kayıtlarını da listeler
+}
+
+// SearchStaff personeli filtreler, serbest metin araması yapar ve imleç tabanlı sayfalama ile döndürür.
+// Serbest metin; ad, soyad, görünen ad, e-posta ve pozisyon alanlarında aranır.
+func (s *StaffService) SearchStaff(filter StaffFilter, query *listquery.Query) (*listquery.Page[model.Staff], error) {
+	db := s.db.Model(&model.Staff{}).
+		Select("staffs.*").
+		Preload("User").Preload("Role").Preload("Manager")
+
+	// Unscoped joinlere deleted_at koşulu eklemediği için silinmiş kullanıcılar açıkça dışlanır
+	if filter.IncludeDeleted {
+		db = db.Unscoped().Joins("JOIN users ON users.id = staffs.user_id")
+	} else {
+		db = db.Joins("JOIN users ON users.id = staffs.user_id AND users.deleted_at IS NULL")
+	}
+	if filter.Department != "" {
+		db = db.Where("staffs.department = ?", filter.Department)
+	}
+	if filter.RoleID > 0 {
+		db = db.Where("staffs.role_id = ?", filter.RoleID)
+	}
+	if filter.Status != "" {
+		db = db.Where("staffs.employee_status = ?", filter.Status)
+	}
+	if filter.ManagerID > 0 {
+		db = db.Where("staffs.manager_id = ?", filter.ManagerID)
+	}
+	if filter.HiredFrom != nil {
+		db = db.Where("staffs.hire_date >= ?", *filter.HiredFrom)
+	}
+	if filter.HiredTo != nil {
+		db = db.Where("staffs.hire_date <= ?", *filter.HiredTo)
+	}
+	if filter.MinAccessLevel != nil {
+		db = db.Where("staffs.access_level >= ?", *filter.MinAccessLevel)
+	}
+	if filter.MaxAccessLevel != nil {
+		db = db.Where("staffs.access_level <= ?", *filter.MaxAccessLevel)
+	}
+
+	if query.Search != "" {
+		pattern := "%" + escapeLike(query.Search) + "%"
+		db = db.Where(
+			"(users.first_name ILIKE ? OR users.last_name ILIKE ? OR users.display_name ILIKE ? OR users.email ILIKE ? OR staffs.position ILIKE ?)",
+			pattern, pattern, pattern, pattern, pattern,
+		)
+	}
+
+	return listquery.Paginate(db, query, StaffListSpec, func(staff *model.Staff) (interface{}, uint) {
+		// Kullanıcı yüklenemediyse metin alanları boş değerle sıralanır
+		var user model.User
+		if staff.User != nil {
+			user = *staff.User
+		}
+
+		switch query.SortKey {
+		case "name":
+			return user.DisplayName, staff.ID
+		case "email":
+			return user.Email, staff.ID
+		case "department":
+			return staff.Department, staff.ID
+		case "position":
+			return staff.Position, staff.ID
+		case "hire_date":
+			return staff.HireDate, staff.ID
+		case "access_level":
+			return staff.AccessLevel, staff.ID
+		case "created_at":
+			return staff.CreatedAt, staff.ID
+		default:
+			return staff.ID, staff.ID
+		}
+	})
+}
+
+// escapeLike LIKE kalıplarında özel anlamı olan karakterleri kaçırır
+func escapeLike(value string) string {
+	return strings.NewReplacer("\\", "\\\\", "%", "\\%", "_", "\\_").Replace(value)
+}
+
 // GetStaffByID ID'ye göre personel bilgisi getirir
 func (s *StaffService) GetStaffByID(id uint) (*model.Staff, error) {
 	var staff model.Staff
@@ -62,111 +176,179 @@ func (s *StaffService) GetStaffByUserID(userID uint) (*model.Staff, error) {
 	return &staff, nil
 }
 
-// CreateStaff yeni bir personel kaydı oluşturur
+// CreateStaff yeni bir personel kaydı oluşturur.
+// Rol ataması ve personel kaydı tek bir işlem içinde yapılır.
 func (s *StaffService) CreateStaff(staff *model.Staff) error {
-	// Kullanıcının var olup olmadığını kontrol et
-	var user model.User
-	if err := s.db.First(&user, staff.UserID).Error; err != nil {
-		return errors.New("kullanıcı bulunamadı")
-	}
+	return runInTransaction(s.db, func(tx *gorm.DB) error {
+		// Kullanıcının var olup olmadığını kontrol et
+		var user model.User
+		if err := tx.First(&user, staff.UserID).Error; err != nil {
+			return errors.New("kullanıcı bulunamadı")
+		}
 
-	// Rolün var olup olmadığını kontrol et
-	var role model.Role
-	if err := s.db.First(&role, staff.RoleID).Error; err != nil {
-		return errors.New("rol bulunamadı")
-	}
+		// Rolün var olup olmadığını kontrol et
+		var role model.Role
+		if err := tx.First(&role, staff.RoleID).Error; err != nil {
+			return errors.New("rol bulunamadı")
+		}
 
-	// Yönetici belirtilmişse var olup olmadığını kontrol et
-	if staff.ManagerID != nil && *staff.ManagerID > 0 {
-		var manager model.Staff
-		if err := s.db.First(&manager, *staff.ManagerID).Error; err != nil {
-			return errors.New("yönetici bulunamadı")
+		// Kullanıcının silinmiş bir personel kaydı varsa yenisi yerine o geri yüklenmeli
+		var deleted int64
+		err := tx.Unscoped().Model(&model.Staff{}).
+			Where("user_id = ? AND deleted_at IS NOT NULL", staff.UserID).
+			Count(&deleted).Error
+		if err != nil {
+			return err
+		}
+		if deleted > 0 {
+			return errors.New("kullanıcının silinmiş bir personel kaydı var, yeni kayıt yerine geri yükleyin")
 		}
-	}
 
-	// Başlangıç tarihini ayarla
-	if staff.StartDate.IsZero() {
-		staff.StartDate = time.Now()
-	}
+		// Yönetici belirtilmişse var olup olmadığını kontrol et
+		if staff.ManagerID != nil && *staff.ManagerID > 0 {
+			var manager model.Staff
+			if err := tx.First(&manager, *staff.ManagerID).Error; err != nil {
+				return errors.New("yönetici bulunamadı")
+			}
+		}
 
-	// Kullanıcıya rolü ekle (many-to-many ilişki)
-	if err := s.db.Model(&user).Association("Roles").Append(&role); err != nil {
-		return err
-	}
+		if _, err := shift.Parse(staff.ShiftPattern); err != nil {
+			return err
+		}
+
+		// Başlangıç tarihini ayarla
+		if staff.StartDate.IsZero() {
+			staff.StartDate = time.Now()
+		}
 
-	// Personel kaydını oluştur
-	return s.db.Create(staff).Error
+		// Kullanıcıya rolü ekle (many-to-many ilişki)
+		if err := tx.Model(&user).Association("Roles").Append(&role); err != nil {
+			return err
+		}
+
+		// Personel kaydını oluştur
+		return tx.Create(staff).Error
+	})
 }
 
-// UpdateStaff bir personel kaydını günceller
+// UpdateStaff bir personel kaydını günceller.
+// Rol değişikliği ve kayıt güncellemesi tek bir işlem içinde yapılır.
 func (s *StaffService) UpdateStaff(staff *model.Staff) error {
-	// Personelin var olup olmadığını kontrol et
-	result := s.db.First(&model.Staff{}, staff.ID)
-	if result.Error != nil {
-		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
-			return errors.New("personel bulunamadı")
+	return runInTransaction(s.db, func(tx *gorm.DB) error {
+		// Personelin var olup olmadığını kontrol et
+		var existing model.Staff
+		result := tx.First(&existing, staff.ID)
+		if result.Error != nil {
+			if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+				return errors.New("personel bulunamadı")
+			}
+			return result.Error
 		}
-		return result.Error
-	}
 
-	// Rol değişmişse ve geçerliyse
-	if staff.RoleID > 0 {
-		var role model.Role
-		if err := s.db.First(&role, staff.RoleID).Error; err != nil {
-			return errors.New("rol bulunamadı")
+		// Durum elle değiştirilmediyse izin eşitlemesinin işareti korunur, böylece izin bitince geri alınabilir
+		staff.OnLeaveBySync = existing.OnLeaveBySync && staff.EmployeeStatus == existing.EmployeeStatus
+
+		// Rol değişmişse ve geçerliyse
+		if staff.RoleID > 0 {
+			var role model.Role
+			if err := tx.First(&role, staff.RoleID).Error; err != nil {
+				return errors.New("rol bulunamadı")
+			}
+
+			// Kullanıcının rollerini güncelle
+			var user model.User
+			if err := tx.First(&user, staff.UserID).Error; err != nil {
+				return errors.New("kullanıcı bulunamadı")
+			}
+
+			// Eski rolü kaldır ve yeni rolü ekle
+			// NOT: Bu örnekte basitleştirmek için tüm rolleri kaldırıp yeni rolü ekliyoruz
+			// Gerçek uygulamada daha karmaşık bir rol yönetimi gerekebilir
+			if err := tx.Model(&user).Association("Roles").Clear(); err != nil {
+				return err
+			}
+
+			if err := tx.Model(&user).Association("Roles").Append(&role); err != nil {
+				return err
+			}
 		}
 
-		// Kullanıcının rollerini güncelle
-		var user model.User
-		if err := s.db.First(&user, staff.UserID).Error; err != nil {
-			return errors.New("kullanıcı bulunamadı")
+		// Yönetici belirtilmişse var olup olmadığını kontrol et
+		if staff.ManagerID != nil && *staff.ManagerID > 0 {
+			var manager model.Staff
+			if err := tx.First(&manager, *staff.ManagerID).Error; err != nil {
+				return errors.New("yönetici bulunamadı")
+			}
 		}
 
-		// Eski rolü kaldır ve yeni rolü ekle
-		// NOT: Bu örnekte basitleştirmek için tüm rolleri kaldırıp yeni rolü ekliyoruz
-		// Gerçek uygulamada daha karmaşık bir rol yönetimi gerekebilir
-		if err := s.db.Model(&user).Association("Roles").Clear(); err != nil {
+		if _, err := shift.Parse(staff.ShiftPattern); err != nil {
 			return err
 		}
 
-		if err := s.db.Model(&user).Association("Roles").Append(&role); err != nil {
+		// Personel kaydını güncelle
+		return tx.Save(staff).Error
+	})
+}
+
+// DeleteStaff bir personel kaydını geri yüklenebilir şekilde siler.
+// Rollerin temizlenmesi ve kaydın silinmesi tek bir işlem içinde yapılır.
+func (s *StaffService) DeleteStaff(id uint) error {
+	return runInTransaction(s.db, func(tx *gorm.DB) error {
+		// Personel var mı kontrol et
+		var staff model.Staff
+		if err := tx.First(&staff, id).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.New("personel bulunamadı")
+			}
 			return err
 		}
-	}
 
-	// Yönetici belirtilmişse var olup olmadığını kontrol et
-	if staff.ManagerID != nil && *staff.ManagerID > 0 {
-		var manager model.Staff
-		if err := s.db.First(&manager, *staff.ManagerID).Error; err != nil {
-			return errors.New("yönetici bulunamadı")
+		// Kullanıcının rollerini temizle
+		var user model.User
+		if err := tx.First(&user, staff.UserID).Error; err == nil {
+			if err := tx.Model(&user).Association("Roles").Clear(); err != nil {
+				return err
+			}
 		}
-	}
 
-	// Personel kaydını güncelle
-	return s.db.Save(staff).Error
+		// Personeli sil
+		return tx.Delete(&staff).Error
+	})
 }
 
-// DeleteStaff bir personel kaydını siler
-func (s *StaffService) DeleteStaff(id uint) error {
-	// Personel var mı kontrol et
-	var staff model.Staff
-	if err := s.db.First(&staff, id).Error; err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return errors.New("personel bulunamadı")
+// RestoreStaff silinmiş bir personel kaydını geri yükler ve personelin rolünü kullanıcıya yeniden atar.
+// Kullanıcı veya rol silinmişse önce onların geri yüklenmesi gerekir.
+func (s *StaffService) RestoreStaff(id uint) (*model.Staff, error) {
+	err := runInTransaction(s.db, func(tx *gorm.DB) error {
+		var staff model.Staff
+		if err := tx.Unscoped().Clauses(clause.Locking{Strength: "UPDATE"}).First(&staff, id).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.New("personel bulunamadı")
+			}
+			return err
+		}
+		if !staff.DeletedAt.Valid {
+			return errors.New("personel kaydı silinmemiş")
 		}
-		return err
-	}
 
-	// Kullanıcının rollerini temizle
-	var user model.User
-	if err := s.db.First(&user, staff.UserID).Error; err == nil {
-		if err := s.db.Model(&user).Association("Roles").Clear(); err != nil {
+		var user model.User
+		if err := tx.First(&user, staff.UserID).Error; err != nil {
+			return errors.New("kullanıcı bulunamadı veya silinmiş")
+		}
+		var role model.Role
+		if err := tx.First(&role, staff.RoleID).Error; err != nil {
+			return errors.New("rol bulunamadı veya silinmiş")
+		}
+
+		if err := tx.Model(&user).Association("Roles").Append(&role); err != nil {
 			return err
 		}
+		return tx.Unscoped().Model(&staff).Update("deleted_at", nil).Error
+	})
+	if err != nil {
+		return nil, err
 	}
-
-	// Personeli sil
-	return s.db.Delete(&staff).Error
+	return s.GetStaffByID(id)
 }
 
 // GetStaffByDepartment departmana göre personel listesi getirir
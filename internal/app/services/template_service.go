@@ -0,0 +1,235 @@
+package services
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	htmltemplate "html/template"
+	"regexp"
+	"strings"
+	texttemplate "text/template"
+	"time"
+
+	"github.com/UmutTKMN/go-backend/internal/app/model"
+	"github.com/UmutTKMN/go-backend/internal/app/templates"
+	"github.com/UmutTKMN/go-backend/internal/pkg/database"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// DefaultTemplateLanguage kullanıcının dilinde şablon bulunmadığında kullanılan dil
+const DefaultTemplateLanguage = "tr"
+
+var templateLanguagePattern = regexp.MustCompile(`^[a-z]{2}$`)
+
+// RenderedMessage işlenmiş şablon çıktısı. HTML şablonda html bölümü yoksa boştur.
+type RenderedMessage struct {
+	Subject string `json:"subject"`
+	Text    string `json:"text"`
+	HTML    string `json:"html,omitempty"`
+}
+
+// TemplateInfo şablon listesinde bir ad ve dil için varsayılan ve özelleştirilmiş sürüm bilgisi
+type TemplateInfo struct {
+	Name       string     `json:"name"`
+	Language   string     `json:"language"`
+	HasDefault bool       `json:"has_default"`
+	IsCustom   bool       `json:"is_custom"`
+	UpdatedAt  *time.Time `json:"updated_at,omitempty"`
+}
+
+// TemplateService mesaj şablonlarının seçimi, işlenmesi ve yönetimi için servis
+type TemplateService struct {
+	db *gorm.DB
+}
+
+// NewTemplateService yeni bir TemplateService örneği oluşturur
+func NewTemplateService() *TemplateService {
+	return &TemplateService{
+		db: database.DB,
+	}
+}
+
+// NormalizeLanguage dil kodunu şablon dil biçimine çevirir ("en-US" -> "en")
+func NormalizeLanguage(language string) string {
+	language = strings.ToLower(strings.TrimSpace(language))
+	if i := strings.IndexAny(language, "-_"); i >= 0 {
+		language = language[:i]
+	}
+	return language
+}
+
+// Render şablonu kullanıcının dilinde işler. O dilde şablon yoksa DefaultTemplateLanguage kullanılır.
+// Her dilde yönetici tarafından düzenlenmiş sürüm gömülü varsayılandan önceliklidir.
+func (s *TemplateService) Render(name, language string, data interface{}) (*RenderedMessage, error) {
+	languages := []string{NormalizeLanguage(language)}
+	if languages[0] != DefaultTemplateLanguage {
+		languages = append(languages, DefaultTemplateLanguage)
+	}
+
+	for _, lang := range languages {
+		tmpl, _, err := s.GetTemplate(name, lang)
+		if err != nil {
+			continue
+		}
+		return renderTemplate(tmpl, data)
+	}
+	return nil, fmt.Errorf("şablon bulunamadı: %s", name)
+}
+
+// GetTemplate ad ve dil için geçerli şablonu getirir; ikinci değer düzenlenmiş sürüm olup olmadığını belirtir
+func (s *TemplateService) GetTemplate(name, language string) (*model.MessageTemplate, bool, error) {
+	var tmpl model.MessageTemplate
+	err := s.db.Where("name = ? AND language = ?", name, language).First(&tmpl).Error
+	if err == nil {
+		return &tmpl, true, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, false, err
+	}
+
+	source, ok := templates.Default(name, language)
+	if !ok {
+		return nil, false, errors.New("şablon bulunamadı")
+	}
+	return &model.MessageTemplate{
+		Name:     name,
+		Language: language,
+		Subject:  source.Subject,
+		BodyText: source.Text,
+		BodyHTML: source.HTML,
+	}, false, nil
+}
+
+// ListTemplates gömülü ve düzenlenmiş tüm şablonları listeler
+func (s *TemplateService) ListTemplates() ([]TemplateInfo, error) {
+	var custom []model.MessageTemplate
+	if err := s.db.Order("name, language").Find(&custom).Error; err != nil {
+		return nil, err
+	}
+
+	index := map[string]int{}
+	var infos []TemplateInfo
+	for _, entry := range templates.List() {
+		index[entry.Name+"/"+entry.Language] = len(infos)
+		infos = append(infos, TemplateInfo{Name: entry.Name, Language: entry.Language, HasDefault: true})
+	}
+
+	for i := range custom {
+		key := custom[i].Name + "/" + custom[i].Language
+		if pos, ok := index[key]; ok {
+			infos[pos].IsCustom = true
+			infos[pos].UpdatedAt = &custom[i].UpdatedAt
+			continue
+		}
+		infos = append(infos, TemplateInfo{
+			Name:      custom[i].Name,
+			Language:  custom[i].Language,
+			IsCustom:  true,
+			UpdatedAt: &custom[i].UpdatedAt,
+		})
+	}
+	return infos, nil
+}
+
+// SaveTemplate şablonun düzenlenmiş sürümünü doğrulayıp kaydeder; varsa günceller.
+// Yalnızca gömülü varsayılanı olan şablon adları düzenlenebilir; yeni diller eklenebilir.
+func (s *TemplateService) SaveTemplate(tmpl *model.MessageTemplate) error {
+	if !templates.Exists(tmpl.Name) {
+		return errors.New("bilinmeyen şablon adı")
+	}
+	if !templateLanguagePattern.MatchString(tmpl.Language) {
+		return errors.New("dil iki harfli ISO 639-1 kodu olmalıdır (ör. tr, en)")
+	}
+	if err := validateTemplate(tmpl); err != nil {
+		return err
+	}
+
+	return s.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "name"}, {Name: "language"}},
+		DoUpdates: clause.AssignmentColumns([]string{"subject", "body_text", "body_html", "updated_by", "updated_at"}),
+	}).Create(tmpl).Error
+}
+
+// DeleteTemplate şablonun düzenlenmiş sürümünü siler; gömülü varsayılan yeniden kullanılır
+func (s *TemplateService) DeleteTemplate(name, language string) error {
+	result := s.db.Where("name = ? AND language = ?", name, language).Delete(&model.MessageTemplate{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("düzenlenmiş şablon bulunamadı")
+	}
+	return nil
+}
+
+// Preview şablonu kaydetmeden verilen verilerle işler
+func (s *TemplateService) Preview(tmpl *model.MessageTemplate, data interface{}) (*RenderedMessage, error) {
+	if err := validateTemplate(tmpl); err != nil {
+		return nil, err
+	}
+	return renderTemplate(tmpl, data)
+}
+
+// validateTemplate zorunlu bölümleri ve şablon sözdizimini doğrular
+func validateTemplate(tmpl *model.MessageTemplate) error {
+	tmpl.Subject = strings.TrimSpace(tmpl.Subject)
+	if tmpl.Subject == "" || strings.TrimSpace(tmpl.BodyText) == "" {
+		return errors.New("konu ve metin gövdesi zorunludur")
+	}
+
+	if _, err := texttemplate.New("subject").Parse(tmpl.Subject); err != nil {
+		return fmt.Errorf("konu şablonu geçersiz: %w", err)
+	}
+	if _, err := texttemplate.New("text").Parse(tmpl.BodyText); err != nil {
+		return fmt.Errorf("metin şablonu geçersiz: %w", err)
+	}
+	if tmpl.BodyHTML != "" {
+		if _, err := htmltemplate.New("html").Parse(tmpl.BodyHTML); err != nil {
+			return fmt.Errorf("HTML şablonu geçersiz: %w", err)
+		}
+	}
+	return nil
+}
+
+// renderTemplate şablonun bölümlerini işler. HTML bölümünde veriler otomatik olarak kaçışlanır.
+func renderTemplate(tmpl *model.MessageTemplate, data interface{}) (*RenderedMessage, error) {
+	var message RenderedMessage
+
+	subject, err := executeText("subject", tmpl.Subject, data)
+	if err != nil {
+		return nil, err
+	}
+	// Konu tek satır olmalıdır
+	message.Subject = strings.Join(strings.Fields(subject), " ")
+
+	if message.Text, err = executeText("text", tmpl.BodyText, data); err != nil {
+		return nil, err
+	}
+
+	if tmpl.BodyHTML != "" {
+		parsed, err := htmltemplate.New("html").Parse(tmpl.BodyHTML)
+		if err != nil {
+			return nil, fmt.Errorf("HTML şablonu geçersiz: %w", err)
+		}
+		var buf bytes.Buffer
+		if err := parsed.Execute(&buf, data); err != nil {
+			return nil, fmt.Errorf("HTML şablonu işlenemedi: %w", err)
+		}
+		message.HTML = buf.String()
+	}
+	return &message, nil
+}
+
+func executeText(name, source string, data interface{}) (string, error) {
+	parsed, err := texttemplate.New(name).Parse(source)
+	if err != nil {
+		return "", fmt.Errorf("%s şablonu geçersiz: %w", name, err)
+	}
+
+	var buf bytes.Buffer
+	if err := parsed.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("%s şablonu işlenemedi: %w", name, err)
+	}
+	return buf.String(), nil
+}
@@ -0,0 +1,11 @@
+package services
+
+import "gorm.io/gorm"
+
+// runInTransaction fn'i verilen bağlantı üzerinde bir işlem içinde çalıştırır.
+// fn hata döndürürse veya panik oluşursa tüm değişiklikler geri alınır. Bağlantı zaten bir
+// işlem içindeyse gorm iç içe işlem için savepoint kullanır, böylece servis metotları
+// başka bir işlemin parçası olarak çağrıldığında da atomik kalır.
+func runInTransaction(db *gorm.DB, fn func(tx *gorm.DB) error) error {
+	return db.Transaction(fn)
+}
@@ -0,0 +1,206 @@
+package services
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/UmutTKMN/go-backend/internal/app/model"
+	"github.com/UmutTKMN/go-backend/internal/pkg/database"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+var errInjected = errors.New("enjekte edilen hata")
+
+// openTestDB TEST_DATABASE_DSN ile PostgreSQL'e bağlanıp şemayı migrate eder ve testin sonunda
+// geri alınan bir işlem döndürür. DSN tanımlı değilse test atlanır.
+func openTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	dsn := os.Getenv("TEST_DATABASE_DSN")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_DSN tanımlı değil")
+	}
+
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatalf("veritabanına bağlanılamadı: %v", err)
+	}
+	if err := database.Migrate(db); err != nil {
+		t.Fatalf("migrate edilemedi: %v", err)
+	}
+
+	tx := db.Begin()
+	if tx.Error != nil {
+		t.Fatalf("işlem başlatılamadı: %v", tx.Error)
+	}
+	t.Cleanup(func() { tx.Rollback() })
+	return tx
+}
+
+// failOn verilen tablodaki ilk create, update veya delete ifadesinde errInjected döndürür.
+// Hata servis işleminin ortasında, önceki adımlar veritabanına yazıldıktan sonra oluşur.
+func failOn(t *testing.T, db *gorm.DB, operation, table string) {
+	t.Helper()
+
+	name := "test:fail_" + operation + "_" + table
+	fail := func(tx *gorm.DB) {
+		if tx.Statement.Table == table {
+			tx.AddError(errInjected)
+		}
+	}
+
+	var err error
+	switch operation {
+	case "create":
+		err = db.Callback().Create().Before("gorm:create").Register(name, fail)
+		t.Cleanup(func() { db.Callback().Create().Remove(name) })
+	case "update":
+		err = db.Callback().Update().Before("gorm:update").Register(name, fail)
+		t.Cleanup(func() { db.Callback().Update().Remove(name) })
+	case "delete":
+		err = db.Callback().Delete().Before("gorm:delete").Register(name, fail)
+		t.Cleanup(func() { db.Callback().Delete().Remove(name) })
+	default:
+		t.Fatalf("bilinmeyen işlem: %s", operation)
+	}
+	if err != nil {
+		t.Fatalf("callback kaydedilemedi: %v", err)
+	}
+}
+
+func createTestUser(t *testing.T, db *gorm.DB, username string) *model.User {
+	t.Helper()
+
+	user := &model.User{Username: username, Email: username + "@example.com", Password: "x"}
+	if err := db.Create(user).Error; err != nil {
+		t.Fatalf("kullanıcı oluşturulamadı: %v", err)
+	}
+	return user
+}
+
+func createTestRole(t *testing.T, db *gorm.DB, name string, createdBy uint) *model.Role {
+	t.Helper()
+
+	role := &model.Role{RoleName: name, CreatedBy: createdBy}
+	if err := db.Create(role).Error; err != nil {
+		t.Fatalf("rol oluşturulamadı: %v", err)
+	}
+	return role
+}
+
+func userRoleIDs(t *testing.T, db *gorm.DB, userID uint) []uint {
+	t.Helper()
+
+	var ids []uint
+	if err := db.Table("user_roles").Where("user_id = ?", userID).Order("role_id").Pluck("role_id", &ids).Error; err != nil {
+		t.Fatalf("kullanıcı rolleri okunamadı: %v", err)
+	}
+	return ids
+}
+
+func TestCreateStaffRollsBackRoleAssignment(t *testing.T) {
+	db := openTestDB(t)
+	user := createTestUser(t, db, "tx_create_staff")
+	role := createTestRole(t, db, "tx_create_staff_role", user.ID)
+
+	// Rol kullanıcıya eklendikten sonra personel kaydı oluşturulurken hata oluşur
+	failOn(t, db, "create", "staffs")
+
+	service := &StaffService{db: db}
+	err := service.CreateStaff(&model.Staff{UserID: user.ID, RoleID: role.ID, Department: "IT"})
+	if !errors.Is(err, errInjected) {
+		t.Fatalf("beklenen hata %v, alınan %v", errInjected, err)
+	}
+
+	if ids := userRoleIDs(t, db, user.ID); len(ids) != 0 {
+		t.Errorf("rol ataması geri alınmadı: %v", ids)
+	}
+	var count int64
+	db.Unscoped().Model(&model.Staff{}).Where("user_id = ?", user.ID).Count(&count)
+	if count != 0 {
+		t.Errorf("personel kaydı oluşturulmamalıydı, bulunan: %d", count)
+	}
+}
+
+func TestUpdateStaffKeepsRolesOnFailure(t *testing.T) {
+	db := openTestDB(t)
+	user := createTestUser(t, db, "tx_update_staff")
+	oldRole := createTestRole(t, db, "tx_update_staff_old", user.ID)
+	newRole := createTestRole(t, db, "tx_update_staff_new", user.ID)
+
+	service := &StaffService{db: db}
+	staff := &model.Staff{UserID: user.ID, RoleID: oldRole.ID, Department: "IT"}
+	if err := service.CreateStaff(staff); err != nil {
+		t.Fatalf("personel oluşturulamadı: %v", err)
+	}
+
+	// Roller temizlenip yeni rol eklendikten sonra personel kaydı güncellenirken hata oluşur
+	failOn(t, db, "update", "staffs")
+
+	updated := *staff
+	updated.RoleID = newRole.ID
+	updated.Department = "HR"
+	if err := service.UpdateStaff(&updated); !errors.Is(err, errInjected) {
+		t.Fatalf("beklenen hata %v, alınan %v", errInjected, err)
+	}
+
+	ids := userRoleIDs(t, db, user.ID)
+	if len(ids) != 1 || ids[0] != oldRole.ID {
+		t.Errorf("kullanıcı rolleri geri alınmadı: %v", ids)
+	}
+	var stored model.Staff
+	if err := db.First(&stored, staff.ID).Error; err != nil {
+		t.Fatalf("personel okunamadı: %v", err)
+	}
+	if stored.RoleID != oldRole.ID || stored.Department != "IT" {
+		t.Errorf("personel kaydı değişmemeliydi: rol %d, departman %q", stored.RoleID, stored.Department)
+	}
+}
+
+func TestDeleteRoleRollsBackOnFailure(t *testing.T) {
+	db := openTestDB(t)
+	user := createTestUser(t, db, "tx_delete_role")
+	role := createTestRole(t, db, "tx_delete_role", user.ID)
+
+	// Kilit ve kullanım kontrolünden sonra silme sırasında hata oluşur
+	failOn(t, db, "delete", "roles")
+
+	service := &RoleService{db: db}
+	if err := service.DeleteRole(role.ID); !errors.Is(err, errInjected) {
+		t.Fatalf("beklenen hata %v, alınan %v", errInjected, err)
+	}
+
+	var stored model.Role
+	if err := db.Unscoped().First(&stored, role.ID).Error; err != nil {
+		t.Fatalf("rol okunamadı: %v", err)
+	}
+	if stored.DeletedAt.Valid {
+		t.Error("rol silinmiş olmamalıydı")
+	}
+}
+
+func TestRegisterRollsBackOnFailure(t *testing.T) {
+	db := openTestDB(t)
+
+	// Benzersizlik kontrolünden sonra kullanıcı kaydedilirken hata oluşur
+	failOn(t, db, "create", "users")
+
+	service := &AuthService{db: db, secretKey: []byte("test")}
+	_, err := service.Register(&model.RegisterRequest{
+		Username: "tx_register",
+		Email:    "tx_register@example.com",
+		Password: "secret123",
+	})
+	if !errors.Is(err, errInjected) {
+		t.Fatalf("beklenen hata %v, alınan %v", errInjected, err)
+	}
+
+	var count int64
+	db.Unscoped().Model(&model.User{}).Where("email = ?", "tx_register@example.com").Count(&count)
+	if count != 0 {
+		t.Errorf("kullanıcı kaydedilmemeliydi, bulunan: %d", count)
+	}
+}
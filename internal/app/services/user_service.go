@@ -2,13 +2,24 @@ package services
 
 import (
 	"errors"
+	"strconv"
 	"time"
 
 	"github.com/UmutTKMN/go-backend/internal/app/model"
 	"github.com/UmutTKMN/go-backend/internal/pkg/database"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+// Kullanıcı benzersizlik hataları; silinmiş hesapların e-posta ve kullanıcı adları da kullanımda sayılır
+var (
+	ErrEmailTaken    = errors.New("bu email adresi zaten kullanılıyor")
+	ErrUsernameTaken = errors.New("bu kullanıcı adı zaten kullanılıyor")
+)
+
+// ErrUserHasStaff aktif personel kaydı olan kullanıcı silinmeye çalışıldığında döner
+var ErrUserHasStaff = errors.New("kullanıcının aktif personel kaydı var, önce personel kaydını silin")
+
 type UserService struct{}
 
 func NewUserService() *UserService {
@@ -52,40 +63,144 @@ func (s *UserService) UpdateUser(id uint, updates map[string]interface{}) (*mode
 	delete(updates, "verification_token")
 	delete(updates, "api_key")
 
-	// Güncellemeleri uygula
-	if err := database.DB.Model(&user).Updates(updates).Error; err != nil {
+	// Profil fotoğrafı yalnızca yükleme ile değiştirilebilir
+	delete(updates, "profile_picture")
+	delete(updates, "avatar_path")
+
+	// Bildirim tercihleri ve gizlilik ayarları doğrulanarak yalnızca kendi uç noktalarından değiştirilebilir
+	delete(updates, "notification_preferences")
+	delete(updates, "privacy_settings")
+
+	// Hesap silme ve geri yükleme yalnızca kendi akışları üzerinden yönetilir
+	delete(updates, "deletion_requested_at")
+	delete(updates, "deletion_scheduled_at")
+	delete(updates, "anonymized_at")
+	delete(updates, "deleted_at")
+
+	// Benzersizlik kontrolü ve güncelleme aynı işlem içinde yapılır
+	wasActive := user.IsActive
+	err := runInTransaction(database.DB, func(tx *gorm.DB) error {
+		email, _ := updates["email"].(string)
+		username, _ := updates["username"].(string)
+		if err := ensureUserUnique(tx, user.ID, email, username); err != nil {
+			return err
+		}
+
+		// Güncellemeleri uygula
+		return tx.Model(&user).Updates(updates).Error
+	})
+	if err != nil {
 		return nil, err
 	}
 
+	// Devre dışı bırakılan hesabın açık oturumları kapatılır
+	if active, ok := updates["is_active"].(bool); ok && !active && wasActive {
+		publishForcedLogout(user.ID, LogoutReasonAccountDeactivated)
+	}
+
 	// Güncellenmiş kullanıcıyı döndür
 	return s.GetUser(id)
 }
 
-// DeleteUser kullanıcıyı siler
-func (s *UserService) DeleteUser(id uint) error {
-	result := database.DB.Delete(&model.User{}, id)
+// ensureUserUnique e-posta veya kullanıcı adının excludeID dışındaki bir hesapta kullanılıp kullanılmadığını
+// kontrol eder. Silinmiş hesaplar da aranır, çünkü benzersiz indeksler onları da kapsar. Boş değerler atlanır.
+func ensureUserUnique(tx *gorm.DB, excludeID uint, email, username string) error {
+	if email == "" && username == "" {
+		return nil
+	}
+
+	db := tx.Unscoped().Where("id <> ?", excludeID)
+	switch {
+	case email != "" && username != "":
+		db = db.Where("email = ? OR username = ?", email, username)
+	case email != "":
+		db = db.Where("email = ?", email)
+	default:
+		db = db.Where("username = ?", username)
+	}
+
+	var existing model.User
+	result := db.Limit(1).Find(&existing)
 	if result.Error != nil {
 		return result.Error
 	}
 	if result.RowsAffected == 0 {
-		return errors.New("kullanıcı bulunamadı")
+		return nil
 	}
+	if email != "" && existing.Email == email {
+		return ErrEmailTaken
+	}
+	return ErrUsernameTaken
+}
+
+// DeleteUser kullanıcıyı geri yüklenebilir şekilde siler ve açık oturumlarını kapatır.
+// Kalıcı silme saklama süresi sonunda kişisel verilerin anonimleştirilmesiyle yapılır.
+func (s *UserService) DeleteUser(id uint) error {
+	err := runInTransaction(database.DB, func(tx *gorm.DB) error {
+		var user model.User
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&user, id).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.New("kullanıcı bulunamadı")
+			}
+			return err
+		}
+
+		// Personel kaydı kullanıcıyla birlikte silinmez; sahipsiz personel kaydı kalmaması için silme engellenir
+		var staffCount int64
+		if err := tx.Model(&model.Staff{}).Where("user_id = ?", id).Count(&staffCount).Error; err != nil {
+			return err
+		}
+		if staffCount > 0 {
+			return ErrUserHasStaff
+		}
+
+		return tx.Delete(&user).Error
+	})
+	if err != nil {
+		return err
+	}
+
+	publishForcedLogout(id, LogoutReasonAccountDeleted)
 	return nil
 }
 
-// GetAllUsers tüm kullanıcıları döndürür
-func (s *UserService) GetAllUsers(page, limit int) ([]model.User, int64, error) {
+// RestoreUser silinmiş kullanıcıyı geri yükler; anonimleştirilmiş hesaplar geri yüklenemez
+func (s *UserService) RestoreUser(id uint) (*model.User, error) {
+	var user model.User
+	if err := database.DB.Unscoped().First(&user, id).Error; err != nil {
+		return nil, errors.New("kullanıcı bulunamadı")
+	}
+	if !user.DeletedAt.Valid {
+		return nil, errors.New("kullanıcı silinmemiş")
+	}
+	if user.AnonymizedAt != nil {
+		return nil, errors.New("kişisel verileri silinmiş kullanıcı geri yüklenemez")
+	}
+
+	if err := database.DB.Unscoped().Model(&user).Update("deleted_at", nil).Error; err != nil {
+		return nil, err
+	}
+	return s.GetUser(id)
+}
+
+// GetAllUsers tüm kullanıcıları döndürür; includeDeleted true ise silinmiş kullanıcılar da listelenir
+func (s *UserService) GetAllUsers(page, limit int, includeDeleted bool) ([]model.User, int64, error) {
 	var users []model.User
 	var total int64
 
+	db := database.DB
+	if includeDeleted {
+		db = db.Unscoped()
+	}
+
 	// Toplam kayıt sayısını al
-	if err := database.DB.Model(&model.User{}).Count(&total).Error; err != nil {
+	if err := db.Model(&model.User{}).Count(&total).Error; err != nil {
 		return nil, 0, err
 	}
 
 	// Sayfalama ile kullanıcıları al
 	offset := (page - 1) * limit
-	if err := database.DB.Offset(offset).Limit(limit).Find(&users).Error; err != nil {
+	if err := db.Offset(offset).Limit(limit).Find(&users).Error; err != nil {
 		return nil, 0, err
 	}
 
@@ -104,3 +219,56 @@ func (s *UserService) GetUserByID(id uint) (*model.User, error) {
 	}
 	return &user, nil
 }
+
+// UserExportColumns dışa aktarılan kullanıcı sütunları
+var UserExportColumns = []string{
+	"id",
+	"username",
+	"email",
+	"first_name",
+	"last_name",
+	"display_name",
+	"phone_number",
+	"country",
+	"city",
+	"account_type",
+	"is_active",
+	"is_verified",
+	"registration_date",
+	"last_login",
+}
+
+// ExportUsers tüm kullanıcıları partiler halinde okuyup her satır için fn'i çağırır
+func (s *UserService) ExportUsers(fn func(values []string) error) error {
+	var batch []model.User
+	result := database.DB.Order("id").FindInBatches(&batch, 500, func(tx *gorm.DB, _ int) error {
+		for _, user := range batch {
+			var lastLogin string
+			if user.LastLogin != nil {
+				lastLogin = user.LastLogin.Format(time.RFC3339)
+			}
+
+			err := fn([]string{
+				strconv.FormatUint(uint64(user.ID), 10),
+				user.Username,
+				user.Email,
+				user.FirstName,
+				user.LastName,
+				user.DisplayName,
+				user.PhoneNumber,
+				user.Country,
+				user.City,
+				user.AccountType,
+				strconv.FormatBool(user.IsActive),
+				strconv.FormatBool(user.IsVerified),
+				formatDate(user.RegistrationDate),
+				lastLogin,
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return result.Error
+}
@@ -0,0 +1,116 @@
+// Package templates e-posta ve bildirimler için gömülü varsayılan mesaj şablonlarını içerir.
+//
+// Şablonlar messages/<dil>/<ad>.tmpl yolundadır. Her dosya "-- subject --", "-- text --" ve
+// isteğe bağlı "-- html --" satırlarıyla ayrılmış bölümlerden oluşur; bölümler Go şablon sözdizimini kullanır.
+package templates
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+)
+
+//go:embed messages
+var files embed.FS
+
+// Source bir şablonun işlenmemiş bölümleri
+type Source struct {
+	Subject string
+	Text    string
+	HTML    string
+}
+
+// Default adı ve dili verilen gömülü şablonu döndürür
+func Default(name, language string) (Source, bool) {
+	data, err := files.ReadFile(path.Join("messages", language, name+".tmpl"))
+	if err != nil {
+		return Source{}, false
+	}
+
+	source, err := Parse(string(data))
+	if err != nil {
+		return Source{}, false
+	}
+	return source, true
+}
+
+// Parse bölümlere ayrılmış şablon dosyasını çözümler
+func Parse(data string) (Source, error) {
+	sections := map[string]*strings.Builder{}
+	var current *strings.Builder
+
+	for _, line := range strings.SplitAfter(data, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "-- ") && strings.HasSuffix(trimmed, " --") {
+			name := strings.TrimSpace(trimmed[3 : len(trimmed)-3])
+			if name != "subject" && name != "text" && name != "html" {
+				return Source{}, fmt.Errorf("bilinmeyen şablon bölümü: %s", name)
+			}
+			current = &strings.Builder{}
+			sections[name] = current
+			continue
+		}
+		if current != nil {
+			current.WriteString(line)
+		}
+	}
+
+	section := func(name string) string {
+		if builder, ok := sections[name]; ok {
+			return strings.TrimRight(builder.String(), "\r\n")
+		}
+		return ""
+	}
+
+	source := Source{
+		Subject: strings.TrimSpace(section("subject")),
+		Text:    section("text"),
+		HTML:    section("html"),
+	}
+	if source.Subject == "" || source.Text == "" {
+		return Source{}, fmt.Errorf("şablonda subject ve text bölümleri zorunludur")
+	}
+	return source, nil
+}
+
+// Entry gömülü bir şablonun adı ve dili
+type Entry struct {
+	Name     string
+	Language string
+}
+
+// List tüm gömülü şablonları ada ve dile göre sıralı döndürür
+func List() []Entry {
+	var entries []Entry
+	fs.WalkDir(files, "messages", func(p string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || path.Ext(p) != ".tmpl" {
+			return err
+		}
+		entries = append(entries, Entry{
+			Name:     strings.TrimSuffix(path.Base(p), ".tmpl"),
+			Language: path.Base(path.Dir(p)),
+		})
+		return nil
+	})
+
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Name != entries[j].Name {
+			return entries[i].Name < entries[j].Name
+		}
+		return entries[i].Language < entries[j].Language
+	})
+	return entries
+}
+
+// Exists verilen adla en az bir gömülü şablon bulunup bulunmadığını döndürür
+func Exists(name string) bool {
+	for _, entry := range List() {
+		if entry.Name == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,139 @@
+// Package businesshours mesai saatleri, çalışma günleri ve tatiller üzerinden süre hesaplar
+package businesshours
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxSearchDays hiç çalışma günü olmayan takvimlerde sonsuz döngüyü önler
+const maxSearchDays = 3660
+
+// Calendar çalışma günleri, günlük mesai aralığı ve tatillerden oluşan iş takvimi.
+// nil Calendar 7/24 açık kabul edilir.
+type Calendar struct {
+	location *time.Location
+	start    time.Duration
+	end      time.Duration
+	workdays map[time.Weekday]bool
+	holidays map[string]bool
+}
+
+// New yeni bir iş takvimi oluşturur.
+// dayStart ve dayEnd "15:04" formatında, workdays "1,2,3,4,5" gibi (0 = Pazar) verilir.
+func New(timezone, dayStart, dayEnd, workdays string, holidays []time.Time) (*Calendar, error) {
+	location, err := time.LoadLocation(timezone)
+	if err != nil {
+		return nil, fmt.Errorf("geçersiz saat dilimi: %s", timezone)
+	}
+
+	start, err := ParseClock(dayStart)
+	if err != nil {
+		return nil, err
+	}
+	end, err := ParseClock(dayEnd)
+	if err != nil {
+		return nil, err
+	}
+	if end <= start {
+		return nil, errors.New("mesai bitişi mesai başlangıcından sonra olmalıdır")
+	}
+
+	days, err := ParseWorkdays(workdays)
+	if err != nil {
+		return nil, err
+	}
+
+	calendar := &Calendar{
+		location: location,
+		start:    start,
+		end:      end,
+		workdays: make(map[time.Weekday]bool, len(days)),
+		holidays: make(map[string]bool, len(holidays)),
+	}
+	for _, day := range days {
+		calendar.workdays[day] = true
+	}
+	for _, holiday := range holidays {
+		// Tatiller tarih olarak saklanır; sürücünün yerel saate çevirdiği değer UTC takvim gününe döndürülür
+		calendar.holidays[holiday.UTC().Format("2006-01-02")] = true
+	}
+	return calendar, nil
+}
+
+// ParseClock "15:04" formatındaki saati gün başından itibaren geçen süreye çevirir
+func ParseClock(value string) (time.Duration, error) {
+	clock, err := time.Parse("15:04", strings.TrimSpace(value))
+	if err != nil {
+		return 0, fmt.Errorf("geçersiz saat, beklenen format: SS:DD (%s)", value)
+	}
+	return time.Duration(clock.Hour())*time.Hour + time.Duration(clock.Minute())*time.Minute, nil
+}
+
+// ParseWorkdays virgülle ayrılmış gün numaralarını (0 = Pazar, 6 = Cumartesi) çözümler
+func ParseWorkdays(value string) ([]time.Weekday, error) {
+	var days []time.Weekday
+	for _, part := range strings.Split(value, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		day, err := strconv.Atoi(part)
+		if err != nil || day < 0 || day > 6 {
+			return nil, fmt.Errorf("geçersiz çalışma günü: %s", part)
+		}
+		days = append(days, time.Weekday(day))
+	}
+	if len(days) == 0 {
+		return nil, errors.New("en az bir çalışma günü tanımlanmalıdır")
+	}
+	return days, nil
+}
+
+// Add from anından itibaren d kadar mesai süresi geçtikten sonraki anı döndürür.
+// Mesai dışı saatler, çalışma günü olmayan günler ve tatiller sayılmaz.
+func (c *Calendar) Add(from time.Time, d time.Duration) time.Time {
+	if c == nil {
+		return from.Add(d)
+	}
+	if d <= 0 {
+		return from
+	}
+
+	current := from.In(c.location)
+	remaining := d
+	for i := 0; i < maxSearchDays; i++ {
+		day := time.Date(current.Year(), current.Month(), current.Day(), 0, 0, 0, 0, c.location)
+		nextDay := day.AddDate(0, 0, 1)
+
+		if !c.isWorkday(day) {
+			current = nextDay
+			continue
+		}
+
+		opensAt, closesAt := day.Add(c.start), day.Add(c.end)
+		if current.Before(opensAt) {
+			current = opensAt
+		}
+		if !current.Before(closesAt) {
+			current = nextDay
+			continue
+		}
+
+		available := closesAt.Sub(current)
+		if remaining <= available {
+			return current.Add(remaining)
+		}
+		remaining -= available
+		current = nextDay
+	}
+
+	return current.Add(remaining)
+}
+
+func (c *Calendar) isWorkday(day time.Time) bool {
+	return c.workdays[day.Weekday()] && !c.holidays[day.Format("2006-01-02")]
+}
@@ -0,0 +1,72 @@
+package businesshours
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCalendarAdd(t *testing.T) {
+	istanbul, err := time.LoadLocation("Europe/Istanbul")
+	if err != nil {
+		t.Skipf("saat dilimi yüklenemedi: %v", err)
+	}
+	// 23 Nisan 2026 Perşembe tatil; 25-26 Nisan hafta sonu
+	holiday := time.Date(2026, 4, 23, 0, 0, 0, 0, time.UTC)
+	calendar, err := New("Europe/Istanbul", "09:00", "18:00", "1,2,3,4,5", []time.Time{holiday})
+	if err != nil {
+		t.Fatalf("takvim oluşturulamadı: %v", err)
+	}
+
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2026, 4, day, hour, minute, 0, 0, istanbul)
+	}
+
+	tests := []struct {
+		name string
+		from time.Time
+		d    time.Duration
+		want time.Time
+	}{
+		{"aynı gün içinde", at(20, 10, 0), 2 * time.Hour, at(20, 12, 0)},
+		{"mesai bitişinde sonraki güne geçer", at(20, 17, 0), 2 * time.Hour, at(21, 10, 0)},
+		{"mesai başlamadan açılan talep", at(20, 7, 30), time.Hour, at(20, 10, 0)},
+		{"cuma akşamından pazartesiye", at(24, 17, 0), 2 * time.Hour, at(27, 10, 0)},
+		{"hafta sonu açılan talep", at(25, 11, 0), time.Hour, at(27, 10, 0)},
+		{"tatil günü atlanır", at(22, 17, 0), 2 * time.Hour, at(24, 10, 0)},
+		{"tatil ve hafta sonu birlikte", at(22, 15, 0), 16 * time.Hour, at(27, 13, 0)},
+		{"hedef mesai bitişine denk gelir", at(24, 12, 0), 24 * time.Hour, at(28, 18, 0)},
+	}
+	for _, tt := range tests {
+		if got := calendar.Add(tt.from, tt.d); !got.Equal(tt.want) {
+			t.Errorf("%s: Add(%s, %s) = %s, beklenen %s", tt.name, tt.from, tt.d, got.In(istanbul), tt.want)
+		}
+	}
+}
+
+func TestNewReadsHolidaysAsUTCDates(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("saat dilimi yüklenemedi: %v", err)
+	}
+
+	// Veritabanı sürücüsü UTC gece yarısı saklanan tatili yerel saatle, bir önceki günün akşamı olarak döndürebilir
+	holiday := time.Date(2026, 4, 23, 0, 0, 0, 0, time.UTC).In(newYork)
+	calendar, err := New("UTC", "09:00", "18:00", "1,2,3,4,5", []time.Time{holiday})
+	if err != nil {
+		t.Fatalf("takvim oluşturulamadı: %v", err)
+	}
+
+	from := time.Date(2026, 4, 22, 17, 0, 0, 0, time.UTC)
+	want := time.Date(2026, 4, 24, 10, 0, 0, 0, time.UTC)
+	if got := calendar.Add(from, 2*time.Hour); !got.Equal(want) {
+		t.Errorf("Add = %s, beklenen %s; tatil 23 Nisan olarak tanınmalı", got, want)
+	}
+}
+
+func TestNilCalendarAddsWallClockTime(t *testing.T) {
+	var calendar *Calendar
+	from := time.Date(2026, 4, 25, 22, 0, 0, 0, time.UTC)
+	if got := calendar.Add(from, 4*time.Hour); !got.Equal(from.Add(4 * time.Hour)) {
+		t.Errorf("Add = %s, beklenen %s", got, from.Add(4*time.Hour))
+	}
+}
@@ -14,11 +14,9 @@ import (
 // DB paylaşılan veritabanı bağlantısı
 var DB *gorm.DB
 
-// Init veritabanı bağlantısını başlatır ve modelleri migrate eder
-func Init(config *configs.Config) {
-	var err error
-
-	dsn := fmt.Sprintf(
+// DSN yapılandırmadan PostgreSQL bağlantı dizesini oluşturur
+func DSN(config *configs.Config) string {
+	return fmt.Sprintf(
 		"host=%s port=%s user=%s password=%s dbname=%s sslmode=%s",
 		config.DB.Host,
 		config.DB.Port,
@@ -27,6 +25,13 @@ func Init(config *configs.Config) {
 		config.DB.DBName,
 		config.DB.SSLMode,
 	)
+}
+
+// Init veritabanı bağlantısını başlatır ve modelleri migrate eder
+func Init(config *configs.Config) {
+	var err error
+
+	dsn := DSN(config)
 
 	// Geliştirme modunda detaylı günlükleme
 	gormConfig := &gorm.Config{}
@@ -41,20 +46,71 @@ func Init(config *configs.Config) {
 
 	log.Println("Veritabanına başarıyla bağlandı")
 
+	if err := Migrate(DB); err != nil {
+		log.Fatalf("Tabloları migrate ederken hata oluştu: %v", err)
+	}
+
+	log.Println("Veritabanı tabloları başarıyla oluşturuldu")
+}
+
+// Migrate şema öncesi veri düzeltmelerini uygular ve modelleri migrate eder
+func Migrate(db *gorm.DB) error {
+	// Benzersiz indeksler eklenmeden önce tekrarlanan kayıtları temizle
+	if err := removeDuplicatePreferences(db); err != nil {
+		return fmt.Errorf("tekrarlanan kullanıcı tercihleri temizlenemedi: %w", err)
+	}
+
+	// Yabancı anahtar eklenmeden önce atanmamış talepleri NULL olarak işaretle
+	if err := clearUnassignedRequestHandlers(db); err != nil {
+		return fmt.Errorf("atanmamış talepler düzeltilemedi: %w", err)
+	}
+
 	// Tabloları otomatik migrate et
-	err = DB.AutoMigrate(
+	return db.AutoMigrate(
 		&model.User{},
 		&model.UserRequest{},
 		&model.UserDocument{},
 		&model.UserDevice{},
 		&model.UserPreference{},
 		&model.UserCommunication{},
+		&model.NotificationDelivery{},
+		&model.MessageTemplate{},
+		&model.Announcement{},
+		&model.DataExport{},
 		&model.Role{},
 		&model.Staff{},
+		&model.LeaveType{},
+		&model.LeaveBalance{},
+		&model.LeaveRequest{},
+		&model.Delegation{},
+		&model.RequestComment{},
+		&model.RequestCommentRevision{},
+		&model.RequestCommentAttachment{},
+		&model.BusinessCalendar{},
+		&model.BusinessHoliday{},
+		&model.SLAPolicy{},
+		&model.RoutingRule{},
 	)
-	if err != nil {
-		log.Fatalf("Tabloları migrate ederken hata oluştu: %v", err)
+}
+
+// removeDuplicatePreferences aynı kullanıcı ve anahtar için birden fazla tercih kaydı varsa en son
+// güncelleneni bırakıp diğerlerini siler; (user_id, preference_key) benzersiz indeksi için gereklidir
+func removeDuplicatePreferences(db *gorm.DB) error {
+	if !db.Migrator().HasTable(&model.UserPreference{}) {
+		return nil
 	}
+	return db.Exec(`
+		DELETE FROM user_preferences a USING user_preferences b
+		WHERE a.user_id = b.user_id AND a.preference_key = b.preference_key
+		AND (a.updated_at < b.updated_at OR (a.updated_at = b.updated_at AND a.id < b.id))
+	`).Error
+}
 
-	log.Println("Veritabanı tabloları başarıyla oluşturuldu")
+// clearUnassignedRequestHandlers eski şemada atanmamış talepleri gösteren handled_by_staff_id = 0
+// değerlerini NULL yapar; aksi halde personel yabancı anahtarı oluşturulamaz ve talepler atanmış görünür
+func clearUnassignedRequestHandlers(db *gorm.DB) error {
+	if !db.Migrator().HasTable(&model.UserRequest{}) {
+		return nil
+	}
+	return db.Exec("UPDATE user_requests SET handled_by_staff_id = NULL WHERE handled_by_staff_id = 0").Error
 }
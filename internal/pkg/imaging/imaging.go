@@ -0,0 +1,139 @@
+// Package imaging yüklenen görselleri küçük resimlere dönüştürmek için yardımcılar sağlar.
+//
+// Görseller yeniden kodlandığından EXIF dahil tüm meta veriler çıktıdan atılır;
+// JPEG dosyalarındaki EXIF yönlendirme bilgisi ise atılmadan önce görüntüye uygulanır.
+package imaging
+
+import (
+	"encoding/binary"
+	"image"
+	"image/color"
+
+	"golang.org/x/image/draw"
+)
+
+// exifOrientationTag EXIF IFD0 içindeki yönlendirme etiketi
+const exifOrientationTag = 0x0112
+
+// Orientation JPEG verisindeki EXIF yönlendirme değerini (1-8) döndürür; bulunamazsa 1 döner
+func Orientation(data []byte) int {
+	if len(data) < 4 || data[0] != 0xFF || data[1] != 0xD8 {
+		return 1
+	}
+
+	for i := 2; i+4 <= len(data); {
+		if data[i] != 0xFF {
+			return 1
+		}
+		marker := data[i+1]
+		if marker == 0xDA || marker == 0xD9 {
+			// Görüntü verisine ulaşıldı, meta veri bölümü bitti
+			return 1
+		}
+
+		length := int(binary.BigEndian.Uint16(data[i+2:]))
+		if length < 2 || i+2+length > len(data) {
+			return 1
+		}
+
+		segment := data[i+4 : i+2+length]
+		if marker == 0xE1 && len(segment) > 6 && string(segment[:6]) == "Exif\x00\x00" {
+			return exifOrientation(segment[6:])
+		}
+		i += 2 + length
+	}
+	return 1
+}
+
+func exifOrientation(tiff []byte) int {
+	if len(tiff) < 8 {
+		return 1
+	}
+
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 1
+	}
+
+	offset := int(order.Uint32(tiff[4:]))
+	if offset < 8 || offset+2 > len(tiff) {
+		return 1
+	}
+
+	count := int(order.Uint16(tiff[offset:]))
+	for n := 0; n < count; n++ {
+		entry := offset + 2 + n*12
+		if entry+12 > len(tiff) {
+			return 1
+		}
+		if order.Uint16(tiff[entry:]) == exifOrientationTag {
+			if value := int(order.Uint16(tiff[entry+8:])); value >= 1 && value <= 8 {
+				return value
+			}
+			return 1
+		}
+	}
+	return 1
+}
+
+// Thumbnail görüntünün ortasından kare bir bölüm kırparak size x size boyutuna ölçekler.
+// Saydam alanlar beyaz zemin üzerine yerleştirilir. Kaynak size'dan küçükse büyütülmez.
+func Thumbnail(src image.Image, size int) *image.RGBA {
+	bounds := src.Bounds()
+	side := min(bounds.Dx(), bounds.Dy())
+	size = min(size, side)
+
+	x := bounds.Min.X + (bounds.Dx()-side)/2
+	y := bounds.Min.Y + (bounds.Dy()-side)/2
+	crop := image.Rect(x, y, x+side, y+side)
+
+	dst := image.NewRGBA(image.Rect(0, 0, size, size))
+	draw.Draw(dst, dst.Bounds(), image.NewUniform(color.White), image.Point{}, draw.Src)
+	draw.CatmullRom.Scale(dst, dst.Bounds(), src, crop, draw.Over, nil)
+	return dst
+}
+
+// Orient görüntüyü EXIF yönlendirme değerine göre döndürür veya aynalar
+func Orient(src *image.RGBA, orientation int) *image.RGBA {
+	if orientation < 2 || orientation > 8 {
+		return src
+	}
+
+	bounds := src.Bounds()
+	w, h := bounds.Dx(), bounds.Dy()
+
+	dstWidth, dstHeight := w, h
+	if orientation >= 5 {
+		dstWidth, dstHeight = h, w
+	}
+	dst := image.NewRGBA(image.Rect(0, 0, dstWidth, dstHeight))
+
+	for y := 0; y < dstHeight; y++ {
+		for x := 0; x < dstWidth; x++ {
+			var sx, sy int
+			switch orientation {
+			case 2: // yatay aynalama
+				sx, sy = w-1-x, y
+			case 3: // 180° döndürme
+				sx, sy = w-1-x, h-1-y
+			case 4: // dikey aynalama
+				sx, sy = x, h-1-y
+			case 5: // ana köşegene göre aynalama
+				sx, sy = y, x
+			case 6: // saat yönünde 90°
+				sx, sy = y, h-1-x
+			case 7: // ters köşegene göre aynalama
+				sx, sy = w-1-y, h-1-x
+			case 8: // saat yönünün tersine 90°
+				sx, sy = w-1-y, x
+			}
+			dst.SetRGBA(x, y, src.RGBAAt(bounds.Min.X+sx, bounds.Min.Y+sy))
+		}
+	}
+	return dst
+}
@@ -0,0 +1,95 @@
+package imaging
+
+import (
+	"encoding/binary"
+	"image"
+	"image/color"
+	"testing"
+)
+
+// exifJPEG verilen yönlendirme değerini taşıyan APP1 bölümlü en küçük JPEG başlığını üretir
+func exifJPEG(order binary.ByteOrder, orientation uint16) []byte {
+	tiff := make([]byte, 8+2+12+4)
+	if order == binary.LittleEndian {
+		copy(tiff, "II")
+	} else {
+		copy(tiff, "MM")
+	}
+	order.PutUint16(tiff[2:], 42)
+	order.PutUint32(tiff[4:], 8)
+	order.PutUint16(tiff[8:], 1)
+	order.PutUint16(tiff[10:], exifOrientationTag)
+	order.PutUint16(tiff[12:], 3) // SHORT
+	order.PutUint32(tiff[14:], 1)
+	order.PutUint16(tiff[18:], orientation)
+
+	segment := append([]byte("Exif\x00\x00"), tiff...)
+	data := []byte{0xFF, 0xD8, 0xFF, 0xE1, 0, 0}
+	binary.BigEndian.PutUint16(data[4:], uint16(len(segment)+2))
+	data = append(data, segment...)
+	return append(data, 0xFF, 0xDA, 0, 2)
+}
+
+func TestOrientation(t *testing.T) {
+	tests := []struct {
+		name string
+		data []byte
+		want int
+	}{
+		{"little endian", exifJPEG(binary.LittleEndian, 6), 6},
+		{"big endian", exifJPEG(binary.BigEndian, 8), 8},
+		{"geçersiz değer", exifJPEG(binary.LittleEndian, 9), 1},
+		{"JPEG değil", []byte("\x89PNG\r\n\x1a\n"), 1},
+		{"EXIF yok", []byte{0xFF, 0xD8, 0xFF, 0xDA, 0, 2}, 1},
+		{"kesik veri", exifJPEG(binary.LittleEndian, 6)[:12], 1},
+		{"boş", nil, 1},
+	}
+	for _, tt := range tests {
+		if got := Orientation(tt.data); got != tt.want {
+			t.Errorf("%s: Orientation = %d, beklenen %d", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestOrient(t *testing.T) {
+	// 3x2 görüntü; her piksel kendi koordinatını taşır
+	src := image.NewRGBA(image.Rect(0, 0, 3, 2))
+	for y := 0; y < 2; y++ {
+		for x := 0; x < 3; x++ {
+			src.SetRGBA(x, y, color.RGBA{R: uint8(x), G: uint8(y), A: 255})
+		}
+	}
+	pixel := func(img *image.RGBA, x, y int) [2]uint8 {
+		c := img.RGBAAt(x, y)
+		return [2]uint8{c.R, c.G}
+	}
+
+	tests := []struct {
+		orientation   int
+		width, height int
+		topLeft       [2]uint8 // Sonuçtaki sol üst pikselin kaynaktaki koordinatı
+		topRight      [2]uint8
+	}{
+		{1, 3, 2, [2]uint8{0, 0}, [2]uint8{2, 0}},
+		{2, 3, 2, [2]uint8{2, 0}, [2]uint8{0, 0}},
+		{3, 3, 2, [2]uint8{2, 1}, [2]uint8{0, 1}},
+		{4, 3, 2, [2]uint8{0, 1}, [2]uint8{2, 1}},
+		{5, 2, 3, [2]uint8{0, 0}, [2]uint8{0, 1}},
+		{6, 2, 3, [2]uint8{0, 1}, [2]uint8{0, 0}},
+		{7, 2, 3, [2]uint8{2, 1}, [2]uint8{2, 0}},
+		{8, 2, 3, [2]uint8{2, 0}, [2]uint8{2, 1}},
+	}
+	for _, tt := range tests {
+		dst := Orient(src, tt.orientation)
+		if dst.Bounds().Dx() != tt.width || dst.Bounds().Dy() != tt.height {
+			t.Errorf("yönlendirme %d: boyut %v, beklenen %dx%d", tt.orientation, dst.Bounds().Size(), tt.width, tt.height)
+			continue
+		}
+		if got := pixel(dst, 0, 0); got != tt.topLeft {
+			t.Errorf("yönlendirme %d: sol üst %v, beklenen %v", tt.orientation, got, tt.topLeft)
+		}
+		if got := pixel(dst, tt.width-1, 0); got != tt.topRight {
+			t.Errorf("yönlendirme %d: sağ üst %v, beklenen %v", tt.orientation, got, tt.topRight)
+		}
+	}
+}
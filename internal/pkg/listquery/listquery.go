@@ -0,0 +1,191 @@
+package listquery
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Sıralama alanı değer türleri; imleç değerinin doğru türe çevrilmesi için kullanılır
+const (
+	KindString = "string"
+	KindInt    = "int"
+	KindTime   = "time"
+)
+
+// SortField API'de kullanılan sıralama anahtarının veritabanı karşılığı
+type SortField struct {
+	Column string
+	Kind   string
+}
+
+// Spec bir liste endpoint'inin izin verilen sıralama alanlarını ve sayfa sınırlarını tanımlar
+type Spec struct {
+	SortFields   map[string]SortField
+	DefaultSort  string
+	DefaultDesc  bool
+	IDColumn     string // Eşit sıralama değerlerinde kullanılan benzersiz sütun
+	DefaultLimit int
+	MaxLimit     int
+}
+
+// Query istemciden gelen arama, sıralama ve sayfalama parametreleri
+type Query struct {
+	Search  string
+	SortKey string
+	Desc    bool
+	Limit   int
+	After   *Cursor
+}
+
+// Cursor bir önceki sayfanın son kaydını işaret eder
+type Cursor struct {
+	Sort  string `json:"s"`
+	Desc  bool   `json:"d"`
+	Value string `json:"v"`
+	ID    uint   `json:"id"`
+}
+
+// Page imleç tabanlı sayfalama sonucu
+type Page[T any] struct {
+	Items      []T    `json:"items"`
+	NextCursor string `json:"next_cursor,omitempty"`
+	HasMore    bool   `json:"has_more"`
+	Limit      int    `json:"limit"`
+}
+
+// Parse q, sort, limit ve cursor sorgu parametrelerini okur.
+// sort parametresi azalan sıralama için "-" önekiyle verilir (ör. sort=-hire_date).
+func Parse(values url.Values, spec Spec) (*Query, error) {
+	query := &Query{
+		Search:  strings.TrimSpace(values.Get("q")),
+		SortKey: spec.DefaultSort,
+		Desc:    spec.DefaultDesc,
+		Limit:   spec.DefaultLimit,
+	}
+
+	if sort := values.Get("sort"); sort != "" {
+		query.Desc = strings.HasPrefix(sort, "-")
+		query.SortKey = strings.TrimPrefix(sort, "-")
+		if _, ok := spec.SortFields[query.SortKey]; !ok {
+			return nil, fmt.Errorf("geçersiz sıralama alanı: %s", query.SortKey)
+		}
+	}
+
+	if limit := values.Get("limit"); limit != "" {
+		parsed, err := strconv.Atoi(limit)
+		if err != nil || parsed < 1 {
+			return nil, errors.New("limit pozitif bir tam sayı olmalıdır")
+		}
+		query.Limit = parsed
+	}
+	if spec.MaxLimit > 0 && query.Limit > spec.MaxLimit {
+		query.Limit = spec.MaxLimit
+	}
+
+	if encoded := values.Get("cursor"); encoded != "" {
+		cursor, err := decodeCursor(encoded)
+		if err != nil {
+			return nil, errors.New("geçersiz imleç")
+		}
+		if cursor.Sort != query.SortKey || cursor.Desc != query.Desc {
+			return nil, errors.New("imleç farklı bir sıralama için oluşturulmuş")
+		}
+		query.After = cursor
+	}
+
+	return query, nil
+}
+
+// Paginate sorguya imleç koşulunu, sıralamayı ve sınırı uygular; bir sonraki sayfanın imlecini hesaplar.
+// key her kaydın sıralama değerini ve benzersiz ID'sini döndürmelidir.
+func Paginate[T any](db *gorm.DB, query *Query, spec Spec, key func(item *T) (interface{}, uint)) (*Page[T], error) {
+	field := spec.SortFields[query.SortKey]
+
+	direction, comparison := "ASC", ">"
+	if query.Desc {
+		direction, comparison = "DESC", "<"
+	}
+
+	if query.After != nil {
+		value, err := parseValue(field.Kind, query.After.Value)
+		if err != nil {
+			return nil, errors.New("geçersiz imleç")
+		}
+		db = db.Where(
+			fmt.Sprintf("((%s %s ?) OR (%s = ? AND %s %s ?))", field.Column, comparison, field.Column, spec.IDColumn, comparison),
+			value, value, query.After.ID,
+		)
+	}
+
+	var items []T
+	err := db.Order(fmt.Sprintf("%s %s, %s %s", field.Column, direction, spec.IDColumn, direction)).
+		Limit(query.Limit + 1).
+		Find(&items).Error
+	if err != nil {
+		return nil, err
+	}
+
+	page := &Page[T]{Items: items, Limit: query.Limit}
+	if len(items) > query.Limit {
+		page.Items = items[:query.Limit]
+		page.HasMore = true
+
+		value, id := key(&page.Items[len(page.Items)-1])
+		page.NextCursor = encodeCursor(Cursor{
+			Sort:  query.SortKey,
+			Desc:  query.Desc,
+			Value: formatValue(value),
+			ID:    id,
+		})
+	}
+
+	return page, nil
+}
+
+func encodeCursor(cursor Cursor) string {
+	data, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeCursor(encoded string) (*Cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, err
+	}
+
+	var cursor Cursor
+	if err := json.Unmarshal(data, &cursor); err != nil {
+		return nil, err
+	}
+	return &cursor, nil
+}
+
+func formatValue(value interface{}) string {
+	switch v := value.(type) {
+	case time.Time:
+		return v.UTC().Format(time.RFC3339Nano)
+	case string:
+		return v
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+func parseValue(kind, value string) (interface{}, error) {
+	switch kind {
+	case KindTime:
+		return time.Parse(time.RFC3339Nano, value)
+	case KindInt:
+		return strconv.ParseInt(value, 10, 64)
+	default:
+		return value, nil
+	}
+}
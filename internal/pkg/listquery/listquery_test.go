@@ -0,0 +1,96 @@
+package listquery
+
+import (
+	"net/url"
+	"testing"
+	"time"
+)
+
+var testSpec = Spec{
+	SortFields: map[string]SortField{
+		"id":         {Column: "id", Kind: KindInt},
+		"name":       {Column: "name", Kind: KindString},
+		"created_at": {Column: "created_at", Kind: KindTime},
+	},
+	DefaultSort:  "id",
+	IDColumn:     "id",
+	DefaultLimit: 20,
+	MaxLimit:     100,
+}
+
+func TestCursorRoundTrip(t *testing.T) {
+	createdAt := time.Date(2026, 4, 20, 9, 30, 15, 123456789, time.FixedZone("TRT", 3*60*60))
+
+	tests := []struct {
+		sort  string
+		value interface{}
+		want  interface{}
+	}{
+		{"-created_at", createdAt, createdAt},
+		{"name", "Ayşe Yılmaz", "Ayşe Yılmaz"},
+		{"id", uint(42), int64(42)},
+	}
+	for _, tt := range tests {
+		values := url.Values{"sort": {tt.sort}}
+		first, err := Parse(values, testSpec)
+		if err != nil {
+			t.Fatalf("%s: sorgu çözümlenemedi: %v", tt.sort, err)
+		}
+
+		encoded := encodeCursor(Cursor{Sort: first.SortKey, Desc: first.Desc, Value: formatValue(tt.value), ID: 7})
+		values.Set("cursor", encoded)
+		next, err := Parse(values, testSpec)
+		if err != nil {
+			t.Fatalf("%s: imleç çözümlenemedi: %v", tt.sort, err)
+		}
+		if next.After == nil || next.After.ID != 7 {
+			t.Fatalf("%s: imleç okunamadı: %+v", tt.sort, next.After)
+		}
+
+		got, err := parseValue(testSpec.SortFields[next.SortKey].Kind, next.After.Value)
+		if err != nil {
+			t.Fatalf("%s: imleç değeri çözümlenemedi: %v", tt.sort, err)
+		}
+		if want, ok := tt.want.(time.Time); ok {
+			if !got.(time.Time).Equal(want) {
+				t.Errorf("%s: imleç değeri %v, beklenen %v", tt.sort, got, want)
+			}
+		} else if got != tt.want {
+			t.Errorf("%s: imleç değeri %v, beklenen %v", tt.sort, got, tt.want)
+		}
+	}
+}
+
+func TestParseRejectsMismatchedCursor(t *testing.T) {
+	encoded := encodeCursor(Cursor{Sort: "name", Value: "a", ID: 1})
+
+	tests := []url.Values{
+		{"sort": {"id"}, "cursor": {encoded}},
+		{"sort": {"-name"}, "cursor": {encoded}},
+		{"cursor": {"not-base64!"}},
+	}
+	for _, values := range tests {
+		if _, err := Parse(values, testSpec); err == nil {
+			t.Errorf("%v için hata bekleniyordu", values)
+		}
+	}
+}
+
+func TestParseLimits(t *testing.T) {
+	query, err := Parse(url.Values{"limit": {"500"}}, testSpec)
+	if err != nil {
+		t.Fatalf("sorgu çözümlenemedi: %v", err)
+	}
+	if query.Limit != testSpec.MaxLimit {
+		t.Errorf("limit %d, beklenen %d", query.Limit, testSpec.MaxLimit)
+	}
+
+	for _, limit := range []string{"0", "-1", "abc"} {
+		if _, err := Parse(url.Values{"limit": {limit}}, testSpec); err == nil {
+			t.Errorf("limit=%s için hata bekleniyordu", limit)
+		}
+	}
+	if _, err := Parse(url.Values{"sort": {"password"}}, testSpec); err == nil {
+		t.Error("tanımsız sıralama alanı için hata bekleniyordu")
+	}
+}
@@ -0,0 +1,228 @@
+package messaging
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/tls"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Email gönderilecek e-posta iletisi. HTML boşsa yalnızca düz metin gönderilir.
+type Email struct {
+	To      string
+	Subject string
+	Text    string
+	HTML    string
+}
+
+// Mailer e-posta gönderen sağlayıcı
+type Mailer interface {
+	Send(ctx context.Context, email Email) error
+}
+
+// SMTPConfig SMTP sunucusu ayarları. Kullanıcı adı boşsa kimlik doğrulaması yapılmaz
+// (ör. yerel geliştirmede MailHog: localhost:1025).
+type SMTPConfig struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+	From     string
+}
+
+// SMTPMailer e-postaları SMTP üzerinden gönderen Mailer uygulaması
+type SMTPMailer struct {
+	config SMTPConfig
+}
+
+// NewSMTPMailer yeni bir SMTPMailer oluşturur
+func NewSMTPMailer(config SMTPConfig) (*SMTPMailer, error) {
+	if config.Host == "" {
+		return nil, errors.New("SMTP sunucusu belirtilmedi")
+	}
+	if config.From == "" {
+		return nil, errors.New("gönderen e-posta adresi belirtilmedi")
+	}
+	if _, err := mail.ParseAddress(config.From); err != nil {
+		return nil, fmt.Errorf("geçersiz gönderen adresi: %w", err)
+	}
+	if config.Port == 0 {
+		config.Port = 587
+	}
+	return &SMTPMailer{config: config}, nil
+}
+
+// Send e-postayı gönderir. Sunucu destekliyorsa STARTTLS kullanılır.
+// 5xx SMTP yanıtları kalıcı hata olarak döner.
+func (m *SMTPMailer) Send(ctx context.Context, email Email) error {
+	if email.To == "" {
+		return Permanent(errors.New("alıcı e-posta adresi yok"))
+	}
+
+	message, err := buildMessage(m.config.From, email)
+	if err != nil {
+		return Permanent(err)
+	}
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(m.config.Host, strconv.Itoa(m.config.Port)))
+	if err != nil {
+		return err
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+
+	client, err := smtp.NewClient(conn, m.config.Host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: m.config.Host}); err != nil {
+			return err
+		}
+	}
+	if m.config.Username != "" {
+		if ok, _ := client.Extension("AUTH"); ok {
+			auth := smtp.PlainAuth("", m.config.Username, m.config.Password, m.config.Host)
+			if err := client.Auth(auth); err != nil {
+				return classifySMTPError(err)
+			}
+		}
+	}
+
+	// Zarf adresi görünen ad içermemelidir ("Uygulama <no-reply@ornek.com>" -> "no-reply@ornek.com")
+	sender, _ := mail.ParseAddress(m.config.From)
+	if err := client.Mail(sender.Address); err != nil {
+		return classifySMTPError(err)
+	}
+	if err := client.Rcpt(email.To); err != nil {
+		return classifySMTPError(err)
+	}
+
+	writer, err := client.Data()
+	if err != nil {
+		return classifySMTPError(err)
+	}
+	if _, err := writer.Write(message); err != nil {
+		return err
+	}
+	if err := writer.Close(); err != nil {
+		return classifySMTPError(err)
+	}
+	return client.Quit()
+}
+
+// classifySMTPError 5xx SMTP yanıtlarını kalıcı hata olarak işaretler
+func classifySMTPError(err error) error {
+	var protoErr *textproto.Error
+	if errors.As(err, &protoErr) && protoErr.Code >= 500 {
+		return Permanent(err)
+	}
+	return err
+}
+
+// buildMessage RFC 5322 uyumlu, quoted-printable kodlanmış bir ileti oluşturur
+func buildMessage(from string, email Email) ([]byte, error) {
+	var buf bytes.Buffer
+
+	messageID, err := newMessageID(from)
+	if err != nil {
+		return nil, err
+	}
+
+	// Başlık alanlarına satır sonu eklenerek yeni başlık enjekte edilmesini önle
+	flatten := strings.NewReplacer("\r", " ", "\n", " ")
+
+	header := textproto.MIMEHeader{}
+	header.Set("From", from)
+	header.Set("To", flatten.Replace(email.To))
+	header.Set("Subject", mime.QEncoding.Encode("utf-8", flatten.Replace(email.Subject)))
+	header.Set("Date", time.Now().Format(time.RFC1123Z))
+	header.Set("Message-ID", messageID)
+	header.Set("MIME-Version", "1.0")
+
+	if email.HTML == "" {
+		header.Set("Content-Type", "text/plain; charset=utf-8")
+		header.Set("Content-Transfer-Encoding", "quoted-printable")
+		writeHeader(&buf, header)
+		if err := writeQuotedPrintable(&buf, email.Text); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	}
+
+	var body bytes.Buffer
+	parts := multipart.NewWriter(&body)
+	header.Set("Content-Type", "multipart/alternative; boundary="+parts.Boundary())
+	writeHeader(&buf, header)
+
+	for _, part := range []struct{ contentType, content string }{
+		{"text/plain; charset=utf-8", email.Text},
+		{"text/html; charset=utf-8", email.HTML},
+	} {
+		writer, err := parts.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return nil, err
+		}
+		if err := writeQuotedPrintable(writer, part.content); err != nil {
+			return nil, err
+		}
+	}
+	if err := parts.Close(); err != nil {
+		return nil, err
+	}
+
+	buf.Write(body.Bytes())
+	return buf.Bytes(), nil
+}
+
+func writeHeader(buf *bytes.Buffer, header textproto.MIMEHeader) {
+	for _, key := range []string{"From", "To", "Subject", "Date", "Message-ID", "MIME-Version", "Content-Type", "Content-Transfer-Encoding"} {
+		if value := header.Get(key); value != "" {
+			fmt.Fprintf(buf, "%s: %s\r\n", key, value)
+		}
+	}
+	buf.WriteString("\r\n")
+}
+
+func writeQuotedPrintable(w io.Writer, content string) error {
+	writer := quotedprintable.NewWriter(w)
+	if _, err := writer.Write([]byte(content)); err != nil {
+		return err
+	}
+	return writer.Close()
+}
+
+func newMessageID(from string) (string, error) {
+	random := make([]byte, 12)
+	if _, err := rand.Read(random); err != nil {
+		return "", err
+	}
+
+	domain := "localhost"
+	if address, err := mail.ParseAddress(from); err == nil {
+		domain = address.Address[strings.LastIndex(address.Address, "@")+1:]
+	}
+	return fmt.Sprintf("<%s@%s>", hex.EncodeToString(random), domain), nil
+}
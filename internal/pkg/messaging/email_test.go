@@ -0,0 +1,90 @@
+package messaging
+
+import (
+	"bytes"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"strings"
+	"testing"
+)
+
+func TestBuildMessageFlattensHeaders(t *testing.T) {
+	message, err := buildMessage("Uygulama <no-reply@example.com>", Email{
+		To:      "ayse@example.com\r\nBcc: attacker@example.com",
+		Subject: "Merhaba\nBcc: attacker@example.com",
+		Text:    "Düz metin",
+	})
+	if err != nil {
+		t.Fatalf("ileti oluşturulamadı: %v", err)
+	}
+
+	parsed, err := mail.ReadMessage(bytes.NewReader(message))
+	if err != nil {
+		t.Fatalf("ileti çözümlenemedi: %v", err)
+	}
+	if bcc := parsed.Header.Get("Bcc"); bcc != "" {
+		t.Errorf("başlık enjekte edildi: Bcc: %s", bcc)
+	}
+	if to := parsed.Header.Get("To"); strings.ContainsAny(to, "\r\n") || !strings.HasPrefix(to, "ayse@example.com") {
+		t.Errorf("beklenmeyen To başlığı: %q", to)
+	}
+
+	subject, err := new(mime.WordDecoder).DecodeHeader(parsed.Header.Get("Subject"))
+	if err != nil || subject != "Merhaba Bcc: attacker@example.com" {
+		t.Errorf("konu %q, hata %v", subject, err)
+	}
+	if id := parsed.Header.Get("Message-ID"); !strings.HasSuffix(id, "@example.com>") {
+		t.Errorf("beklenmeyen Message-ID: %s", id)
+	}
+
+	body, err := io.ReadAll(quotedprintable.NewReader(parsed.Body))
+	if err != nil || string(body) != "Düz metin" {
+		t.Errorf("gövde %q, hata %v", body, err)
+	}
+}
+
+func TestBuildMessageWithHTML(t *testing.T) {
+	message, err := buildMessage("no-reply@example.com", Email{
+		To:      "ayse@example.com",
+		Subject: "Bildirim",
+		Text:    "Düz metin",
+		HTML:    "<p>HTML içerik</p>",
+	})
+	if err != nil {
+		t.Fatalf("ileti oluşturulamadı: %v", err)
+	}
+
+	parsed, err := mail.ReadMessage(bytes.NewReader(message))
+	if err != nil {
+		t.Fatalf("ileti çözümlenemedi: %v", err)
+	}
+	mediaType, params, err := mime.ParseMediaType(parsed.Header.Get("Content-Type"))
+	if err != nil || mediaType != "multipart/alternative" {
+		t.Fatalf("içerik türü %q, hata %v", mediaType, err)
+	}
+
+	reader := multipart.NewReader(parsed.Body, params["boundary"])
+	var parts []string
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("bölüm okunamadı: %v", err)
+		}
+		// multipart.Reader quoted-printable bölümleri kendisi çözer
+		content, err := io.ReadAll(part)
+		if err != nil {
+			t.Fatalf("bölüm içeriği okunamadı: %v", err)
+		}
+		parts = append(parts, string(content))
+	}
+
+	if len(parts) != 2 || parts[0] != "Düz metin" || parts[1] != "<p>HTML içerik</p>" {
+		t.Errorf("beklenmeyen bölümler: %q", parts)
+	}
+}
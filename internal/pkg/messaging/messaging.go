@@ -0,0 +1,81 @@
+// Package messaging bildirimleri uygulama dışına ileten sağlayıcıları (SMTP e-posta, SMS, push) içerir.
+//
+// Sağlayıcılar yeniden denenmesi anlamsız hataları Permanent ile işaretler; teslimat kuyruğu
+// bu hatalarda yeniden denemeyi bırakır.
+package messaging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// defaultHTTPTimeout HTTP tabanlı sağlayıcılar için istek zaman aşımı
+const defaultHTTPTimeout = 15 * time.Second
+
+// PermanentError yeniden denendiğinde de başarısız olacak teslimat hatası
+type PermanentError struct {
+	Err error
+}
+
+func (e *PermanentError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *PermanentError) Unwrap() error {
+	return e.Err
+}
+
+// Permanent hatayı kalıcı olarak işaretler
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &PermanentError{Err: err}
+}
+
+// IsPermanent hatanın kalıcı olarak işaretlenip işaretlenmediğini döndürür
+func IsPermanent(err error) bool {
+	var permanent *PermanentError
+	return errors.As(err, &permanent)
+}
+
+// postJSON payload'ı JSON olarak gönderir. 429 dışındaki 4xx yanıtlar kalıcı hata sayılır.
+func postJSON(ctx context.Context, client *http.Client, url, token string, payload interface{}) (int, error) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return 0, Permanent(err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return 0, Permanent(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		io.Copy(io.Discard, resp.Body)
+		return resp.StatusCode, nil
+	}
+
+	detail, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+	err = fmt.Errorf("sağlayıcı %d yanıtı döndürdü: %s", resp.StatusCode, bytes.TrimSpace(detail))
+	if resp.StatusCode >= 400 && resp.StatusCode < 500 && resp.StatusCode != http.StatusTooManyRequests {
+		return resp.StatusCode, Permanent(err)
+	}
+	return resp.StatusCode, err
+}
@@ -0,0 +1,59 @@
+package messaging
+
+import (
+	"context"
+	"errors"
+	"net/http"
+)
+
+// ErrUnregistered cihaz jetonunun push servisinde artık geçerli olmadığını belirtir.
+// Bu hata kalıcıdır; jeton cihaz kaydından silinmelidir.
+var ErrUnregistered = errors.New("cihaz jetonu geçersiz veya kaydı silinmiş")
+
+// PushMessage cihaza gönderilecek bildirim
+type PushMessage struct {
+	Title string            `json:"title"`
+	Body  string            `json:"body"`
+	Data  map[string]string `json:"data,omitempty"`
+}
+
+// PushSender cihazlara push bildirimi gönderen sağlayıcı
+type PushSender interface {
+	Push(ctx context.Context, deviceToken string, message PushMessage) error
+}
+
+// HTTPPushConfig HTTP tabanlı push ağ geçidi ayarları
+type HTTPPushConfig struct {
+	URL   string
+	Token string
+}
+
+// HTTPPushSender bildirimleri {"token", "notification"} JSON gövdesiyle bir push ağ geçidine gönderir.
+// 404 ve 410 yanıtları cihaz jetonunun geçersiz olduğu şeklinde yorumlanır.
+type HTTPPushSender struct {
+	config HTTPPushConfig
+	client *http.Client
+}
+
+// NewHTTPPushSender yeni bir HTTPPushSender oluşturur
+func NewHTTPPushSender(config HTTPPushConfig) (*HTTPPushSender, error) {
+	if config.URL == "" {
+		return nil, errors.New("push ağ geçidi adresi belirtilmedi")
+	}
+	return &HTTPPushSender{
+		config: config,
+		client: &http.Client{Timeout: defaultHTTPTimeout},
+	}, nil
+}
+
+// Push bildirimi cihaza gönderir
+func (s *HTTPPushSender) Push(ctx context.Context, deviceToken string, message PushMessage) error {
+	status, err := postJSON(ctx, s.client, s.config.URL, s.config.Token, map[string]interface{}{
+		"token":        deviceToken,
+		"notification": message,
+	})
+	if status == http.StatusNotFound || status == http.StatusGone {
+		return Permanent(ErrUnregistered)
+	}
+	return err
+}
@@ -0,0 +1,51 @@
+package messaging
+
+import (
+	"context"
+	"errors"
+	"net/http"
+)
+
+// SMSSender kısa mesaj gönderen sağlayıcı
+type SMSSender interface {
+	SendSMS(ctx context.Context, to, message string) error
+}
+
+// HTTPSMSConfig HTTP tabanlı SMS ağ geçidi ayarları
+type HTTPSMSConfig struct {
+	URL   string
+	Token string
+	From  string // Gönderici adı veya numarası
+}
+
+// HTTPSMSSender mesajları {"from","to","message"} JSON gövdesiyle bir HTTP ağ geçidine gönderir.
+// Gerçek bir sağlayıcıya geçilene kadar yerel bir sahte sunucuyla da kullanılabilir.
+type HTTPSMSSender struct {
+	config HTTPSMSConfig
+	client *http.Client
+}
+
+// NewHTTPSMSSender yeni bir HTTPSMSSender oluşturur
+func NewHTTPSMSSender(config HTTPSMSConfig) (*HTTPSMSSender, error) {
+	if config.URL == "" {
+		return nil, errors.New("SMS ağ geçidi adresi belirtilmedi")
+	}
+	return &HTTPSMSSender{
+		config: config,
+		client: &http.Client{Timeout: defaultHTTPTimeout},
+	}, nil
+}
+
+// SendSMS mesajı gönderir
+func (s *HTTPSMSSender) SendSMS(ctx context.Context, to, message string) error {
+	if to == "" {
+		return Permanent(errors.New("alıcı telefon numarası yok"))
+	}
+
+	_, err := postJSON(ctx, s.client, s.config.URL, s.config.Token, map[string]string{
+		"from":    s.config.From,
+		"to":      to,
+		"message": message,
+	})
+	return err
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/UmutTKMN/go-backend/configs"
 	"github.com/UmutTKMN/go-backend/internal/app/model"
@@ -74,9 +75,19 @@ func AuthMiddleware() gin.HandlerFunc {
 				return
 			}
 
+			// Silme isteğinden önce alınmış token'lar geçersizdir; yeniden giriş silme işlemini iptal eder
+			if user.DeletionScheduledAt != nil {
+				c.JSON(http.StatusUnauthorized, gin.H{"error": "Hesabınız silinmek üzere; iptal etmek için yeniden giriş yapın"})
+				c.Abort()
+				return
+			}
+
 			// Kullanıcıyı context'e ekle
 			c.Set("user", user)
 			c.Set("userID", uint(userID))
+			if expiresAt, err := claims.GetExpirationTime(); err == nil && expiresAt != nil {
+				c.Set("tokenExpiresAt", expiresAt.Time)
+			}
 
 			// Son aktivite zamanını güncelle
 			database.DB.Model(&user).Update("last_activity", database.DB.NowFunc())
@@ -90,6 +101,51 @@ func AuthMiddleware() gin.HandlerFunc {
 	}
 }
 
+// OptionalAuthMiddleware Authorization başlığı varsa AuthMiddleware ile doğrular, yoksa isteği anonim olarak geçirir.
+// Geçersiz token gönderen istekler anonim kabul edilmez, reddedilir.
+func OptionalAuthMiddleware() gin.HandlerFunc {
+	auth := AuthMiddleware()
+	return func(c *gin.Context) {
+		if c.GetHeader("Authorization") == "" {
+			c.Next()
+			return
+		}
+		auth(c)
+	}
+}
+
+// queryTokenKey StripQueryToken'ın URL'den çıkardığı token'ı sakladığı context anahtarı
+const queryTokenKey = "queryAccessToken"
+
+// StripQueryToken access_token sorgu parametresini URL'den çıkarıp context'e taşır. Token'ın erişim
+// günlüklerine düşmemesi için istek günlüğü middleware'inden önce router'a eklenmelidir.
+func StripQueryToken() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		query := c.Request.URL.Query()
+		if token := query.Get("access_token"); token != "" {
+			query.Del("access_token")
+			c.Request.URL.RawQuery = query.Encode()
+			c.Request.RequestURI = c.Request.URL.RequestURI()
+			c.Set(queryTokenKey, token)
+		}
+		c.Next()
+	}
+}
+
+// QueryTokenMiddleware Authorization başlığı gönderemeyen istemciler (EventSource, tarayıcı WebSocket) için
+// access_token sorgu parametresiyle gelen token'ı başlığa taşır. Token StripQueryToken tarafından URL'den
+// çıkarılmış olmalıdır; yalnızca akış uç noktalarında AuthMiddleware'den önce kullanılmalıdır.
+func QueryTokenMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.GetHeader("Authorization") == "" {
+			if token := c.GetString(queryTokenKey); token != "" {
+				c.Request.Header.Set("Authorization", "Bearer "+token)
+			}
+		}
+		c.Next()
+	}
+}
+
 // GetCurrentUser context'ten mevcut kullanıcıyı alır
 func GetCurrentUser(c *gin.Context) (model.User, bool) {
 	user, exists := c.Get("user")
@@ -108,6 +164,15 @@ func GetCurrentUserID(c *gin.Context) (uint, bool) {
 	return userID.(uint), true
 }
 
+// GetTokenExpiry context'ten oturum token'ının geçerlilik bitiş zamanını alır
+func GetTokenExpiry(c *gin.Context) (time.Time, bool) {
+	expiresAt, exists := c.Get("tokenExpiresAt")
+	if !exists {
+		return time.Time{}, false
+	}
+	return expiresAt.(time.Time), true
+}
+
 // RoleMiddleware yetki kontrolü için middleware
 type RoleMiddleware struct {
 	roleService *services.RoleService
@@ -0,0 +1,136 @@
+// Package realtime bağlı istemcilere kullanıcı bazında anlık olay iletimi sağlar.
+//
+// Hub olayları aynı süreçteki abonelere dağıtır. Birden fazla uygulama örneği çalışıyorsa
+// Hub'a bir Broker (ör. PostgresBridge) bağlanır; yayınlanan olaylar broker üzerinden tüm
+// örneklere ulaştırılır ve her örnek kendi abonelerine dağıtır.
+package realtime
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+)
+
+// subscriptionBuffer bir abonenin okumadan biriktirebileceği en fazla olay sayısı
+const subscriptionBuffer = 64
+
+// Event bir kullanıcıya iletilen anlık olay. Truncated true ise Data boyut sınırı nedeniyle
+// gönderilmemiştir; istemci ilgili kaydı API üzerinden yeniden okumalıdır.
+type Event struct {
+	Type      string          `json:"type"`
+	UserID    uint            `json:"user_id"`
+	Data      json.RawMessage `json:"data,omitempty"`
+	Truncated bool            `json:"truncated,omitempty"`
+}
+
+// Broker olayları tüm uygulama örneklerine ileten aracı
+type Broker interface {
+	Publish(ctx context.Context, event Event) error
+}
+
+// Hub kullanıcı aboneliklerini tutar ve olayları dağıtır
+type Hub struct {
+	mu          sync.RWMutex
+	subscribers map[uint]map[*Subscription]struct{}
+	broker      Broker
+}
+
+// NewHub yeni bir Hub oluşturur
+func NewHub() *Hub {
+	return &Hub{subscribers: map[uint]map[*Subscription]struct{}{}}
+}
+
+// SetBroker olayların diğer uygulama örneklerine iletileceği aracıyı ayarlar.
+// Broker ayarlandığında Publish olayları yerel abonelere doğrudan değil broker üzerinden dağıtır.
+func (h *Hub) SetBroker(broker Broker) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.broker = broker
+}
+
+// Publish olayı yayınlar. Broker yoksa veya broker hata verirse olay yalnızca yerel abonelere dağıtılır.
+func (h *Hub) Publish(ctx context.Context, event Event) error {
+	h.mu.RLock()
+	broker := h.broker
+	h.mu.RUnlock()
+
+	if broker == nil {
+		h.Dispatch(event)
+		return nil
+	}
+	if err := broker.Publish(ctx, event); err != nil {
+		h.Dispatch(event)
+		return err
+	}
+	return nil
+}
+
+// Dispatch olayı bu süreçteki kullanıcı abonelerine iletir. Arabelleği dolan (olayları okuyamayan)
+// aboneliklerin kanalı kapatılır; istemcinin yeniden bağlanması beklenir.
+func (h *Hub) Dispatch(event Event) {
+	h.mu.RLock()
+	var slow []*Subscription
+	for subscription := range h.subscribers[event.UserID] {
+		select {
+		case subscription.events <- event:
+		default:
+			slow = append(slow, subscription)
+		}
+	}
+	h.mu.RUnlock()
+
+	for _, subscription := range slow {
+		subscription.Close()
+	}
+}
+
+// Subscribe kullanıcı için yeni bir abonelik açar; abonelik işi bitince Close ile kapatılmalıdır
+func (h *Hub) Subscribe(userID uint) *Subscription {
+	subscription := &Subscription{
+		hub:    h,
+		userID: userID,
+		events: make(chan Event, subscriptionBuffer),
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.subscribers[userID] == nil {
+		h.subscribers[userID] = map[*Subscription]struct{}{}
+	}
+	h.subscribers[userID][subscription] = struct{}{}
+	return subscription
+}
+
+// Connections kullanıcının bu süreçteki açık abonelik sayısını döndürür
+func (h *Hub) Connections(userID uint) int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return len(h.subscribers[userID])
+}
+
+// Subscription bir kullanıcının olay aboneliği
+type Subscription struct {
+	hub       *Hub
+	userID    uint
+	events    chan Event
+	closeOnce sync.Once
+}
+
+// Events abonelik olaylarının okunduğu kanal; abonelik kapatıldığında kanal da kapanır
+func (s *Subscription) Events() <-chan Event {
+	return s.events
+}
+
+// Close aboneliği hub'dan çıkarır ve olay kanalını kapatır
+func (s *Subscription) Close() {
+	s.closeOnce.Do(func() {
+		s.hub.mu.Lock()
+		defer s.hub.mu.Unlock()
+
+		delete(s.hub.subscribers[s.userID], s)
+		if len(s.hub.subscribers[s.userID]) == 0 {
+			delete(s.hub.subscribers, s.userID)
+		}
+		close(s.events)
+	})
+}
@@ -0,0 +1,71 @@
+package realtime
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestHubClosesSlowSubscriber(t *testing.T) {
+	hub := NewHub()
+	slow := hub.Subscribe(1)
+	fast := hub.Subscribe(1)
+	other := hub.Subscribe(2)
+
+	// Hızlı abone her olayı okur; yavaş abonenin arabelleği dolunca kanalı kapatılır
+	for i := 0; i <= subscriptionBuffer; i++ {
+		hub.Dispatch(Event{Type: "test", UserID: 1})
+		if _, ok := <-fast.Events(); !ok {
+			t.Fatalf("%d. olayda hızlı abonelik kapatılmamalıydı", i)
+		}
+	}
+
+	received := 0
+	for range slow.Events() {
+		received++
+	}
+	if received != subscriptionBuffer {
+		t.Errorf("yavaş abone %d olay aldı, beklenen %d", received, subscriptionBuffer)
+	}
+	if got := hub.Connections(1); got != 1 {
+		t.Errorf("kullanıcının açık abonelik sayısı %d, beklenen 1", got)
+	}
+
+	// Kapatılmış abonelik yeniden kapatılabilir; diğer kullanıcı etkilenmez
+	slow.Close()
+	hub.Dispatch(Event{Type: "test", UserID: 2})
+	if event := <-other.Events(); event.UserID != 2 {
+		t.Errorf("beklenmeyen olay: %+v", event)
+	}
+
+	fast.Close()
+	other.Close()
+	if hub.Connections(1) != 0 || hub.Connections(2) != 0 {
+		t.Error("tüm abonelikler kapatılmalıydı")
+	}
+}
+
+type failingBroker struct{}
+
+func (failingBroker) Publish(ctx context.Context, event Event) error {
+	return errors.New("broker erişilemez")
+}
+
+func TestHubPublishFallsBackToLocalDispatch(t *testing.T) {
+	hub := NewHub()
+	hub.SetBroker(failingBroker{})
+	subscription := hub.Subscribe(1)
+	defer subscription.Close()
+
+	if err := hub.Publish(context.Background(), Event{Type: "test", UserID: 1}); err == nil {
+		t.Error("broker hatası döndürülmeliydi")
+	}
+	select {
+	case event := <-subscription.Events():
+		if event.Type != "test" {
+			t.Errorf("beklenmeyen olay: %+v", event)
+		}
+	default:
+		t.Error("olay yerel aboneye iletilmeliydi")
+	}
+}
@@ -0,0 +1,116 @@
+package realtime
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"log"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// DefaultPostgresChannel olayların yayınlandığı varsayılan LISTEN/NOTIFY kanalı
+const DefaultPostgresChannel = "realtime_events"
+
+// maxNotifyPayload PostgreSQL NOTIFY yükü için güvenli üst sınır (sunucu sınırı 8000 bayttır)
+const maxNotifyPayload = 7900
+
+// Dinleme bağlantısı koptuğunda yeniden bağlanma bekleme süreleri
+const (
+	minReconnectDelay = time.Second
+	maxReconnectDelay = 30 * time.Second
+)
+
+// PostgresBridge olayları PostgreSQL LISTEN/NOTIFY ile tüm uygulama örnekleri arasında dağıtır.
+// Yayınlama paylaşılan bağlantı havuzuyla, dinleme ise Run içinde açılan ayrı bir bağlantıyla yapılır.
+type PostgresBridge struct {
+	dsn     string
+	channel string
+	db      *sql.DB
+	hub     *Hub
+}
+
+// NewPostgresBridge yeni bir PostgresBridge oluşturur. db yayınlama için kullanılır; dsn dinleme bağlantısı içindir.
+func NewPostgresBridge(dsn, channel string, db *sql.DB, hub *Hub) *PostgresBridge {
+	if channel == "" {
+		channel = DefaultPostgresChannel
+	}
+	return &PostgresBridge{dsn: dsn, channel: channel, db: db, hub: hub}
+}
+
+// Publish olayı NOTIFY ile yayınlar. Boyut sınırını aşan olaylar verisiz (Truncated) gönderilir.
+func (b *PostgresBridge) Publish(ctx context.Context, event Event) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	if len(payload) > maxNotifyPayload {
+		event.Data = nil
+		event.Truncated = true
+		if payload, err = json.Marshal(event); err != nil {
+			return err
+		}
+	}
+
+	_, err = b.db.ExecContext(ctx, "SELECT pg_notify($1, $2)", b.channel, string(payload))
+	return err
+}
+
+// Run kanalı dinler ve gelen olayları hub'daki yerel abonelere dağıtır. Bağlantı koparsa artan
+// bekleme süreleriyle yeniden bağlanır; ctx iptal edilene kadar döner.
+func (b *PostgresBridge) Run(ctx context.Context) error {
+	delay := minReconnectDelay
+	for {
+		started := time.Now()
+		err := b.listen(ctx)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		// Uzun süre ayakta kalan bağlantıdan sonra bekleme süresi baştan başlar
+		if time.Since(started) > maxReconnectDelay {
+			delay = minReconnectDelay
+		}
+		log.Printf("Anlık olay dinleyicisi bağlantısı koptu, %s sonra yeniden bağlanılacak: %v", delay, err)
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(delay):
+		}
+		if delay *= 2; delay > maxReconnectDelay {
+			delay = maxReconnectDelay
+		}
+	}
+}
+
+// listen tek bir dinleme bağlantısı açar ve bağlantı kopana kadar bildirimleri işler
+func (b *PostgresBridge) listen(ctx context.Context) error {
+	conn, err := pgx.Connect(ctx, b.dsn)
+	if err != nil {
+		return err
+	}
+	defer conn.Close(context.Background())
+
+	if _, err := conn.Exec(ctx, "LISTEN "+pgx.Identifier{b.channel}.Sanitize()); err != nil {
+		return err
+	}
+
+	for {
+		notification, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+
+		var event Event
+		if err := json.Unmarshal([]byte(notification.Payload), &event); err != nil {
+			log.Printf("Anlık olay çözümlenemedi: %v", err)
+			continue
+		}
+		if event.Type == "" || event.UserID == 0 {
+			log.Printf("Anlık olay eksik alan içeriyor: %s", notification.Payload)
+			continue
+		}
+		b.hub.Dispatch(event)
+	}
+}
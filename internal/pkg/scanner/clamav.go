@@ -0,0 +1,163 @@
+package scanner
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"time"
+)
+
+// DefaultClamAVTimeout bağlamda süre sınırı yoksa tek bir tarama için beklenen en uzun süre
+const DefaultClamAVTimeout = 2 * time.Minute
+
+const clamAVChunkSize = 64 << 10
+
+// ClamAV clamd ile INSTREAM komutu üzerinden konuşan Scanner uygulaması
+type ClamAV struct {
+	network string
+	address string
+	timeout time.Duration
+}
+
+// NewClamAV clamd istemcisi oluşturur. Adres "unix:///run/clamav/clamd.ctl" veya
+// "tcp://127.0.0.1:3310" biçimindedir; şema verilmezse tcp varsayılır.
+func NewClamAV(address string, timeout time.Duration) (*ClamAV, error) {
+	network := "tcp"
+	switch {
+	case strings.HasPrefix(address, "unix://"):
+		network, address = "unix", strings.TrimPrefix(address, "unix://")
+	case strings.HasPrefix(address, "tcp://"):
+		address = strings.TrimPrefix(address, "tcp://")
+	}
+	if address == "" {
+		return nil, errors.New("clamd adresi boş olamaz")
+	}
+	if timeout <= 0 {
+		timeout = DefaultClamAVTimeout
+	}
+
+	return &ClamAV{network: network, address: address, timeout: timeout}, nil
+}
+
+// Ping clamd'nin erişilebilir olduğunu doğrular
+func (c *ClamAV) Ping(ctx context.Context) error {
+	conn, err := c.dial(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if _, err := conn.Write([]byte("zPING\x00")); err != nil {
+		return err
+	}
+
+	reply, err := readReply(conn)
+	if err != nil {
+		return err
+	}
+	if reply != "PONG" {
+		return fmt.Errorf("beklenmeyen clamd yanıtı: %s", reply)
+	}
+	return nil
+}
+
+// Scan içeriği parçalar halinde clamd'ye gönderir ve sonucu döndürür
+func (c *ClamAV) Scan(ctx context.Context, r io.Reader) (Result, error) {
+	conn, err := c.dial(ctx)
+	if err != nil {
+		return Result{}, err
+	}
+	defer conn.Close()
+
+	// Bağlam iptal edilirse bekleyen okuma ve yazmaları sonlandır
+	stop := context.AfterFunc(ctx, func() { conn.Close() })
+	defer stop()
+
+	if _, err := conn.Write([]byte("zINSTREAM\x00")); err != nil {
+		return Result{}, err
+	}
+
+	if err := writeChunks(conn, r); err != nil {
+		// clamd boyut sınırı aşıldığında bağlantıyı yanıt verip kapatır
+		if reply, readErr := readReply(conn); readErr == nil {
+			return parseReply(reply)
+		}
+		return Result{}, err
+	}
+
+	reply, err := readReply(conn)
+	if err != nil {
+		return Result{}, err
+	}
+	return parseReply(reply)
+}
+
+func (c *ClamAV) dial(ctx context.Context) (net.Conn, error) {
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, c.network, c.address)
+	if err != nil {
+		return nil, fmt.Errorf("clamd bağlantısı kurulamadı: %w", err)
+	}
+
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(c.timeout)
+	}
+	if err := conn.SetDeadline(deadline); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return conn, nil
+}
+
+// writeChunks içeriği 4 baytlık büyük endian uzunluk önekli parçalar halinde yazar
+// ve akışı sıfır uzunluklu parça ile sonlandırır
+func writeChunks(w io.Writer, r io.Reader) error {
+	buf := make([]byte, 4+clamAVChunkSize)
+	for {
+		n, err := r.Read(buf[4:])
+		if n > 0 {
+			binary.BigEndian.PutUint32(buf[:4], uint32(n))
+			if _, werr := w.Write(buf[:4+n]); werr != nil {
+				return werr
+			}
+		}
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err := w.Write([]byte{0, 0, 0, 0})
+	return err
+}
+
+func readReply(r io.Reader) (string, error) {
+	reply, err := bufio.NewReader(r).ReadString(0)
+	if err != nil && !(errors.Is(err, io.EOF) && reply != "") {
+		return "", fmt.Errorf("clamd yanıtı okunamadı: %w", err)
+	}
+	return strings.TrimSpace(strings.TrimRight(reply, "\x00")), nil
+}
+
+// parseReply "stream: OK", "stream: <imza> FOUND" veya "<mesaj> ERROR" yanıtlarını çözümler
+func parseReply(reply string) (Result, error) {
+	reply = strings.TrimPrefix(reply, "stream: ")
+	switch {
+	case reply == "OK":
+		return Result{}, nil
+	case strings.HasSuffix(reply, " FOUND"):
+		return Result{Infected: true, Signature: strings.TrimSuffix(reply, " FOUND")}, nil
+	case strings.HasSuffix(reply, " ERROR"):
+		return Result{}, fmt.Errorf("clamd hatası: %s", strings.TrimSuffix(reply, " ERROR"))
+	default:
+		return Result{}, fmt.Errorf("beklenmeyen clamd yanıtı: %s", reply)
+	}
+}
@@ -0,0 +1,131 @@
+package scanner
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"io"
+	"net"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseReply(t *testing.T) {
+	tests := []struct {
+		reply   string
+		want    Result
+		wantErr bool
+	}{
+		{"stream: OK", Result{}, false},
+		{"stream: Eicar-Test-Signature FOUND", Result{Infected: true, Signature: "Eicar-Test-Signature"}, false},
+		{"INSTREAM size limit exceeded. ERROR", Result{}, true},
+		{"UNKNOWN COMMAND", Result{}, true},
+	}
+	for _, tt := range tests {
+		got, err := parseReply(tt.reply)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("parseReply(%q) = %+v, %v", tt.reply, got, err)
+		}
+	}
+}
+
+// fakeClamd tek bir INSTREAM isteğini okuyup reply yanıtını veren sahte clamd başlatır.
+// Sunucunun aldığı içerik kanaldan okunabilir.
+func fakeClamd(t *testing.T, reply string) (string, <-chan []byte) {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("dinlenemedi: %v", err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	received := make(chan []byte, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		command := make([]byte, len("zINSTREAM\x00"))
+		if _, err := io.ReadFull(conn, command); err != nil || string(command) != "zINSTREAM\x00" {
+			return
+		}
+
+		var body bytes.Buffer
+		for {
+			var size uint32
+			if err := binary.Read(conn, binary.BigEndian, &size); err != nil {
+				return
+			}
+			if size == 0 {
+				break
+			}
+			if _, err := io.CopyN(&body, conn, int64(size)); err != nil {
+				return
+			}
+		}
+		received <- body.Bytes()
+		conn.Write([]byte(reply + "\x00"))
+	}()
+
+	return "tcp://" + listener.Addr().String(), received
+}
+
+func TestClamAVScan(t *testing.T) {
+	content := strings.Repeat("x", clamAVChunkSize+10) // Birden fazla parça gönderilir
+
+	tests := []struct {
+		reply   string
+		want    Result
+		wantErr bool
+	}{
+		{"stream: OK", Result{}, false},
+		{"stream: Eicar-Test-Signature FOUND", Result{Infected: true, Signature: "Eicar-Test-Signature"}, false},
+		{"stream: Can't allocate memory ERROR", Result{}, true},
+	}
+	for _, tt := range tests {
+		address, received := fakeClamd(t, tt.reply)
+		client, err := NewClamAV(address, 5*time.Second)
+		if err != nil {
+			t.Fatalf("istemci oluşturulamadı: %v", err)
+		}
+
+		got, err := client.Scan(context.Background(), strings.NewReader(content))
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("%q: Scan = %+v, %v", tt.reply, got, err)
+		}
+		select {
+		case body := <-received:
+			if string(body) != content {
+				t.Errorf("%q: clamd'ye giden içerik %d bayt, beklenen %d", tt.reply, len(body), len(content))
+			}
+		case <-time.After(time.Second):
+			t.Errorf("%q: clamd içeriği almadı", tt.reply)
+		}
+	}
+}
+
+func TestNewClamAVAddress(t *testing.T) {
+	tests := []struct {
+		address, network, host string
+	}{
+		{"unix:///run/clamav/clamd.ctl", "unix", "/run/clamav/clamd.ctl"},
+		{"tcp://127.0.0.1:3310", "tcp", "127.0.0.1:3310"},
+		{"clamav:3310", "tcp", "clamav:3310"},
+	}
+	for _, tt := range tests {
+		client, err := NewClamAV(tt.address, 0)
+		if err != nil {
+			t.Fatalf("%s: istemci oluşturulamadı: %v", tt.address, err)
+		}
+		if client.network != tt.network || client.address != tt.host || client.timeout != DefaultClamAVTimeout {
+			t.Errorf("%s: %+v", tt.address, client)
+		}
+	}
+	if _, err := NewClamAV("tcp://", 0); err == nil {
+		t.Error("boş adres reddedilmeliydi")
+	}
+}
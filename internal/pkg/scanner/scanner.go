@@ -0,0 +1,27 @@
+// Package scanner yüklenen dosyaları zararlı içeriğe karşı tarayan arka uçlar için ortak arayüzü tanımlar
+package scanner
+
+import (
+	"context"
+	"io"
+)
+
+// Result bir taramanın sonucunu tanımlar
+type Result struct {
+	Infected  bool
+	Signature string // Tespit edilen zararlı içeriğin adı (ör. "Eicar-Test-Signature")
+}
+
+// Scanner dosya içeriğini tarayan arka uç için ortak arayüz.
+// Tarama yapılamadığında hata döner; bu durum dosyanın temiz olduğu anlamına gelmez.
+type Scanner interface {
+	Scan(ctx context.Context, r io.Reader) (Result, error)
+}
+
+// Func sıradan bir fonksiyonu Scanner olarak kullanmayı sağlar (ör. testlerde sahte tarayıcı)
+type Func func(ctx context.Context, r io.Reader) (Result, error)
+
+// Scan f(ctx, r) çağırır
+func (f Func) Scan(ctx context.Context, r io.Reader) (Result, error) {
+	return f(ctx, r)
+}
@@ -0,0 +1,54 @@
+package scheduler
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// Job belirli aralıklarla çalıştırılan arka plan işini tanımlar
+type Job struct {
+	Name     string
+	Interval time.Duration
+	Run      func(ctx context.Context) error
+}
+
+// Scheduler arka plan işlerini periyodik olarak çalıştırır
+type Scheduler struct {
+	jobs []Job
+}
+
+// New yeni bir Scheduler örneği oluşturur
+func New() *Scheduler {
+	return &Scheduler{}
+}
+
+// Every verilen aralıkla çalışacak yeni bir iş ekler
+func (s *Scheduler) Every(name string, interval time.Duration, run func(ctx context.Context) error) {
+	s.jobs = append(s.jobs, Job{Name: name, Interval: interval, Run: run})
+}
+
+// Start tüm işleri ayrı goroutine'lerde başlatır.
+// İşler başlangıçta bir kez, ardından her aralıkta çalıştırılır; ctx iptal edilince durur.
+func (s *Scheduler) Start(ctx context.Context) {
+	for _, job := range s.jobs {
+		go s.loop(ctx, job)
+	}
+}
+
+func (s *Scheduler) loop(ctx context.Context, job Job) {
+	ticker := time.NewTicker(job.Interval)
+	defer ticker.Stop()
+
+	for {
+		if err := job.Run(ctx); err != nil {
+			log.Printf("Zamanlanmış iş başarısız oldu (%s): %v", job.Name, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,162 @@
+// Package shift personel vardiya düzenlerini (Staff.ShiftPattern) çözümler.
+//
+// Düzen noktalı virgülle ayrılmış aralıklardan oluşur: "Mon-Fri 09:00-18:00; Sat 10:00-14:00".
+// Günler tek tek (Mon,Wed), aralık olarak (Mon-Fri) veya "*" ile her gün şeklinde yazılabilir.
+// Bitişi başlangıcından önce olan aralıklar ertesi güne taşar (ör. "* 22:00-06:00").
+package shift
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+var weekdays = map[string]time.Weekday{
+	"sun": time.Sunday,
+	"mon": time.Monday,
+	"tue": time.Tuesday,
+	"wed": time.Wednesday,
+	"thu": time.Thursday,
+	"fri": time.Friday,
+	"sat": time.Saturday,
+}
+
+// window belirli günlerde tekrar eden tek bir vardiya aralığı
+type window struct {
+	days  [7]bool
+	start time.Duration
+	end   time.Duration
+}
+
+// Schedule çözümlenmiş vardiya düzeni. nil Schedule her zaman vardiyada kabul edilir.
+type Schedule struct {
+	windows []window
+}
+
+// Parse vardiya düzenini çözümler. Boş düzen için nil döner.
+func Parse(pattern string) (*Schedule, error) {
+	pattern = strings.TrimSpace(pattern)
+	if pattern == "" {
+		return nil, nil
+	}
+
+	schedule := &Schedule{}
+	for _, part := range strings.Split(pattern, ";") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		fields := strings.Fields(part)
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("geçersiz vardiya aralığı, beklenen format: \"Mon-Fri 09:00-18:00\" (%s)", part)
+		}
+
+		days, err := parseDays(fields[0])
+		if err != nil {
+			return nil, err
+		}
+		start, end, err := parseHours(fields[1])
+		if err != nil {
+			return nil, err
+		}
+
+		schedule.windows = append(schedule.windows, window{days: days, start: start, end: end})
+	}
+
+	if len(schedule.windows) == 0 {
+		return nil, nil
+	}
+	return schedule, nil
+}
+
+// IsOnShift verilen anın vardiya içinde olup olmadığını döndürür. Vardiya saatleri at'in saat
+// dilimindeki duvar saatine göre değerlendirilir; çağıran anı personelin saat dilimine çevirmelidir.
+func (s *Schedule) IsOnShift(at time.Time) bool {
+	if s == nil {
+		return true
+	}
+
+	// Yaz saati geçişlerinde gün 23 veya 25 saat sürdüğünden gece yarısından geçen süre değil saat kullanılır
+	offset := time.Duration(at.Hour())*time.Hour + time.Duration(at.Minute())*time.Minute + time.Duration(at.Second())*time.Second
+	today := at.Weekday()
+	yesterday := (today + 6) % 7
+
+	for _, w := range s.windows {
+		if w.end > w.start {
+			if w.days[today] && offset >= w.start && offset < w.end {
+				return true
+			}
+			continue
+		}
+
+		// Gece yarısını geçen vardiya
+		if (w.days[today] && offset >= w.start) || (w.days[yesterday] && offset < w.end) {
+			return true
+		}
+	}
+	return false
+}
+
+func parseDays(value string) ([7]bool, error) {
+	var days [7]bool
+	if value == "*" {
+		for i := range days {
+			days[i] = true
+		}
+		return days, nil
+	}
+
+	for _, item := range strings.Split(value, ",") {
+		bounds := strings.SplitN(strings.ToLower(strings.TrimSpace(item)), "-", 2)
+		first, ok := weekdays[bounds[0]]
+		if !ok {
+			return days, fmt.Errorf("geçersiz gün: %s", item)
+		}
+		last := first
+		if len(bounds) == 2 {
+			if last, ok = weekdays[bounds[1]]; !ok {
+				return days, fmt.Errorf("geçersiz gün: %s", item)
+			}
+		}
+
+		for day := first; ; day = (day + 1) % 7 {
+			days[day] = true
+			if day == last {
+				break
+			}
+		}
+	}
+	return days, nil
+}
+
+func parseHours(value string) (time.Duration, time.Duration, error) {
+	bounds := strings.SplitN(value, "-", 2)
+	if len(bounds) != 2 {
+		return 0, 0, fmt.Errorf("geçersiz vardiya saatleri, beklenen format: SS:DD-SS:DD (%s)", value)
+	}
+
+	start, err := parseClock(bounds[0])
+	if err != nil {
+		return 0, 0, err
+	}
+	end, err := parseClock(bounds[1])
+	if err != nil {
+		return 0, 0, err
+	}
+	if start == end {
+		return 0, 0, fmt.Errorf("vardiya başlangıç ve bitiş saatleri aynı olamaz (%s)", value)
+	}
+	return start, end, nil
+}
+
+func parseClock(value string) (time.Duration, error) {
+	if value == "24:00" {
+		return 24 * time.Hour, nil
+	}
+	clock, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, fmt.Errorf("geçersiz saat, beklenen format: SS:DD (%s)", value)
+	}
+	return time.Duration(clock.Hour())*time.Hour + time.Duration(clock.Minute())*time.Minute, nil
+}
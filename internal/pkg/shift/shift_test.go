@@ -0,0 +1,75 @@
+package shift
+
+import (
+	"testing"
+	"time"
+)
+
+func TestIsOnShift(t *testing.T) {
+	// 13 Nisan 2026 Pazartesi
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2026, 4, day, hour, minute, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name    string
+		pattern string
+		at      time.Time
+		want    bool
+	}{
+		{"boş düzen her zaman vardiyada", "", at(13, 3, 0), true},
+		{"gündüz vardiyası içinde", "Mon-Fri 09:00-18:00", at(13, 9, 0), true},
+		{"gündüz vardiyası bitişi dahil değil", "Mon-Fri 09:00-18:00", at(13, 18, 0), false},
+		{"hafta sonu", "Mon-Fri 09:00-18:00", at(18, 10, 0), false},
+		{"ikinci aralık", "Mon-Fri 09:00-18:00; Sat 10:00-14:00", at(18, 11, 0), true},
+		{"gece vardiyası başladığı gün", "Mon-Fri 22:00-06:00", at(13, 23, 0), true},
+		{"gece vardiyası ertesi sabah", "Mon-Fri 22:00-06:00", at(14, 5, 59), true},
+		{"gece vardiyası bitişi dahil değil", "Mon-Fri 22:00-06:00", at(14, 6, 0), false},
+		{"cuma gecesi vardiyası cumartesi sabahına taşar", "Mon-Fri 22:00-06:00", at(18, 2, 0), true},
+		{"pazartesi sabahı pazar gecesine ait değil", "Mon-Fri 22:00-06:00", at(13, 2, 0), false},
+		{"gece yarısında biten vardiya", "* 16:00-24:00", at(13, 23, 59), true},
+	}
+	for _, tt := range tests {
+		schedule, err := Parse(tt.pattern)
+		if err != nil {
+			t.Fatalf("%s: düzen çözümlenemedi: %v", tt.name, err)
+		}
+		if got := schedule.IsOnShift(tt.at); got != tt.want {
+			t.Errorf("%s: IsOnShift(%s) = %v, beklenen %v", tt.name, tt.at, got, tt.want)
+		}
+	}
+}
+
+func TestIsOnShiftUsesLocalWallClock(t *testing.T) {
+	istanbul, err := time.LoadLocation("Europe/Istanbul")
+	if err != nil {
+		t.Skipf("saat dilimi yüklenemedi: %v", err)
+	}
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("saat dilimi yüklenemedi: %v", err)
+	}
+
+	schedule, err := Parse("Mon-Fri 22:00-06:00")
+	if err != nil {
+		t.Fatalf("düzen çözümlenemedi: %v", err)
+	}
+
+	// Salı 01:00 UTC İstanbul'da 04:00 (vardiyada), New York'ta Pazartesi 21:00 (vardiya dışı)
+	now := time.Date(2026, 4, 14, 1, 0, 0, 0, time.UTC)
+	if !schedule.IsOnShift(now.In(istanbul)) {
+		t.Error("İstanbul'da gece vardiyasında olmalıydı")
+	}
+	if schedule.IsOnShift(now.In(newYork)) {
+		t.Error("New York'ta vardiya başlamamış olmalıydı")
+	}
+
+	// Yaz saatine geçilen gece (8 Mart 2026, 02:00 → 03:00) gece yarısından 3,5 saat geçmiş olsa da saat 04:30'dur
+	early, err := Parse("* 04:00-05:00")
+	if err != nil {
+		t.Fatalf("düzen çözümlenemedi: %v", err)
+	}
+	if dst := time.Date(2026, 3, 8, 4, 30, 0, 0, newYork); !early.IsOnShift(dst) {
+		t.Error("yaz saati geçişinde 04:30 vardiyada olmalıydı")
+	}
+}
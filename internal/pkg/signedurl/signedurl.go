@@ -0,0 +1,77 @@
+// Package signedurl kimlik doğrulaması gerektirmeden kullanılabilen süreli, HMAC imzalı bağlantılar üretir
+package signedurl
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// İmza doğrulama hataları
+var (
+	ErrInvalidSignature = errors.New("geçersiz bağlantı imzası")
+	ErrExpired          = errors.New("bağlantının süresi dolmuş")
+)
+
+// Signer verilen yol için süreli bağlantıları imzalar ve doğrular
+type Signer struct {
+	secret  []byte
+	baseURL string
+}
+
+// New yeni bir Signer oluşturur. baseURL bağlantıların başına eklenen uygulama adresidir.
+func New(secret, baseURL string) *Signer {
+	return &Signer{
+		secret:  []byte(secret),
+		baseURL: strings.TrimRight(baseURL, "/"),
+	}
+}
+
+// BaseURL bağlantıların başına eklenen uygulama adresini döndürür
+func (s *Signer) BaseURL() string {
+	return s.baseURL
+}
+
+// URL path için expiry süresince geçerli, imzalı mutlak bir bağlantı döndürür
+func (s *Signer) URL(path string, expiry time.Duration) (string, time.Time) {
+	expiresAt := time.Now().Add(expiry).Truncate(time.Second)
+	expires := strconv.FormatInt(expiresAt.Unix(), 10)
+
+	query := url.Values{}
+	query.Set("expires", expires)
+	query.Set("signature", s.sign(path, expires))
+	return s.baseURL + path + "?" + query.Encode(), expiresAt
+}
+
+// Verify istek yolunun ve sorgu parametrelerinin geçerli ve süresi dolmamış bir imza taşıdığını doğrular
+func (s *Signer) Verify(path string, query url.Values) error {
+	expires := query.Get("expires")
+	signature := query.Get("signature")
+	if expires == "" || signature == "" {
+		return ErrInvalidSignature
+	}
+
+	if !hmac.Equal([]byte(signature), []byte(s.sign(path, expires))) {
+		return ErrInvalidSignature
+	}
+
+	unix, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+	if time.Now().After(time.Unix(unix, 0)) {
+		return ErrExpired
+	}
+	return nil
+}
+
+func (s *Signer) sign(path, expires string) string {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte(path + "\n" + expires))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
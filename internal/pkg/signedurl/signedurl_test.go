@@ -0,0 +1,79 @@
+package signedurl
+
+import (
+	"errors"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// signedQuery URL'nin yolunu ve sorgu parametrelerini döndürür
+func signedQuery(t *testing.T, link string) (string, url.Values) {
+	t.Helper()
+
+	parsed, err := url.Parse(link)
+	if err != nil {
+		t.Fatalf("bağlantı çözümlenemedi: %v", err)
+	}
+	return parsed.Path, parsed.Query()
+}
+
+func TestSignerURLVerifies(t *testing.T) {
+	signer := New("secret", "https://example.com/")
+
+	link, expiresAt := signer.URL("/api/v1/files/documents/7", time.Hour)
+	if !strings.HasPrefix(link, "https://example.com/api/v1/files/documents/7?") {
+		t.Errorf("beklenmeyen bağlantı: %s", link)
+	}
+	if until := time.Until(expiresAt); until <= 59*time.Minute || until > time.Hour {
+		t.Errorf("beklenmeyen bitiş zamanı: %s", expiresAt)
+	}
+
+	path, query := signedQuery(t, link)
+	if err := signer.Verify(path, query); err != nil {
+		t.Errorf("geçerli bağlantı doğrulanamadı: %v", err)
+	}
+}
+
+func TestSignerVerifyRejectsExpiredLinks(t *testing.T) {
+	signer := New("secret", "https://example.com")
+
+	link, _ := signer.URL("/api/v1/files/exports/3", -time.Minute)
+	path, query := signedQuery(t, link)
+	if err := signer.Verify(path, query); !errors.Is(err, ErrExpired) {
+		t.Errorf("beklenen hata %v, alınan %v", ErrExpired, err)
+	}
+}
+
+func TestSignerVerifyRejectsTamperedLinks(t *testing.T) {
+	signer := New("secret", "https://example.com")
+	link, _ := signer.URL("/api/v1/files/documents/7", time.Hour)
+	path, query := signedQuery(t, link)
+
+	extended := url.Values{}
+	extended.Set("expires", strconv.FormatInt(time.Now().Add(24*time.Hour).Unix(), 10))
+	extended.Set("signature", query.Get("signature"))
+
+	tests := []struct {
+		name  string
+		path  string
+		query url.Values
+	}{
+		{"başka bir dosya", "/api/v1/files/documents/8", query},
+		{"uzatılmış süre", path, extended},
+		{"imza yok", path, url.Values{"expires": {query.Get("expires")}}},
+		{"süre yok", path, url.Values{"signature": {query.Get("signature")}}},
+	}
+	for _, tt := range tests {
+		if err := signer.Verify(tt.path, tt.query); !errors.Is(err, ErrInvalidSignature) {
+			t.Errorf("%s: beklenen hata %v, alınan %v", tt.name, ErrInvalidSignature, err)
+		}
+	}
+
+	// Farklı anahtarla imzalanmış bağlantı kabul edilmez
+	if err := New("other", "https://example.com").Verify(path, query); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("farklı anahtar için beklenen hata %v, alınan %v", ErrInvalidSignature, err)
+	}
+}
@@ -0,0 +1,194 @@
+package spreadsheet
+
+import (
+	"bufio"
+	"encoding/csv"
+	"errors"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// Desteklenen dosya formatları
+const (
+	FormatCSV  = "csv"
+	FormatXLSX = "xlsx"
+)
+
+const sheetName = "Sheet1"
+
+// ErrUnsupportedFormat desteklenmeyen dosya formatı hatası
+var ErrUnsupportedFormat = errors.New("desteklenmeyen dosya formatı, yalnızca csv ve xlsx kabul edilir")
+
+// FormatFromFilename dosya uzantısından formatı belirler
+func FormatFromFilename(filename string) (string, error) {
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".csv":
+		return FormatCSV, nil
+	case ".xlsx":
+		return FormatXLSX, nil
+	default:
+		return "", ErrUnsupportedFormat
+	}
+}
+
+// ContentType format için HTTP içerik türünü döndürür
+func ContentType(format string) string {
+	if format == FormatXLSX {
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	}
+	return "text/csv; charset=utf-8"
+}
+
+// formulaPrefixes hücrenin formül olarak yorumlanmasına yol açan ilk karakterler
+const formulaPrefixes = "=+-@\t\r"
+
+// EscapeCell formül gibi başlayan değerlerin önüne ' ekler; elektronik tablo programları
+// dışa aktarılan kullanıcı verisini formül olarak çalıştırmaz.
+func EscapeCell(value string) string {
+	if value != "" && strings.ContainsRune(formulaPrefixes, rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
+
+// UnescapeCell EscapeCell ile eklenen ' işaretini kaldırır; dışa aktarılan dosya yeniden içe aktarılabilir
+func UnescapeCell(value string) string {
+	if len(value) > 1 && value[0] == '\'' && strings.ContainsRune(formulaPrefixes, rune(value[1])) {
+		return value[1:]
+	}
+	return value
+}
+
+// ReadRows dosyadaki tüm satırları okur. İlk satır başlık satırıdır.
+func ReadRows(format string, r io.Reader) ([][]string, error) {
+	var rows [][]string
+	switch format {
+	case FormatCSV:
+		reader := csv.NewReader(r)
+		reader.FieldsPerRecord = -1
+		reader.TrimLeadingSpace = true
+		records, err := reader.ReadAll()
+		if err != nil {
+			return nil, err
+		}
+		rows = records
+	case FormatXLSX:
+		file, err := excelize.OpenReader(r)
+		if err != nil {
+			return nil, err
+		}
+		defer file.Close()
+
+		sheets := file.GetSheetList()
+		if len(sheets) == 0 {
+			return nil, errors.New("dosyada çalışma sayfası bulunamadı")
+		}
+		if rows, err = file.GetRows(sheets[0]); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, ErrUnsupportedFormat
+	}
+
+	for _, row := range rows {
+		for i := range row {
+			row[i] = UnescapeCell(row[i])
+		}
+	}
+	return rows, nil
+}
+
+// Writer satırları verilen formatta akış olarak yazar; hücreler EscapeCell ile yazılır
+type Writer interface {
+	WriteRow(values []string) error
+	Close() error
+}
+
+// NewWriter verilen format için yeni bir Writer oluşturur.
+// CSV satırları doğrudan akışa yazılır; XLSX dosyası Close çağrıldığında tamamlanır.
+func NewWriter(format string, w io.Writer) (Writer, error) {
+	switch format {
+	case FormatCSV:
+		buffered := bufio.NewWriter(w)
+		return &csvWriter{buffered: buffered, writer: csv.NewWriter(buffered)}, nil
+	case FormatXLSX:
+		file := excelize.NewFile()
+		stream, err := file.NewStreamWriter(sheetName)
+		if err != nil {
+			file.Close()
+			return nil, err
+		}
+		return &xlsxWriter{out: w, file: file, stream: stream}, nil
+	default:
+		return nil, ErrUnsupportedFormat
+	}
+}
+
+type csvWriter struct {
+	buffered *bufio.Writer
+	writer   *csv.Writer
+	rows     int
+}
+
+func (w *csvWriter) WriteRow(values []string) error {
+	escaped := make([]string, len(values))
+	for i, value := range values {
+		escaped[i] = EscapeCell(value)
+	}
+	if err := w.writer.Write(escaped); err != nil {
+		return err
+	}
+
+	// Büyük dışa aktarımlarda belleği şişirmemek için düzenli olarak boşalt
+	w.rows++
+	if w.rows%500 == 0 {
+		w.writer.Flush()
+		if err := w.writer.Error(); err != nil {
+			return err
+		}
+		return w.buffered.Flush()
+	}
+	return nil
+}
+
+func (w *csvWriter) Close() error {
+	w.writer.Flush()
+	if err := w.writer.Error(); err != nil {
+		return err
+	}
+	return w.buffered.Flush()
+}
+
+type xlsxWriter struct {
+	out    io.Writer
+	file   *excelize.File
+	stream *excelize.StreamWriter
+	rows   int
+}
+
+func (w *xlsxWriter) WriteRow(values []string) error {
+	w.rows++
+	cell, err := excelize.CoordinatesToCellName(1, w.rows)
+	if err != nil {
+		return err
+	}
+
+	row := make([]interface{}, len(values))
+	for i, value := range values {
+		row[i] = EscapeCell(value)
+	}
+	return w.stream.SetRow(cell, row)
+}
+
+func (w *xlsxWriter) Close() error {
+	defer w.file.Close()
+
+	if err := w.stream.Flush(); err != nil {
+		return err
+	}
+	_, err := w.file.WriteTo(w.out)
+	return err
+}
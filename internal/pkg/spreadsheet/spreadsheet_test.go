@@ -0,0 +1,71 @@
+package spreadsheet
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestEscapeCell(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{"=HYPERLINK(\"http://evil\")", "'=HYPERLINK(\"http://evil\")"},
+		{"+90 555 000 00 00", "'+90 555 000 00 00"},
+		{"-2+3", "'-2+3"},
+		{"@SUM(A1)", "'@SUM(A1)"},
+		{"\t=1", "'\t=1"},
+		{"Ayşe", "Ayşe"},
+		{"a=b", "a=b"},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := EscapeCell(tt.value); got != tt.want {
+			t.Errorf("EscapeCell(%q) = %q, beklenen %q", tt.value, got, tt.want)
+		}
+		if got := UnescapeCell(EscapeCell(tt.value)); got != tt.value {
+			t.Errorf("UnescapeCell(EscapeCell(%q)) = %q", tt.value, got)
+		}
+	}
+
+	// Formül karakteriyle devam etmeyen ' işareti kullanıcı verisidir, korunur
+	if got := UnescapeCell("'tırnak"); got != "'tırnak" {
+		t.Errorf("UnescapeCell = %q, ' korunmalıydı", got)
+	}
+}
+
+func TestWriterRoundTrip(t *testing.T) {
+	rows := [][]string{
+		{"email", "first_name"},
+		{"a@example.com", "=cmd|' /C calc'!A0"},
+	}
+
+	for _, format := range []string{FormatCSV, FormatXLSX} {
+		var buf bytes.Buffer
+		writer, err := NewWriter(format, &buf)
+		if err != nil {
+			t.Fatalf("%s: yazıcı oluşturulamadı: %v", format, err)
+		}
+		for _, row := range rows {
+			if err := writer.WriteRow(row); err != nil {
+				t.Fatalf("%s: satır yazılamadı: %v", format, err)
+			}
+		}
+		if err := writer.Close(); err != nil {
+			t.Fatalf("%s: yazıcı kapatılamadı: %v", format, err)
+		}
+
+		if format == FormatCSV && !strings.Contains(buf.String(), "'=cmd") {
+			t.Errorf("csv: formül kaçırılmadı: %q", buf.String())
+		}
+
+		got, err := ReadRows(format, &buf)
+		if err != nil {
+			t.Fatalf("%s: satırlar okunamadı: %v", format, err)
+		}
+		if len(got) != len(rows) || got[1][1] != rows[1][1] {
+			t.Errorf("%s: okunan satırlar %q, beklenen %q", format, got, rows)
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLocalStorageRoundTrip(t *testing.T) {
+	store, err := NewLocalStorage(t.TempDir())
+	if err != nil {
+		t.Fatalf("depolama oluşturulamadı: %v", err)
+	}
+	ctx := context.Background()
+
+	if err := store.Put(ctx, "requests/1/rapor.txt", strings.NewReader("içerik"), 7, "text/plain"); err != nil {
+		t.Fatalf("dosya yazılamadı: %v", err)
+	}
+	reader, err := store.Open(ctx, "requests/1/rapor.txt")
+	if err != nil {
+		t.Fatalf("dosya açılamadı: %v", err)
+	}
+	data, err := io.ReadAll(reader)
+	reader.Close()
+	if err != nil || string(data) != "içerik" {
+		t.Errorf("okunan içerik %q, hata %v", data, err)
+	}
+
+	if err := store.Delete(ctx, "requests/1/rapor.txt"); err != nil {
+		t.Fatalf("dosya silinemedi: %v", err)
+	}
+	if _, err := store.Open(ctx, "requests/1/rapor.txt"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("beklenen hata %v, alınan %v", ErrNotFound, err)
+	}
+	if err := store.Delete(ctx, "requests/1/rapor.txt"); err != nil {
+		t.Errorf("olmayan dosyayı silmek hata vermemeli: %v", err)
+	}
+}
+
+func TestLocalStorageRejectsPathTraversal(t *testing.T) {
+	parent := t.TempDir()
+	root := filepath.Join(parent, "files")
+	store, err := NewLocalStorage(root)
+	if err != nil {
+		t.Fatalf("depolama oluşturulamadı: %v", err)
+	}
+	ctx := context.Background()
+
+	// Kök dizinin kendisi veya boş anahtar geçerli bir dosya değildir
+	for _, key := range []string{"", "/", ".", "..", "../"} {
+		if err := store.Put(ctx, key, strings.NewReader("x"), 1, ""); err == nil {
+			t.Errorf("%q anahtarı reddedilmeliydi", key)
+		}
+	}
+
+	// Üst dizine çıkan anahtarlar kök dizin içinde kalır
+	for _, key := range []string{"../escape.txt", "a/../../escape.txt", "/../../escape.txt"} {
+		if err := store.Put(ctx, key, strings.NewReader("x"), 1, ""); err != nil {
+			t.Fatalf("%q yazılamadı: %v", key, err)
+		}
+		if _, err := os.Stat(filepath.Join(parent, "escape.txt")); !os.IsNotExist(err) {
+			t.Fatalf("%q kök dizin dışına yazıldı", key)
+		}
+		if _, err := os.Stat(filepath.Join(root, "escape.txt")); err != nil {
+			t.Errorf("%q kök dizin içine yazılmadı: %v", key, err)
+		}
+	}
+}
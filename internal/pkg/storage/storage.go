@@ -0,0 +1,25 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+)
+
+// ErrNotFound istenen nesne depolamada bulunamadığında döner
+var ErrNotFound = errors.New("dosya bulunamadı")
+
+// Storage dosyaların saklandığı arka uç için ortak arayüz.
+// Anahtarlar "/" ile ayrılmış göreli yollardır (ör. "requests/12/comments/3/rapor.pdf").
+type Storage interface {
+	Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+}
+
+// URLSigner depolama arka ucunun kendi süreli indirme bağlantılarını üretebildiğini belirtir.
+// Bu arayüzü uygulamayan arka uçlar için bağlantılar uygulama tarafından imzalanır.
+type URLSigner interface {
+	SignedURL(ctx context.Context, key string, expiry time.Duration, fileName string) (string, error)
+}